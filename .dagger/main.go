@@ -19,6 +19,8 @@ import (
 	"dagger/fern-mycelium/internal/dagger"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 )
 
 // FernMycelium defines the reusable Dagger pipeline components
@@ -28,9 +30,23 @@ func (f *FernMycelium) Build(
 	ctx context.Context,
 	// +defaultPath="."
 	src *dagger.Directory,
+	// Semantic version reported by `mycel version`; defaults to
+	// `git describe` of src
+	// +optional
+	version string,
+	// Git commit reported by `mycel version`; defaults to the HEAD of src
+	// +optional
+	commit string,
 ) (*dagger.Container, error) {
 	log.Println("🔨 Building slim Alpine image with counterfeiter (with caching)")
 
+	if version == "" {
+		version = gitOutput(ctx, src, "describe", "--tags", "--always")
+	}
+	if commit == "" {
+		commit = gitOutput(ctx, src, "rev-parse", "--short", "HEAD")
+	}
+
 	builder := dag.Container().
 		From("golang:1.24.3").
 		WithMountedDirectory("/src", src).
@@ -56,7 +72,7 @@ func (f *FernMycelium) Build(
 			"-o", "pkg/repo/fakes/fake_pgx_querier.go",
 			"github.com/guidewire-oss/fern-mycelium/pkg/repo.PgxQuerier",
 		}).
-		WithExec([]string{"go", "build", "-o", "/app/fern-mycelium"})
+		WithExec([]string{"go", "build", "-ldflags", versionLDFlags(version, commit), "-o", "/app/fern-mycelium"})
 
 	runtime := dag.Container().
 		From("alpine:3.20").
@@ -67,6 +83,36 @@ func (f *FernMycelium) Build(
 	return runtime, nil
 }
 
+// versionPackage receives the build metadata printed by `mycel version`.
+const versionPackage = "github.com/guidewire-oss/fern-mycelium/internal/version"
+
+// gitOutput runs git with args in src and returns its trimmed output, or ""
+// when src is not a git checkout, so the build metadata stays "dev".
+func gitOutput(ctx context.Context, src *dagger.Directory, args ...string) string {
+	out, err := dag.Container().
+		From("golang:1.24.3").
+		WithMountedDirectory("/src", src).
+		WithWorkdir("/src").
+		WithExec(append([]string{"git", "-c", "safe.directory=*"}, args...)).
+		Stdout(ctx)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// versionLDFlags injects the build metadata, leaving unset values as "dev".
+func versionLDFlags(version, commit string) string {
+	flags := fmt.Sprintf("-X %s.BuildDate=%s", versionPackage, time.Now().UTC().Format(time.RFC3339))
+	if version != "" {
+		flags += fmt.Sprintf(" -X %s.Version=%s", versionPackage, version)
+	}
+	if commit != "" {
+		flags += fmt.Sprintf(" -X %s.Commit=%s", versionPackage, commit)
+	}
+	return flags
+}
+
 // Scan runs Trivy scan on the built container image
 func (f *FernMycelium) Scan(
 	ctx context.Context,
@@ -74,7 +120,7 @@ func (f *FernMycelium) Scan(
 	src *dagger.Directory,
 ) (string, error) {
 	log.Println("🔍 Running Trivy filesystem scan on built container (with caching)...")
-	container, err := f.Build(ctx, src)
+	container, err := f.Build(ctx, src, "", "")
 	if err != nil {
 		return "", err
 	}
@@ -98,8 +144,11 @@ func (m *FernMycelium) Publish(
 	src *dagger.Directory,
 	version string,
 	githubToken dagger.Secret,
+	// Git commit reported by `mycel version`; defaults to the HEAD of src
+	// +optional
+	commit string,
 ) error {
-	container, err := m.Build(ctx, src)
+	container, err := m.Build(ctx, src, version, commit)
 	if err != nil {
 		return err
 	}
@@ -292,8 +341,16 @@ func (m *FernMycelium) Cosign(ctx context.Context, image string) error {
 	return err
 }
 
-func (m *FernMycelium) Release(ctx context.Context, src *dagger.Directory, version string, githubToken dagger.Secret) error {
-	container, err := m.Build(ctx, src)
+func (m *FernMycelium) Release(
+	ctx context.Context,
+	src *dagger.Directory,
+	version string,
+	githubToken dagger.Secret,
+	// Git commit reported by `mycel version`; defaults to the HEAD of src
+	// +optional
+	commit string,
+) error {
+	container, err := m.Build(ctx, src, version, commit)
 	if err != nil {
 		return err
 	}
@@ -325,7 +382,7 @@ func (m *FernMycelium) Deploy(
 	log.Println("🚀 Deploying to k3d cluster using KubeVela...")
 
 	// Build the container first
-	container, err := m.Build(ctx, src)
+	container, err := m.Build(ctx, src, "", "")
 	if err != nil {
 		return "", fmt.Errorf("failed to build container: %w", err)
 	}
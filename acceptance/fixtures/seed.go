@@ -4,42 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	"github.com/golang-migrate/migrate/v4/source/iofs"
-	"github.com/guidewire/fern-reporter/pkg/db/migrations"
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
 	_ "github.com/lib/pq"
 )
 
-// LoadSchema runs database migrations using fern-reporter's embedded migration files
+// LoadSchema applies fern-reporter's embedded migrations, then fern-mycelium's
+// own, such as the test_quarantines table.
 func LoadSchema(ctx context.Context, dsn string) error {
-	db, err := sql.Open("postgres", dsn)
+	m, err := db.NewMigrator(dsn)
 	if err != nil {
-		return fmt.Errorf("failed to open db: %w", err)
-	}
-	defer db.Close() //nolint:all
-
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-	if err != nil {
-		return fmt.Errorf("failed to create postgres driver: %w", err)
-	}
-
-	sourceDriver, err := iofs.New(migrations.EmbeddedMigrations, ".")
-	if err != nil {
-		return fmt.Errorf("failed to init embedded migration source: %w", err)
-	}
-
-	m, err := migrate.NewWithInstance("iofs", sourceDriver, "postgres", driver)
-	if err != nil {
-		return fmt.Errorf("failed to init migrate instance: %w", err)
+		return err
 	}
+	defer m.Close() //nolint:all
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("migration failed: %w", err)
-	}
-
-	return nil
+	_, err = m.Up()
+	return err
 }
 
 //	func LoadSchema(ctx context.Context, db *pgxpool.Pool) error {
@@ -55,42 +36,189 @@ func LoadSchema(ctx context.Context, dsn string) error {
 //		// _, err = db.Exec(ctx, string(schemaBytes))
 //		// return err
 //	}
+
+// Seeding is retried this many times in total, as the database may still be
+// settling right after the container reports ready.
+const (
+	seedAttempts   = 3
+	seedRetryDelay = 500 * time.Millisecond
+)
+
+// SeedFlakyTests loads the acceptance fixtures in a single transaction, so a
+// failure leaves the database untouched. Rows that already exist are kept,
+// making it safe to run against a database seeded before.
 func SeedFlakyTests(ctx context.Context, dsn string) error {
-	db, err := sql.Open("postgres", dsn)
+	conn, err := sql.Open("postgres", dsn)
 	if err != nil {
 		return fmt.Errorf("failed to open db: %w", err)
 	}
-	defer db.Close() //nolint:all
-
-	statements := []string{
-		`INSERT INTO test_runs (id,  start_time, end_time, git_branch, git_sha, build_trigger_actor, build_url, test_seed)
-     VALUES (1,  CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'abc123', 'tester', 'https://ci.example.com/build/1', 100);`,
-
-		`INSERT INTO suite_runs (id, test_run_id, suite_name, start_time, end_time)
-		 VALUES (1, 1, 'Auth Suite', NOW(), NOW());`,
-
-		`INSERT INTO spec_runs (id, suite_id, spec_description,  status, message, start_time, end_time)
-		 VALUES
-		 (1, 1, 'LoginService handles expired tokens',  'failed', 'message1', NOW(), NOW()),
-		 (2, 1, 'LoginService handles expired tokens',  'failed', 'message2', NOW(), NOW());`,
-
-		`INSERT INTO tags (id, name)
-		 VALUES (1, 'flaky');`,
+	defer conn.Close() //nolint:all
 
-		`INSERT INTO spec_run_tags (spec_run_id, tag_id)
-		 VALUES (1, 1);`,
+	for attempt := 1; ; attempt++ {
+		err = seed(ctx, conn, seedStatements)
+		if err == nil || attempt == seedAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(seedRetryDelay):
+		}
+	}
+}
 
-		`INSERT INTO project_details (id, name, team_name,comment, created_at, updated_at)
-		 VALUES (1, 'demo', 'team-a', 'comment-1', NOW(), NOW());`,
+// seed runs statements in a transaction, rolling back on the first failure.
+func seed(ctx context.Context, conn *sql.DB, statements []string) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin seed transaction: %w", err)
 	}
+	defer tx.Rollback() //nolint:all
 
 	for _, stmt := range statements {
-		if _, err := db.ExecContext(ctx, stmt); err != nil {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
 			return fmt.Errorf("seed statement failed: %w", err)
 		}
 	}
+	return tx.Commit()
+}
+
+// seedStatements insert the acceptance fixtures. Each one skips rows that
+// already exist so seeding can be repeated.
+var seedStatements = []string{
+	`INSERT INTO project_details (id, name, team_name,comment, created_at, updated_at)
+		 VALUES
+		 (1, 'demo', 'team-a', 'comment-1', NOW(), NOW()),
+		 (2, 'billing', 'team-b', 'comment-2', NOW(), NOW()),
+		 (3, 'paging', 'team-b', 'comment-3', NOW(), NOW()),
+		 (4, 'lookback', 'team-c', 'comment-4', NOW(), NOW()),
+		 (5, 'statuses', 'team-c', 'comment-5', NOW(), NOW()),
+		 (6, 'branches', 'team-d', 'comment-6', NOW(), NOW()),
+		 (7, 'suites', 'team-d', 'comment-7', NOW(), NOW()),
+		 (8, 'regressions', 'team-e', 'comment-8', NOW(), NOW()),
+		 (9, 'ingest', 'team-f', 'comment-9', NOW(), NOW()),
+		 (10, 'ties', 'team-g', 'comment-10', NOW(), NOW()),
+		 (11, 'parameterized', 'team-h', 'comment-11', NOW(), NOW()),
+		 (12, 'unowned', NULL, 'comment-12', NOW(), NOW())
+		 ON CONFLICT DO NOTHING;`,
+
+	`INSERT INTO test_runs (id, project_id, start_time, end_time, git_branch, git_sha, build_trigger_actor, build_url, test_seed)
+     VALUES
+     (1, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'abc123', 'tester', 'https://ci.example.com/build/1', 100),
+     (2, 2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'def456', 'tester', 'https://ci.example.com/build/2', 200),
+     (3, 3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'fed789', 'tester', 'https://ci.example.com/build/3', 300),
+     (4, 4, NOW() - INTERVAL '60 days', NOW() - INTERVAL '60 days', 'main', 'old111', 'tester', 'https://ci.example.com/build/4', 400),
+     (5, 4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'new222', 'tester', 'https://ci.example.com/build/5', 500),
+     (6, 5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'sts333', 'tester', 'https://ci.example.com/build/6', 600),
+     (7, 6, NOW() - INTERVAL '2 days', NOW() - INTERVAL '2 days', 'main', 'brn444', 'tester', 'https://ci.example.com/build/7', 700),
+     (8, 6, NOW() - INTERVAL '1 hour', NOW() - INTERVAL '1 hour', 'feature/retry', 'brn555', 'tester', 'https://ci.example.com/build/8', 800),
+     (9, 7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'ste666', 'tester', 'https://ci.example.com/build/9', 900),
+     (10, 8, NOW() - INTERVAL '20 days', NOW() - INTERVAL '20 days', 'main', 'reg777', 'tester', 'https://ci.example.com/build/10', 1000),
+     (11, 8, NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day', 'main', 'reg888', 'tester', 'https://ci.example.com/build/11', 1100),
+     (12, 10, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'tie999', 'tester', 'https://ci.example.com/build/12', 1200),
+     (13, 11, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'main', 'prm000', 'tester', 'https://ci.example.com/build/13', 1300)
+     ON CONFLICT DO NOTHING;`,
+
+	`INSERT INTO suite_runs (id, test_run_id, suite_name, start_time, end_time)
+		 VALUES
+		 (1, 1, 'Auth Suite', NOW(), NOW()),
+		 (2, 2, 'Billing Suite', NOW(), NOW()),
+		 (3, 3, 'Paging Suite', NOW(), NOW()),
+		 (4, 4, 'Lookback Suite', NOW() - INTERVAL '60 days', NOW() - INTERVAL '60 days'),
+		 (5, 5, 'Lookback Suite', NOW(), NOW()),
+		 (6, 6, 'Status Suite', NOW(), NOW()),
+		 (7, 7, 'Branch Suite', NOW() - INTERVAL '2 days', NOW() - INTERVAL '2 days'),
+		 (8, 8, 'Branch Suite', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '1 hour'),
+		 (9, 9, 'Stable Suite', NOW(), NOW()),
+		 (10, 9, 'Shaky Suite', NOW(), NOW()),
+		 (11, 10, 'Regression Suite', NOW() - INTERVAL '20 days', NOW() - INTERVAL '20 days'),
+		 (12, 11, 'Regression Suite', NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day'),
+		 (13, 12, 'Tie Suite', NOW(), NOW()),
+		 (14, 13, 'Parameterized Suite', NOW(), NOW())
+		 ON CONFLICT DO NOTHING;`,
+
+	`INSERT INTO spec_runs (id, suite_id, spec_description,  status, message, start_time, end_time)
+		 VALUES
+		 (1, 1, 'LoginService handles expired tokens',  'failed', 'message1', NOW(), NOW()),
+		 (2, 1, 'LoginService handles expired tokens',  'failed', 'message2', NOW(), NOW()),
+		 (3, 2, 'InvoiceService rounds totals',  'passed', '', NOW(), NOW()),
+		 (4, 2, 'InvoiceService rounds totals',  'failed', 'message3', NOW(), NOW()),
+		 (5, 3, 'Paging spec A',  'failed', 'message4', NOW(), NOW()),
+		 (6, 3, 'Paging spec A',  'failed', 'message4', NOW(), NOW()),
+		 (7, 3, 'Paging spec B',  'failed', 'message5', NOW(), NOW()),
+		 (8, 3, 'Paging spec B',  'passed', '', NOW(), NOW()),
+		 (9, 3, 'Paging spec C',  'passed', '', NOW(), NOW()),
+		 (10, 3, 'Paging spec C',  'passed', '', NOW(), NOW()),
+		 (11, 4, 'Lookback stabilized spec',  'failed', 'message6', NOW() - INTERVAL '60 days', NOW() - INTERVAL '60 days'),
+		 (12, 4, 'Lookback stabilized spec',  'failed', 'message6', NOW() - INTERVAL '60 days', NOW() - INTERVAL '60 days'),
+		 (13, 5, 'Lookback stabilized spec',  'passed', '', NOW(), NOW()),
+		 (14, 5, 'Lookback recent spec',  'failed', 'message7', NOW(), NOW()),
+		 (15, 6, 'Status mixed spec',  'passed', '', NOW(), NOW()),
+		 (16, 6, 'Status mixed spec',  'failed', 'message8', NOW(), NOW()),
+		 (17, 6, 'Status mixed spec',  'skipped', '', NOW(), NOW()),
+		 (18, 6, 'Status mixed spec',  'pending', '', NOW(), NOW()),
+		 (19, 6, 'Status short pass spec',  'pass', '', NOW(), NOW()),
+		 (20, 6, 'Status short pass spec',  'pass', '', NOW(), NOW()),
+		 (21, 7, 'Branch spec',  'failed', 'message9', NOW() - INTERVAL '2 days', NOW() - INTERVAL '2 days'),
+		 (22, 8, 'Branch spec',  'failed', 'message9', NOW() - INTERVAL '1 hour', NOW() - INTERVAL '1 hour'),
+		 (23, 8, 'Branch spec',  'passed', '', NOW() - INTERVAL '30 minutes', NOW() - INTERVAL '30 minutes'),
+		 (24, 9, 'Stable spec',  'passed', '', NOW(), NOW()),
+		 (25, 9, 'Stable spec',  'passed', '', NOW(), NOW()),
+		 (26, 10, 'Shaky spec one',  'passed', '', NOW(), NOW()),
+		 (27, 10, 'Shaky spec one',  'failed', 'message10', NOW(), NOW()),
+		 (28, 10, 'Shaky spec two',  'failed', 'message11', NOW(), NOW()),
+		 (29, 10, 'Shaky spec two',  'failed', 'message11', NOW(), NOW()),
+		 (30, 10, 'Shaky spec two',  'skipped', '', NOW(), NOW()),
+		 (31, 11, 'Regressed spec',  'passed', '', NOW() - INTERVAL '20 days', NOW() - INTERVAL '20 days'),
+		 (32, 11, 'Regressed spec',  'passed', '', NOW() - INTERVAL '20 days', NOW() - INTERVAL '20 days'),
+		 (33, 12, 'Regressed spec',  'failed', 'message12', NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day'),
+		 (34, 12, 'Regressed spec',  'passed', '', NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day'),
+		 (35, 11, 'Steady spec',  'failed', 'message13', NOW() - INTERVAL '20 days', NOW() - INTERVAL '20 days'),
+		 (36, 11, 'Steady spec',  'passed', '', NOW() - INTERVAL '20 days', NOW() - INTERVAL '20 days'),
+		 (37, 12, 'Steady spec',  'failed', 'message13', NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day'),
+		 (38, 12, 'Steady spec',  'passed', '', NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day'),
+		 (39, 12, 'Brand new spec',  'failed', 'message14', NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day'),
+		 (40, 13, 'Tie spec D',  'failed', 'message15', NOW(), NOW()),
+		 (41, 13, 'Tie spec D',  'passed', '', NOW(), NOW()),
+		 (42, 13, 'Tie spec B',  'passed', '', NOW(), NOW()),
+		 (43, 13, 'Tie spec B',  'failed', 'message15', NOW(), NOW()),
+		 (44, 13, 'Tie spec A',  'failed', 'message15', NOW(), NOW()),
+		 (45, 13, 'Tie spec A',  'passed', '', NOW(), NOW()),
+		 (46, 13, 'Tie spec C',  'passed', '', NOW(), NOW()),
+		 (47, 13, 'Tie spec C',  'failed', 'message15', NOW(), NOW()),
+		 (48, 14, 'Token handles [case 1]',  'failed', 'message16', NOW(), NOW()),
+		 (49, 14, 'Token handles [case 1]',  'passed', '', NOW(), NOW()),
+		 (50, 14, 'Token handles [case 2]',  'failed', 'message16', NOW(), NOW()),
+		 (51, 14, 'Token handles [case 3]',  'passed', '', NOW(), NOW()),
+		 (52, 7, 'Branch spec',  'failed', 'message9', NOW() - INTERVAL '3 days', NULL)
+		 ON CONFLICT DO NOTHING;`,
+
+	// test_quarantines ids come from its sequence, so rows are matched
+	// on their content instead of a conflicting key.
+	`INSERT INTO test_quarantines (project_id, test_name, reason, quarantined_by, quarantined_at, expires_at, lifted_at)
+		 SELECT seed.* FROM (VALUES
+		 (7, 'Shaky spec one', 'intermittent timeout', 'alice', NOW() - INTERVAL '1 day', NULL::timestamptz, NULL::timestamptz),
+		 (7, 'Shaky spec two', 'fixed upstream', 'bob', NOW() - INTERVAL '10 days', NULL, NOW() - INTERVAL '2 days'),
+		 (7, 'Stable spec', 'investigating', 'carol', NOW() - INTERVAL '20 days', NOW() - INTERVAL '5 days', NULL)
+		 ) AS seed (project_id, test_name, reason, quarantined_by, quarantined_at, expires_at, lifted_at)
+		 WHERE NOT EXISTS (
+		   SELECT 1 FROM test_quarantines q
+		   WHERE q.project_id = seed.project_id AND q.test_name = seed.test_name AND q.quarantined_by = seed.quarantined_by
+		 );`,
+
+	`INSERT INTO tags (id, name)
+		 VALUES (1, 'flaky')
+		 ON CONFLICT DO NOTHING;`,
+
+	`INSERT INTO spec_run_tags (spec_run_id, tag_id)
+		 VALUES (1, 1)
+		 ON CONFLICT DO NOTHING;`,
 
-	return nil
+	// The rows above set their ids explicitly, so move the sequences past
+	// them for rows inserted later, such as ingested runs.
+	`SELECT setval(pg_get_serial_sequence('test_runs', 'id'), (SELECT MAX(id) FROM test_runs));`,
+	`SELECT setval(pg_get_serial_sequence('suite_runs', 'id'), (SELECT MAX(id) FROM suite_runs));`,
+	`SELECT setval(pg_get_serial_sequence('spec_runs', 'id'), (SELECT MAX(id) FROM spec_runs));`,
 }
 
 // func SeedFlakyTests(ctx context.Context, dsn string) error {
@@ -0,0 +1,445 @@
+package acceptance
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+var _ = Describe("FlakyTests Query", func() {
+	It("should return flaky tests", func() {
+		By("querying the GraphQL endpoint")
+
+		query := `
+			query {
+				flakyTests(limit: 5, projectID: "demo") {
+					testName
+					passRate
+					failureRate
+					runCount
+					lastFailure
+				}
+			}
+		`
+
+		body := postQuery(query)
+
+		var data struct {
+			Data struct {
+				FlakyTests []map[string]any `json:"flakyTests"`
+			} `json:"data"`
+		}
+
+		err := json.Unmarshal(body, &data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data.Data.FlakyTests).ToNot(BeEmpty())
+		Expect(data.Data.FlakyTests[0]["runCount"]).Should(BeNumerically("==", 2))
+		Expect(data.Data.FlakyTests[0]["passRate"]).Should(BeNumerically("==", 0))
+		Expect(data.Data.FlakyTests[0]["failureRate"]).Should(BeNumerically("==", 1))
+	})
+
+	It("should isolate flaky tests between projects", func() {
+		By("querying each seeded project")
+
+		Expect(flakyTestNames(`flakyTests(limit: 10, projectID: "demo")`)).
+			To(ConsistOf("LoginService handles expired tokens"))
+		Expect(flakyTestNames(`flakyTests(limit: 10, projectID: "billing")`)).
+			To(ConsistOf("InvoiceService rounds totals"))
+	})
+
+	It("should filter flaky tests by suite name within a project", func() {
+		Expect(flakyTestNames(`flakyTests(limit: 10, projectID: "demo", suiteName: "Auth Suite")`)).
+			To(ConsistOf("LoginService handles expired tokens"))
+		Expect(flakyTestNames(`flakyTests(limit: 10, projectID: "demo", suiteName: "Billing Suite")`)).
+			To(BeEmpty())
+	})
+})
+
+var _ = Describe("FlakyTests Pagination", func() {
+	It("should page through results with limit and offset", func() {
+		Expect(flakyTestNames(`flakyTests(limit: 2, projectID: "paging")`)).
+			To(Equal([]string{"Paging spec A", "Paging spec B"}))
+		Expect(flakyTestNames(`flakyTests(limit: 2, projectID: "paging", offset: 2)`)).
+			To(Equal([]string{"Paging spec C"}))
+	})
+
+	It("should order specs with equal failure rates by name on every call", func() {
+		// The tie specs all fail half their runs and were seeded out of order.
+		for range 5 {
+			Expect(flakyTestNames(`flakyTests(limit: 10, projectID: "ties")`)).
+				To(Equal([]string{"Tie spec A", "Tie spec B", "Tie spec C", "Tie spec D"}))
+		}
+		Expect(flakyTestNames(`flakyTests(limit: 2, projectID: "ties", offset: 2)`)).
+			To(Equal([]string{"Tie spec C", "Tie spec D"}))
+	})
+
+	It("should apply the default limit when none is given", func() {
+		Expect(flakyTestNames(`flakyTests(projectID: "paging")`)).
+			To(Equal([]string{"Paging spec A", "Paging spec B", "Paging spec C"}))
+	})
+
+	It("should group repeated failure messages with their counts", func() {
+		body := postQuery(`query { flakyTests(limit: 2, projectID: "paging") { testName topFailureMessages { message count } } }`)
+		Expect(body).To(MatchJSON(`{"data":{"flakyTests":[` +
+			`{"testName":"Paging spec A","topFailureMessages":[{"message":"message4","count":2}]},` +
+			`{"testName":"Paging spec B","topFailureMessages":[{"message":"message5","count":1}]}]}}`))
+	})
+
+	DescribeTable("should keep only specs at or above the failure rate threshold",
+		func(threshold string, expected []string) {
+			Expect(flakyTestNames(`flakyTests(limit: 10, projectID: "paging", minFailureRate: ` + threshold + `)`)).
+				To(Equal(expected))
+		},
+		Entry("zero keeps stable specs", "0", []string{"Paging spec A", "Paging spec B", "Paging spec C"}),
+		Entry("half is inclusive", "0.5", []string{"Paging spec A", "Paging spec B"}),
+		Entry("one keeps only always-failing specs", "1", []string{"Paging spec A"}),
+	)
+
+	It("should reject a failure rate threshold above one", func() {
+		body := postQuery(`query { flakyTests(limit: 2, projectID: "paging", minFailureRate: 1.5) { testName } }`)
+		Expect(string(body)).To(ContainSubstring("minFailureRate must be between 0 and 1"))
+	})
+
+	It("should reject a negative offset", func() {
+		body := postQuery(`query { flakyTests(limit: 2, projectID: "paging", offset: -1) { testName } }`)
+		Expect(string(body)).To(ContainSubstring("offset must be non-negative"))
+	})
+	DescribeTable("should report the total number of qualifying specs alongside a page",
+		func(args, expected string) {
+			body := postQuery(`query { flakyTestsPage(` + args + `) { items { testName } totalCount } }`)
+			Expect(body).To(MatchJSON(`{"data":{"flakyTestsPage":` + expected + `}}`))
+		},
+		Entry("beyond the first page", `limit: 1, projectID: "paging"`,
+			`{"items":[{"testName":"Paging spec A"}],"totalCount":3}`),
+		Entry("regardless of the offset", `limit: 2, projectID: "paging", offset: 2`,
+			`{"items":[{"testName":"Paging spec C"}],"totalCount":3}`),
+		Entry("with a failure rate threshold", `limit: 1, projectID: "paging", minFailureRate: 0.5`,
+			`{"items":[{"testName":"Paging spec A"}],"totalCount":2}`),
+		Entry("with a minimum run count", `limit: 1, projectID: "lookback", sinceDays: 90, minRuns: 2`,
+			`{"items":[{"testName":"Lookback stabilized spec"}],"totalCount":1}`),
+		Entry("for an unknown project", `limit: 5, projectID: "missing"`,
+			`{"items":[],"totalCount":0}`),
+	)
+})
+
+var _ = Describe("FlakyTests Connection", func() {
+	type page struct {
+		Edges []struct {
+			Cursor string `json:"cursor"`
+			Node   struct {
+				TestName string `json:"testName"`
+			} `json:"node"`
+		} `json:"edges"`
+		PageInfo struct {
+			HasNextPage bool    `json:"hasNextPage"`
+			EndCursor   *string `json:"endCursor"`
+		} `json:"pageInfo"`
+	}
+
+	fetchPage := func(args string) page {
+		body := postQuery(`query { flakyTestsConnection(` + args + `) {
+			edges { cursor node { testName } }
+			pageInfo { hasNextPage endCursor }
+		} }`)
+
+		var data struct {
+			Data struct {
+				FlakyTestsConnection page `json:"flakyTestsConnection"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed())
+		return data.Data.FlakyTestsConnection
+	}
+
+	It("should walk two pages using the end cursor", func() {
+		first := fetchPage(`projectID: "paging", first: 2`)
+		Expect(first.Edges).To(HaveLen(2))
+		Expect(first.Edges[0].Node.TestName).To(Equal("Paging spec A"))
+		Expect(first.Edges[1].Node.TestName).To(Equal("Paging spec B"))
+		Expect(first.PageInfo.HasNextPage).To(BeTrue())
+		Expect(first.PageInfo.EndCursor).ToNot(BeNil())
+
+		second := fetchPage(`projectID: "paging", first: 2, after: "` + *first.PageInfo.EndCursor + `"`)
+		Expect(second.Edges).To(HaveLen(1))
+		Expect(second.Edges[0].Node.TestName).To(Equal("Paging spec C"))
+		Expect(second.PageInfo.HasNextPage).To(BeFalse())
+	})
+})
+
+var _ = Describe("FlakyTests Lookback Window", func() {
+	type result struct {
+		TestName    string  `json:"testName"`
+		FailureRate float64 `json:"failureRate"`
+		RunCount    int     `json:"runCount"`
+	}
+
+	fetch := func(args string) map[string]result {
+		body := postQuery(`query { flakyTests(` + args + `) { testName failureRate runCount } }`)
+
+		var data struct {
+			Data struct {
+				FlakyTests []result `json:"flakyTests"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed())
+
+		byName := map[string]result{}
+		for _, r := range data.Data.FlakyTests {
+			byName[r.TestName] = r
+		}
+		return byName
+	}
+
+	It("should only count runs inside the default window", func() {
+		results := fetch(`limit: 10, projectID: "lookback"`)
+		Expect(results).To(HaveLen(2))
+		Expect(results["Lookback stabilized spec"].RunCount).To(Equal(1))
+		Expect(results["Lookback stabilized spec"].FailureRate).To(BeNumerically("==", 0))
+		Expect(results["Lookback recent spec"].FailureRate).To(BeNumerically("==", 1))
+	})
+
+	It("should include older runs when the window is widened", func() {
+		results := fetch(`limit: 10, projectID: "lookback", sinceDays: 90`)
+		Expect(results["Lookback stabilized spec"].RunCount).To(Equal(3))
+		Expect(results["Lookback stabilized spec"].FailureRate).To(BeNumerically("~", 2.0/3.0, 0.001))
+	})
+
+	It("should drop single-run specs once a minimum run count is set", func() {
+		Expect(fetch(`limit: 10, projectID: "lookback", sinceDays: 90, minRuns: 1`)).To(HaveLen(2))
+
+		results := fetch(`limit: 10, projectID: "lookback", sinceDays: 90, minRuns: 2`)
+		Expect(results).To(HaveLen(1))
+		Expect(results).To(HaveKey("Lookback stabilized spec"))
+	})
+})
+
+var _ = Describe("FlakyTests Status Handling", func() {
+	It("should exclude skipped and pending runs and accept short pass statuses", func() {
+		body := postQuery(`query { flakyTests(limit: 10, projectID: "statuses") { testName failureRate runCount } }`)
+
+		var data struct {
+			Data struct {
+				FlakyTests []map[string]any `json:"flakyTests"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed())
+		Expect(data.Data.FlakyTests).To(HaveLen(2))
+
+		Expect(data.Data.FlakyTests[0]["testName"]).To(Equal("Status mixed spec"))
+		Expect(data.Data.FlakyTests[0]["runCount"]).To(BeNumerically("==", 2))
+		Expect(data.Data.FlakyTests[0]["failureRate"]).To(BeNumerically("==", 0.5))
+
+		Expect(data.Data.FlakyTests[1]["testName"]).To(Equal("Status short pass spec"))
+		Expect(data.Data.FlakyTests[1]["failureRate"]).To(BeNumerically("==", 0))
+	})
+
+	It("should break each spec's runs down by status, skipped runs included", func() {
+		body := postQuery(`query { flakyTests(limit: 10, projectID: "statuses") { testName statusCounts { passed failed error skipped } } }`)
+		Expect(body).To(MatchJSON(`{"data":{"flakyTests":[` +
+			`{"testName":"Status mixed spec","statusCounts":{"passed":1,"failed":1,"error":0,"skipped":2}},` +
+			`{"testName":"Status short pass spec","statusCounts":{"passed":2,"failed":0,"error":0,"skipped":0}}]}}`))
+	})
+})
+
+var _ = Describe("FlakyTests Name Normalization", func() {
+	It("should keep parameterized specs apart by default", func() {
+		Expect(flakyTestNames(`flakyTests(limit: 10, projectID: "parameterized")`)).
+			To(Equal([]string{"Token handles [case 2]", "Token handles [case 1]", "Token handles [case 3]"}))
+	})
+
+	It("should group parameterized specs when asked to normalize names", func() {
+		body := postQuery(`query { flakyTests(limit: 10, projectID: "parameterized", normalizeNames: true) {
+			testName runCount failureRate topFailureMessages { message count } statusCounts { passed failed }
+		} }`)
+		Expect(body).To(MatchJSON(`{"data":{"flakyTests":[{"testName":"Token handles","runCount":4,"failureRate":0.5,` +
+			`"topFailureMessages":[{"message":"message16","count":2}],"statusCounts":{"passed":2,"failed":2}}]}}`))
+	})
+
+	It("should count the grouped specs for paging", func() {
+		body := postQuery(`query { flakyTestsPage(limit: 10, projectID: "parameterized", normalizeNames: true) { totalCount } }`)
+		Expect(body).To(MatchJSON(`{"data":{"flakyTestsPage":{"totalCount":1}}}`))
+	})
+})
+
+var _ = Describe("FlakyTests Git Context", func() {
+	It("should report the branch and SHA of the latest failure across branches", func() {
+		// A failed run without an end time on main does not count as the latest.
+		body := postQuery(`query { flakyTests(limit: 10, projectID: "branches") { testName lastFailureBranch lastFailureSha } }`)
+		Expect(body).To(MatchJSON(`{"data":{"flakyTests":[` +
+			`{"testName":"Branch spec","lastFailureBranch":"feature/retry","lastFailureSha":"brn555"}]}}`))
+	})
+})
+
+var _ = Describe("FlakyTests Sorting", func() {
+	DescribeTable("should order results by the requested column and direction",
+		func(args string, expected []string) {
+			Expect(flakyTestNames(`flakyTests(` + args + `)`)).To(Equal(expected))
+		},
+		Entry("failure rate descending by default", `limit: 10, projectID: "paging"`,
+			[]string{"Paging spec A", "Paging spec B", "Paging spec C"}),
+		Entry("failure rate ascending", `limit: 10, projectID: "paging", sortOrder: ASC`,
+			[]string{"Paging spec C", "Paging spec B", "Paging spec A"}),
+		Entry("run count descending", `limit: 10, projectID: "lookback", sinceDays: 90, sortBy: RUN_COUNT`,
+			[]string{"Lookback stabilized spec", "Lookback recent spec"}),
+		Entry("run count ascending", `limit: 10, projectID: "lookback", sinceDays: 90, sortBy: RUN_COUNT, sortOrder: ASC`,
+			[]string{"Lookback recent spec", "Lookback stabilized spec"}),
+		Entry("last failure descending", `limit: 10, projectID: "lookback", sinceDays: 90, sortBy: LAST_FAILURE`,
+			[]string{"Lookback recent spec", "Lookback stabilized spec"}),
+		Entry("last failure ascending", `limit: 10, projectID: "lookback", sinceDays: 90, sortBy: LAST_FAILURE, sortOrder: ASC`,
+			[]string{"Lookback stabilized spec", "Lookback recent spec"}),
+		Entry("never-failed specs last by last failure", `limit: 10, projectID: "paging", sortBy: LAST_FAILURE, sortOrder: ASC`,
+			[]string{"Paging spec A", "Paging spec B", "Paging spec C"}),
+	)
+
+	It("should reject an unknown sort field", func() {
+		body := postQuery(`query { flakyTests(limit: 10, projectID: "paging", sortBy: TEST_NAME) { testName } }`)
+		Expect(string(body)).To(ContainSubstring("TEST_NAME"))
+	})
+})
+
+var _ = Describe("FlakyTests Batching", func() {
+	It("should resolve several projects in one document the same as separately", func() {
+		body := postQuery(`query {
+			demo: flakyTests(limit: 10, projectID: "demo") { testName topFailureMessages { message } }
+			billing: flakyTests(limit: 10, projectID: "billing") { testName topFailureMessages { message } }
+			paging: flakyTests(limit: 2, projectID: "paging") { testName }
+		}`)
+
+		var data struct {
+			Data map[string][]struct {
+				TestName string `json:"testName"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed())
+		names := func(alias string) []string {
+			var names []string
+			for _, t := range data.Data[alias] {
+				names = append(names, t.TestName)
+			}
+			return names
+		}
+
+		Expect(names("demo")).To(Equal(flakyTestNames(`flakyTests(limit: 10, projectID: "demo")`)))
+		Expect(names("billing")).To(Equal(flakyTestNames(`flakyTests(limit: 10, projectID: "billing")`)))
+		Expect(names("paging")).To(Equal([]string{"Paging spec A", "Paging spec B"}))
+	})
+})
+
+var _ = Describe("FailureActors Query", func() {
+	It("should count a spec's failing runs by build trigger actor", func() {
+		body := postQuery(`query { failureActors(projectID: "branches", testName: "Branch spec") { actor count } }`)
+		Expect(body).To(MatchJSON(`{"data":{"failureActors":[{"actor":"tester","count":3}]}}`))
+	})
+
+	It("should return an empty list for a spec that never failed", func() {
+		body := postQuery(`query { failureActors(projectID: "suites", testName: "Stable spec") { actor count } }`)
+		Expect(body).To(MatchJSON(`{"data":{"failureActors":[]}}`))
+	})
+})
+
+var _ = Describe("TopFailingTests Query", func() {
+	type result struct {
+		TestName    string  `json:"testName"`
+		ProjectName string  `json:"projectName"`
+		FailureRate float64 `json:"failureRate"`
+	}
+
+	It("should rank failing specs across projects and attribute each to its project", func() {
+		body := postQuery(`query { topFailingTests(limit: 50) { testName projectName failureRate } }`)
+
+		var data struct {
+			Data struct {
+				TopFailingTests []result `json:"topFailingTests"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed())
+
+		tests := data.Data.TopFailingTests
+		Expect(tests).To(ContainElement(result{TestName: "LoginService handles expired tokens", ProjectName: "demo", FailureRate: 1}))
+		Expect(tests).To(ContainElement(result{TestName: "InvoiceService rounds totals", ProjectName: "billing", FailureRate: 0.5}))
+		Expect(tests).ToNot(ContainElement(HaveField("TestName", "Paging spec C")))
+		for i := 1; i < len(tests); i++ {
+			Expect(tests[i].FailureRate).To(BeNumerically("<=", tests[i-1].FailureRate))
+		}
+	})
+})
+
+var _ = Describe("CoFailingTests", func() {
+	It("should not pair specs whose shared failures are explained by chance", func() {
+		// Every tie spec fails in the project's only run, so each pair has a
+		// lift of exactly 1.
+		body := postQuery(`query { coFailingTests(projectID: "ties") { testA testB } }`)
+		Expect(body).To(MatchJSON(`{"data":{"coFailingTests":[]}}`))
+	})
+
+	It("should reject an out-of-range limit", func() {
+		body := postQuery(`query { coFailingTests(projectID: "ties", limit: 0) { testA } }`)
+		Expect(string(body)).To(ContainSubstring("limit must be between 1 and"))
+	})
+})
+
+// flakyTestNames runs the given flakyTests field selection and returns the test names.
+func flakyTestNames(field string) []string {
+	body := postQuery(`query { ` + field + ` { testName } }`)
+
+	var data struct {
+		Data struct {
+			FlakyTests []struct {
+				TestName string `json:"testName"`
+			} `json:"flakyTests"`
+		} `json:"data"`
+	}
+	Expect(json.Unmarshal(body, &data)).To(Succeed())
+
+	names := make([]string, 0, len(data.Data.FlakyTests))
+	for _, t := range data.Data.FlakyTests {
+		names = append(names, t.TestName)
+	}
+	return names
+}
+
+// postQuery sends a GraphQL query to the server and returns the raw response body.
+func postQuery(query string) []byte {
+	reqBody, err := json.Marshal(map[string]string{
+		"query": query,
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(serverURL(), "application/json", bytes.NewBuffer(reqBody))
+	Expect(err).ToNot(HaveOccurred())
+	defer resp.Body.Close() //nolint:all
+
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+	body, err := io.ReadAll(resp.Body)
+	Expect(err).ToNot(HaveOccurred())
+	return body
+}
+
+func serverURL() string {
+	url := os.Getenv("SERVER_URL")
+	if url != "" {
+		return url
+	}
+	// fallback if not running against external server
+	return Server.URL + "/query"
+}
+
+var _ = Describe("NewlyFlakyTests Query", func() {
+	It("should return specs that were stable in the baseline but fail recently", func() {
+		body := postQuery(`query { newlyFlakyTests(projectID: "regressions", recentDays: 7, baselineDays: 30) { testName failureRate runCount } }`)
+		Expect(body).To(MatchJSON(`{"data":{"newlyFlakyTests":[{"testName":"Regressed spec","failureRate":0.5,"runCount":2}]}}`))
+	})
+
+	It("should honour a higher minimum increase", func() {
+		body := postQuery(`query { newlyFlakyTests(projectID: "regressions", recentDays: 7, baselineDays: 30, minIncrease: 0.6) { testName } }`)
+		Expect(body).To(MatchJSON(`{"data":{"newlyFlakyTests":[]}}`))
+	})
+})
@@ -0,0 +1,153 @@
+package acceptance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ = Describe("Health check", func() {
+	healthz := func(pool *pgxpool.Pool) (int, string) {
+		router, err := server.NewRouter(server.Config{}, &resolvers.Resolver{DB: pool},
+			metrics.New(prometheus.NewRegistry()), nil)
+		Expect(err).NotTo(HaveOccurred())
+		srv := httptest.NewServer(router)
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL + "/healthz")
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close() //nolint:all
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+
+	It("should report healthy while the database is reachable", func() {
+		pool, err := pgxpool.New(context.Background(), os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		defer pool.Close()
+
+		status, body := healthz(pool)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`{"status":"ok","message":"fern-mycelium is healthy 🍄"}`))
+	})
+
+	It("should report degraded once the pool is closed", func() {
+		pool, err := pgxpool.New(context.Background(), os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		pool.Close()
+
+		status, body := healthz(pool)
+		Expect(status).To(Equal(http.StatusServiceUnavailable))
+		Expect(body).To(MatchJSON(`{"status":"degraded","error":"database unreachable"}`))
+	})
+})
+
+var _ = Describe("Pool draining", func() {
+	It("should wait for in-flight queries before closing the pool", func() {
+		pool, err := pgxpool.New(context.Background(), os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		conn, err := pool.Acquire(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		Expect(db.CloseContext(ctx, pool)).To(MatchError(context.DeadlineExceeded))
+		Expect(pool.Ping(context.Background())).To(MatchError(ContainSubstring("closed pool")))
+
+		// The held connection is still usable until it is released.
+		Expect(conn.Ping(context.Background())).To(Succeed())
+		conn.Release()
+		Eventually(func() int32 { return pool.Stat().TotalConns() }).Should(BeZero())
+	})
+})
+
+var _ = Describe("Statement timeout", func() {
+	It("should have Postgres cancel statements running past DB_STATEMENT_TIMEOUT", func() {
+		GinkgoT().Setenv("DB_STATEMENT_TIMEOUT", "100ms")
+		cfg, err := db.LoadConfig()
+		Expect(err).ToNot(HaveOccurred())
+		pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
+		Expect(err).ToNot(HaveOccurred())
+		defer pool.Close()
+
+		start := time.Now()
+		_, err = pool.Exec(context.Background(), "SELECT pg_sleep(5)")
+		var pgErr *pgconn.PgError
+		Expect(errors.As(err, &pgErr)).To(BeTrue())
+		Expect(pgErr.Code).To(Equal("57014"))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+
+		var timeout string
+		Expect(pool.QueryRow(context.Background(), "SHOW statement_timeout").Scan(&timeout)).To(Succeed())
+		Expect(timeout).To(Equal("100ms"))
+	})
+})
+
+var _ = Describe("Health Query", func() {
+	queryHealth := func(pool *pgxpool.Pool) map[string]any {
+		router, err := server.NewRouter(server.Config{}, &resolvers.Resolver{DB: pool, Schema: repo.NewSchemaVersionRepo(pool)},
+			metrics.New(prometheus.NewRegistry()), nil)
+		Expect(err).NotTo(HaveOccurred())
+		srv := httptest.NewServer(router)
+		defer srv.Close()
+
+		resp, err := http.Post(srv.URL+"/query", "application/json",
+			strings.NewReader(`{"query":"{ health { status database schemaVersion } }"}`))
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close() //nolint:all
+
+		var data struct {
+			Data struct {
+				Health map[string]any `json:"health"`
+			} `json:"data"`
+		}
+		Expect(json.NewDecoder(resp.Body).Decode(&data)).To(Succeed())
+		return data.Data.Health
+	}
+
+	It("should report the database and the applied schema version", func() {
+		pool, err := pgxpool.New(context.Background(), os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		defer pool.Close()
+
+		m, err := db.NewMigrator(os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		defer m.Close() //nolint:all
+		version, _, err := m.Version()
+		Expect(err).ToNot(HaveOccurred())
+
+		health := queryHealth(pool)
+		Expect(health).To(HaveKeyWithValue("status", "ok"))
+		Expect(health).To(HaveKeyWithValue("database", "ok"))
+		Expect(health).To(HaveKeyWithValue("schemaVersion", BeNumerically("==", version)))
+	})
+
+	It("should report the database as unreachable once the pool is closed", func() {
+		pool, err := pgxpool.New(context.Background(), os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		pool.Close()
+
+		Expect(queryHealth(pool)).To(Equal(map[string]any{
+			"status": "degraded", "database": "unreachable", "schemaVersion": nil,
+		}))
+	})
+})
@@ -0,0 +1,61 @@
+package acceptance
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+var _ = Describe("IngestTestRun Mutation", func() {
+	type response struct {
+		Data struct {
+			IngestTestRun *struct {
+				TestRunID   string   `json:"testRunID"`
+				SuiteRunIDs []string `json:"suiteRunIDs"`
+				SpecRunIDs  []string `json:"specRunIDs"`
+			} `json:"ingestTestRun"`
+		} `json:"data"`
+		Errors []struct {
+			Message    string         `json:"message"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"errors"`
+	}
+
+	ingest := func(projectID string) response {
+		start := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		end := time.Now().Add(-time.Hour + time.Minute).UTC().Format(time.RFC3339)
+		spec := func(status string) string {
+			return fmt.Sprintf(`{specDescription: "Ingested spec", status: %q, startTime: %q, endTime: %q}`, status, start, end)
+		}
+		body := postQuery(fmt.Sprintf(`mutation { ingestTestRun(input: {
+			projectID: %q, startTime: %q, endTime: %q, gitBranch: "main", gitSha: "ing999",
+			suites: [{suiteName: "Ingest Suite", startTime: %q, endTime: %q, specs: [%s, %s]}]
+		}) { testRunID suiteRunIDs specRunIDs } }`, projectID, start, end, start, end, spec("failed"), spec("passed")))
+
+		var resp response
+		Expect(json.Unmarshal(body, &resp)).To(Succeed())
+		return resp
+	}
+
+	It("should store the run and report it in flaky test results", func() {
+		resp := ingest("ingest")
+		Expect(resp.Errors).To(BeEmpty())
+		Expect(resp.Data.IngestTestRun.TestRunID).ToNot(BeEmpty())
+		Expect(resp.Data.IngestTestRun.SuiteRunIDs).To(HaveLen(1))
+		Expect(resp.Data.IngestTestRun.SpecRunIDs).To(HaveLen(2))
+
+		body := postQuery(`query { flakyTests(limit: 5, projectID: "ingest") { testName failureRate runCount lastFailureSha } }`)
+		Expect(body).To(MatchJSON(`{"data":{"flakyTests":[` +
+			`{"testName":"Ingested spec","failureRate":0.5,"runCount":2,"lastFailureSha":"ing999"}]}}`))
+	})
+
+	It("should reject an unknown project without storing anything", func() {
+		resp := ingest("no-such-project")
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Message).To(ContainSubstring("project not found"))
+		Expect(resp.Errors[0].Extensions).To(HaveKeyWithValue("code", "NOT_FOUND"))
+	})
+})
@@ -0,0 +1,129 @@
+package acceptance
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/acceptance/fixtures"
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire/fern-reporter/pkg/db/migrations"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// latestMigration returns the highest version among the embedded migrations.
+func latestMigration() uint {
+	entries, err := fs.ReadDir(migrations.EmbeddedMigrations, ".")
+	Expect(err).ToNot(HaveOccurred())
+
+	var latest uint
+	for _, entry := range entries {
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found {
+			continue
+		}
+		if v, err := strconv.ParseUint(prefix, 10, 64); err == nil && uint(v) > latest {
+			latest = uint(v)
+		}
+	}
+	return latest
+}
+
+var _ = Describe("Migrator", func() {
+	It("should apply every migration to an empty database and report its version", func() {
+		conn, err := sql.Open("postgres", os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		defer conn.Close() //nolint:all
+
+		_, err = conn.Exec(`CREATE DATABASE migrate_test`)
+		Expect(err).ToNot(HaveOccurred())
+
+		m, err := db.NewMigrator(strings.Replace(os.Getenv("DB_URL"), "/fern?", "/migrate_test?", 1))
+		Expect(err).ToNot(HaveOccurred())
+		defer m.Close() //nolint:all
+
+		version, _, err := m.Version()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(BeZero())
+
+		changed, err := m.Up()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		version, dirty, err := m.Version()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dirty).To(BeFalse())
+		Expect(version).To(Equal(latestMigration()))
+
+		myceliumVersion, _, err := m.MyceliumVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(myceliumVersion).To(BeNumerically(">=", 1))
+
+		changed, err = m.Up()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+})
+
+var _ = Describe("Serving with --migrate", func() {
+	It("should migrate an empty database on boot and serve flaky test queries", func() {
+		conn, err := sql.Open("postgres", os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		defer conn.Close() //nolint:all
+
+		_, err = conn.Exec(`CREATE DATABASE serve_migrate_test`)
+		Expect(err).ToNot(HaveOccurred())
+		dsn := strings.Replace(os.Getenv("DB_URL"), "/fern?", "/serve_migrate_test?", 1)
+		GinkgoT().Setenv("DB_URL", dsn)
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		addr := ln.Addr().String()
+		Expect(ln.Close()).To(Succeed())
+
+		cfg, err := server.LoadConfig()
+		Expect(err).ToNot(HaveOccurred())
+		cfg.Addr = addr
+		cfg.Migrate = true
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		done := make(chan error, 1)
+		go func() {
+			done <- server.StartWithContext(ctx, cfg)
+		}()
+
+		Eventually(func() (int, error) {
+			resp, err := http.Get("http://" + addr + "/readyz")
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close() //nolint:all
+			return resp.StatusCode, nil
+		}, 30*time.Second, 100*time.Millisecond).Should(Equal(http.StatusOK))
+
+		Expect(fixtures.SeedFlakyTests(ctx, dsn)).To(Succeed())
+
+		body := `{"query":"{ flakyTests(limit: 10, projectID: \"demo\") { testName } }"}`
+		resp, err := http.Post("http://"+addr+"/query", "application/json", strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close() //nolint:all
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		raw, err := io.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(raw)).ToNot(ContainSubstring(`"errors"`))
+		Expect(string(raw)).To(ContainSubstring(`"testName"`))
+
+		cancel()
+		Eventually(done, 30*time.Second).Should(Receive(BeNil()))
+	})
+})
@@ -0,0 +1,56 @@
+package acceptance
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ = Describe("TestOwner Query", func() {
+	It("should route a spec to the team of its project", func() {
+		body := postQuery(`query { testOwner(projectID: "demo", testName: "LoginService handles expired tokens") {
+			projectID testName team source rule } }`)
+
+		Expect(body).To(MatchJSON(`{"data":{"testOwner":{"projectID":"demo","testName":"LoginService handles expired tokens",` +
+			`"team":"team-a","source":"PROJECT","rule":null}}}`))
+	})
+
+	It("should report an unknown owner for a project without a team", func() {
+		body := postQuery(`query { testOwner(projectID: "unowned", testName: "Anything") { team source } }`)
+
+		Expect(body).To(MatchJSON(`{"data":{"testOwner":{"team":null,"source":"UNKNOWN"}}}`))
+	})
+
+	It("should report an unknown owner for an unknown project", func() {
+		body := postQuery(`query { testOwner(projectID: "does-not-exist", testName: "Anything") { team source } }`)
+
+		Expect(body).To(MatchJSON(`{"data":{"testOwner":{"team":null,"source":"UNKNOWN"}}}`))
+	})
+
+	It("should match owners file rules against the seeded suites", func() {
+		owners, err := repo.ParseOwners(strings.NewReader("Shaky Suite team-stability\n"))
+		Expect(err).NotTo(HaveOccurred())
+		pool, err := pgxpool.New(context.Background(), os.Getenv("DB_URL"))
+		Expect(err).NotTo(HaveOccurred())
+		defer pool.Close()
+		ownership := repo.NewOwnershipRepo(pool, owners)
+
+		owner, err := ownership.GetTestOwner(context.Background(), "suites", "Shaky spec two")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owner.Source).To(Equal(gql.OwnershipSourceOwnersFile))
+		Expect(*owner.Team).To(Equal("team-stability"))
+
+		// "Stable spec" only ran in Stable Suite, so it stays with team-d.
+		owner, err = ownership.GetTestOwner(context.Background(), "suites", "Stable spec")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(owner.Source).To(Equal(gql.OwnershipSourceProject))
+		Expect(*owner.Team).To(Equal("team-d"))
+	})
+})
@@ -0,0 +1,56 @@
+package acceptance
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+var _ = Describe("Projects Query", func() {
+	type project struct {
+		ID       string  `json:"id"`
+		Name     string  `json:"name"`
+		TeamName *string `json:"teamName"`
+	}
+
+	listProjects := func(args string) []project {
+		body := postQuery(`query { projects` + args + ` { id name teamName } }`)
+
+		var data struct {
+			Data struct {
+				Projects []project `json:"projects"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed())
+		return data.Data.Projects
+	}
+
+	It("should list every seeded project by name", func() {
+		projects := listProjects("")
+
+		var names []string
+		for _, p := range projects {
+			Expect(p.ID).ToNot(BeEmpty())
+			names = append(names, p.Name)
+		}
+		Expect(names).To(Equal([]string{"billing", "branches", "demo", "ingest", "lookback", "paging", "parameterized", "regressions", "statuses", "suites", "ties", "unowned"}))
+	})
+
+	It("should filter projects by team", func() {
+		projects := listProjects(`(teamName: "team-b")`)
+
+		Expect(projects).To(HaveLen(2))
+		Expect(projects[0].Name).To(Equal("billing"))
+		Expect(projects[1].Name).To(Equal("paging"))
+		Expect(*projects[0].TeamName).To(Equal("team-b"))
+	})
+
+	It("should return project IDs usable as a projectID", func() {
+		projects := listProjects(`(teamName: "team-a")`)
+		Expect(projects).To(HaveLen(1))
+
+		Expect(flakyTestNames(`flakyTests(limit: 10, projectID: "` + projects[0].ID + `")`)).
+			To(ConsistOf("LoginService handles expired tokens"))
+	})
+})
@@ -0,0 +1,85 @@
+package acceptance
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+var _ = Describe("QuarantineTest Mutation", func() {
+	type response struct {
+		Data struct {
+			QuarantineTest *struct {
+				ID            string  `json:"id"`
+				TestName      string  `json:"testName"`
+				Reason        *string `json:"reason"`
+				QuarantinedAt string  `json:"quarantinedAt"`
+			} `json:"quarantineTest"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	quarantine := func(args string) response {
+		body := postQuery(`mutation { quarantineTest(` + args + `) { id testName reason quarantinedAt } }`)
+
+		var resp response
+		Expect(json.Unmarshal(body, &resp)).To(Succeed())
+		return resp
+	}
+
+	It("should quarantine a test once and reject a duplicate", func() {
+		first := quarantine(`projectID: "billing", testName: "InvoiceService rounds totals", reason: "rounding race"`)
+		Expect(first.Errors).To(BeEmpty())
+		Expect(first.Data.QuarantineTest.ID).ToNot(BeEmpty())
+		Expect(first.Data.QuarantineTest.TestName).To(Equal("InvoiceService rounds totals"))
+		Expect(*first.Data.QuarantineTest.Reason).To(Equal("rounding race"))
+		Expect(first.Data.QuarantineTest.QuarantinedAt).ToNot(BeEmpty())
+
+		duplicate := quarantine(`projectID: "billing", testName: "InvoiceService rounds totals"`)
+		Expect(duplicate.Errors).To(HaveLen(1))
+		Expect(duplicate.Errors[0].Message).To(ContainSubstring("already quarantined"))
+	})
+
+	It("should reject an unknown project", func() {
+		resp := quarantine(`projectID: "no-such-project", testName: "anything"`)
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Message).To(ContainSubstring("project not found"))
+	})
+})
+
+var _ = Describe("QuarantinedTests Query", func() {
+	type quarantined struct {
+		TestName      string `json:"testName"`
+		QuarantinedBy string `json:"quarantinedBy"`
+		Active        bool   `json:"active"`
+	}
+
+	list := func(args string) []quarantined {
+		body := postQuery(`query { quarantinedTests(` + args + `) { testName quarantinedBy active } }`)
+
+		var data struct {
+			Data struct {
+				QuarantinedTests []quarantined `json:"quarantinedTests"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed())
+		return data.Data.QuarantinedTests
+	}
+
+	It("should list only active quarantines by default", func() {
+		Expect(list(`projectID: "suites"`)).To(Equal([]quarantined{
+			{TestName: "Shaky spec one", QuarantinedBy: "alice", Active: true},
+		}))
+	})
+
+	It("should include lifted and expired quarantines when asked", func() {
+		Expect(list(`projectID: "suites", includeInactive: true`)).To(Equal([]quarantined{
+			{TestName: "Shaky spec one", QuarantinedBy: "alice", Active: true},
+			{TestName: "Shaky spec two", QuarantinedBy: "bob", Active: false},
+			{TestName: "Stable spec", QuarantinedBy: "carol", Active: false},
+		}))
+	})
+})
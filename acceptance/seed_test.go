@@ -0,0 +1,38 @@
+package acceptance
+
+import (
+	"context"
+	"database/sql"
+	"os"
+
+	"github.com/guidewire-oss/fern-mycelium/acceptance/fixtures"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fixture seeding", func() {
+	// rowCounts returns the number of rows in every seeded table.
+	rowCounts := func() map[string]int {
+		conn, err := sql.Open("postgres", os.Getenv("DB_URL"))
+		Expect(err).ToNot(HaveOccurred())
+		defer conn.Close() //nolint:all
+
+		counts := map[string]int{}
+		for _, table := range []string{"project_details", "test_runs", "suite_runs", "spec_runs", "test_quarantines", "tags", "spec_run_tags"} {
+			var count int
+			Expect(conn.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)).To(Succeed())
+			counts[table] = count
+		}
+		return counts
+	}
+
+	It("should leave an already seeded database unchanged when run again", func() {
+		before := rowCounts()
+		Expect(before["spec_runs"]).To(BeNumerically(">", 0))
+
+		Expect(fixtures.SeedFlakyTests(context.Background(), os.Getenv("DB_URL"))).To(Succeed())
+		Expect(fixtures.SeedFlakyTests(context.Background(), os.Getenv("DB_URL"))).To(Succeed())
+
+		Expect(rowCounts()).To(Equal(before))
+	})
+})
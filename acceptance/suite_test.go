@@ -12,6 +12,7 @@ import (
 	"github.com/guidewire-oss/fern-mycelium/acceptance/fixtures"
 	"github.com/guidewire-oss/fern-mycelium/internal/gql"
 	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/loader"
 	"github.com/guidewire-oss/fern-mycelium/internal/server"
 	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -61,13 +62,35 @@ var _ = BeforeSuite(func() {
 	Expect(expectSchema).To(Succeed())
 	Expect(fixtures.SeedFlakyTests(ctx, dsn)).To(Succeed())
 
-	repo := repo.NewFlakyTestRepo(dbpool)
-	schema := gql.NewExecutableSchema(gql.Config{Resolvers: &resolvers.Resolver{FlakyRepo: repo}})
-	handler := server.NewGraphQLServer(schema)
+	flakyRepo := repo.NewFlakyTestRepo(dbpool)
+	schema := gql.NewExecutableSchema(gql.Config{Resolvers: &resolvers.Resolver{
+		FlakyRepo:      loader.NewProvider(flakyRepo),
+		FlakyPager:     flakyRepo,
+		FlakyCounter:   flakyRepo,
+		SlowRepo:       repo.NewSlowTestRepo(dbpool),
+		OutlierRepo:    repo.NewSlowTestRepo(dbpool),
+		TrendRepo:      repo.NewTrendRepo(dbpool),
+		ProjectRepo:    repo.NewProjectRepo(dbpool),
+		SuiteRepo:      repo.NewSuiteHealthRepo(dbpool),
+		TeamRepo:       repo.NewTeamHealthRepo(dbpool),
+		OwnerRepo:      repo.NewOwnershipRepo(dbpool, nil),
+		HealthScores:   flakyRepo,
+		TopFailing:     flakyRepo,
+		TestImpact:     flakyRepo,
+		NewlyFlaky:     flakyRepo,
+		ActorRepo:      flakyRepo,
+		CoFailures:     flakyRepo,
+		TestRunRepo:    repo.NewTestRunRepo(dbpool),
+		HistoryRepo:    repo.NewTestRunRepo(dbpool),
+		QuarantineRepo: repo.NewQuarantineRepo(dbpool),
+		IngestRepo:     repo.NewIngestRepo(dbpool),
+		FlakyBatcher:   flakyRepo,
+	}})
+	handler := server.NewGraphQLServer(server.Config{}, schema)
 
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.Default()
-	r.POST("/query", gin.WrapH(handler))
+	r.POST("/query", server.FlakyTestLoader(flakyRepo, loader.DefaultWait), gin.WrapH(handler))
 	Server = httptest.NewServer(r)
 })
 
@@ -0,0 +1,75 @@
+package acceptance
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+var _ = Describe("SuiteHealth Query", func() {
+	It("should roll up runs, failures and flaky specs per suite", func() {
+		body := postQuery(`query { suiteHealth(projectID: "suites") {
+			suiteName totalRuns failureCount passRate flakyTestCount } }`)
+
+		Expect(body).To(MatchJSON(`{"data":{"suiteHealth":[` +
+			`{"suiteName":"Shaky Suite","totalRuns":4,"failureCount":3,"passRate":0.25,"flakyTestCount":1},` +
+			`{"suiteName":"Stable Suite","totalRuns":2,"failureCount":0,"passRate":1,"flakyTestCount":0}]}}`))
+	})
+})
+
+var _ = Describe("TeamHealth Query", func() {
+	type teamHealth struct {
+		TeamName           string  `json:"teamName"`
+		ProjectCount       int     `json:"projectCount"`
+		TotalRuns          int     `json:"totalRuns"`
+		FlakyTestCount     int     `json:"flakyTestCount"`
+		AverageFailureRate float64 `json:"averageFailureRate"`
+	}
+	fetch := func(teamName string) teamHealth {
+		body := postQuery(`query { teamHealth(teamName: "` + teamName + `") {
+			teamName projectCount totalRuns flakyTestCount averageFailureRate } }`)
+
+		var data struct {
+			Data struct {
+				TeamHealth teamHealth `json:"teamHealth"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed(), string(body))
+		return data.Data.TeamHealth
+	}
+
+	It("should aggregate every project of the team", func() {
+		// team-d owns "branches" (3 of 4 runs failed) and "suites" (3 of 6).
+		team := fetch("team-d")
+		Expect(team.TeamName).To(Equal("team-d"))
+		Expect(team.ProjectCount).To(Equal(2))
+		Expect(team.TotalRuns).To(Equal(10))
+		Expect(team.FlakyTestCount).To(Equal(2))
+		Expect(team.AverageFailureRate).To(BeNumerically("~", (3.0/4.0+0.5)/2, 0.0001))
+	})
+
+	It("should return a zeroed result for a team without projects", func() {
+		Expect(fetch("team-none")).To(Equal(teamHealth{TeamName: "team-none"}))
+	})
+
+	It("should reject an empty team name", func() {
+		body := postQuery(`query { teamHealth(teamName: "") { teamName } }`)
+		Expect(string(body)).To(ContainSubstring("teamName must not be empty"))
+	})
+})
+
+var _ = Describe("ProjectHealthScore Query", func() {
+	It("should score a project from its flaky, failing and slow specs", func() {
+		body := postQuery(`query { projectHealthScore(projectID: "suites") {
+			projectID score sinceDays testCount flakyTestCount averageFailureRate slowTestCount } }`)
+		// Three specs: one flaky, failure rates 0, 0.5 and 1, none slow.
+		Expect(body).To(MatchJSON(`{"data":{"projectHealthScore":{"projectID":"suites","score":64.2,"sinceDays":30,
+			"testCount":3,"flakyTestCount":1,"averageFailureRate":0.5,"slowTestCount":0}}}`))
+	})
+
+	It("should return null for a project without runs", func() {
+		body := postQuery(`query { projectHealthScore(projectID: "no-such-project") { score } }`)
+		Expect(body).To(MatchJSON(`{"data":{"projectHealthScore":null}}`))
+	})
+})
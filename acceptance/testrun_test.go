@@ -0,0 +1,79 @@
+package acceptance
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+var _ = Describe("RecentTestRuns Query", func() {
+	type testRun struct {
+		ID                string  `json:"id"`
+		GitBranch         string  `json:"gitBranch"`
+		GitSha            string  `json:"gitSha"`
+		BuildTriggerActor string  `json:"buildTriggerActor"`
+		BuildURL          string  `json:"buildUrl"`
+		StartTime         *string `json:"startTime"`
+		EndTime           *string `json:"endTime"`
+	}
+
+	recentRuns := func(args string) []testRun {
+		body := postQuery(`query { recentTestRuns(` + args + `) {
+			id gitBranch gitSha buildTriggerActor buildUrl startTime endTime } }`)
+
+		var data struct {
+			Data struct {
+				RecentTestRuns []testRun `json:"recentTestRuns"`
+			} `json:"data"`
+		}
+		Expect(json.Unmarshal(body, &data)).To(Succeed())
+		return data.Data.RecentTestRuns
+	}
+
+	It("should list a project's runs newest first with their metadata", func() {
+		runs := recentRuns(`projectID: "branches", limit: 5`)
+
+		Expect(runs).To(HaveLen(2))
+		Expect(runs[0].ID).To(Equal("8"))
+		Expect(runs[0].GitBranch).To(Equal("feature/retry"))
+		Expect(runs[0].GitSha).To(Equal("brn555"))
+		Expect(runs[0].BuildTriggerActor).To(Equal("tester"))
+		Expect(runs[0].BuildURL).To(Equal("https://ci.example.com/build/8"))
+		Expect(runs[0].StartTime).ToNot(BeNil())
+		Expect(runs[0].EndTime).ToNot(BeNil())
+		Expect(runs[1].ID).To(Equal("7"))
+	})
+
+	It("should list runs across every project when no project is given", func() {
+		runs := recentRuns(`limit: 100`)
+
+		Expect(len(runs)).To(BeNumerically(">=", 9))
+		Expect(runs).To(ContainElement(HaveField("GitSha", "abc123")))
+		Expect(runs).To(ContainElement(HaveField("GitSha", "def456")))
+	})
+
+	It("should respect the limit", func() {
+		Expect(recentRuns(`limit: 2`)).To(HaveLen(2))
+	})
+})
+
+var _ = Describe("TestHistory Query", func() {
+	It("should list a spec's latest runs oldest first with their branch", func() {
+		body := postQuery(`query { testHistory(projectID: "branches", testName: "Branch spec", limit: 2) {
+			specRunID testRunID status passed message gitBranch gitSha } }`)
+		Expect(body).To(MatchJSON(`{"data":{"testHistory":[
+			{"specRunID":"22","testRunID":"8","status":"failed","passed":false,"message":"message9","gitBranch":"feature/retry","gitSha":"brn555"},
+			{"specRunID":"23","testRunID":"8","status":"passed","passed":true,"message":null,"gitBranch":"feature/retry","gitSha":"brn555"}]}}`))
+	})
+
+	It("should default to the latest 50 runs", func() {
+		body := postQuery(`query { testHistory(projectID: "branches", testName: "Branch spec") { specRunID } }`)
+		Expect(body).To(MatchJSON(`{"data":{"testHistory":[{"specRunID":"52"},{"specRunID":"21"},{"specRunID":"22"},{"specRunID":"23"}]}}`))
+	})
+
+	It("should return an empty list for an unknown spec", func() {
+		body := postQuery(`query { testHistory(projectID: "branches", testName: "No such spec") { specRunID } }`)
+		Expect(body).To(MatchJSON(`{"data":{"testHistory":[]}}`))
+	})
+})
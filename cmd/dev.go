@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/devstack"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Run a local development stack in Docker",
+}
+
+var devUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Start a seeded Postgres in Docker and serve against it",
+	Long: `Start a Postgres container, apply the migrations, seed the demo data and
+run the server against it until Ctrl-C. The container is kept, with its data,
+for the next "mycel dev up"; remove it with "mycel dev down".`,
+	Example: `  mycel dev up
+  mycel dev up --port 55433 --addr :9090`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stack, err := devStack(cmd)
+		if err != nil {
+			return err
+		}
+		addr, _ := cmd.Flags().GetString("addr")
+		stack.Serve = func(ctx context.Context, dsn string) error {
+			if err := os.Setenv("DB_URL", dsn); err != nil {
+				return err
+			}
+			cfg, err := server.LoadConfig()
+			if err != nil {
+				return err
+			}
+			if addr != "" {
+				cfg.Addr = addr
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "🌱 Starting Mycelium MCP API server...")
+			return server.StartWithContext(ctx, cfg)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return stack.Up(ctx)
+	},
+}
+
+var devDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Remove the development Postgres container and its data",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stack, err := devStack(cmd)
+		if err != nil {
+			return err
+		}
+		return stack.Down(cmd.Context())
+	},
+}
+
+func devStack(cmd *cobra.Command) (*devstack.Stack, error) {
+	stack := devstack.NewStack(devstack.ExecRunner{}, cmd.OutOrStdout())
+	stack.Container, _ = cmd.Flags().GetString("container")
+	if image, err := cmd.Flags().GetString("image"); err == nil {
+		stack.Image = image
+	}
+	stack.Port, _ = cmd.Flags().GetInt("port")
+	if stack.Port < 1 || stack.Port > 65535 {
+		return nil, fmt.Errorf("--port must be between 1 and 65535, got %d", stack.Port)
+	}
+	return stack, nil
+}
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+	devCmd.AddCommand(devUpCmd, devDownCmd)
+
+	devCmd.PersistentFlags().String("container", devstack.DefaultContainer, "Name of the Postgres container")
+	devCmd.PersistentFlags().Int("port", devstack.DefaultPort, "Host port the Postgres container listens on")
+	devUpCmd.Flags().String("image", devstack.DefaultImage, "Postgres image to create the container from")
+	devUpCmd.Flags().String("addr", "", "Address for the server to listen on (overrides LISTEN_ADDR and PORT, default :8080)")
+}
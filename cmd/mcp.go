@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run the fern-mycelium MCP server over stdio",
+	Long: `Runs a Model Context Protocol server speaking newline-delimited JSON-RPC 2.0
+on stdin/stdout, so MCP clients can launch mycel as a subprocess.
+Logs are written to stderr to keep stdout reserved for protocol messages.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		pool, err := db.ConnectContext(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		return mcp.NewServer(repo.NewFlakyTestRepo(pool)).ServeStdio(ctx, os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the database schema",
+	Long:  "Applies fern-reporter's embedded schema migrations, followed by fern-mycelium's own, to the database at DB_URL.",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withMigrator(func(m *db.Migrator) error {
+			changed, err := m.Up()
+			if err != nil {
+				return err
+			}
+			return reportVersion(cmd, m, changed)
+		})
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back migrations (one step by default)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steps, _ := cmd.Flags().GetInt("steps")
+		all, _ := cmd.Flags().GetBool("all")
+		if all {
+			steps = 0
+		} else if steps < 1 {
+			return fmt.Errorf("--steps must be at least 1, got %d", steps)
+		}
+
+		return withMigrator(func(m *db.Migrator) error {
+			changed, err := m.Down(steps)
+			if err != nil {
+				return err
+			}
+			return reportVersion(cmd, m, changed)
+		})
+	},
+}
+
+var migrateVersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the applied schema versions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withMigrator(func(m *db.Migrator) error {
+			versions, err := describeVersions(m)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), versions)
+			return nil
+		})
+	},
+}
+
+func withMigrator(run func(m *db.Migrator) error) error {
+	m, err := db.NewMigratorFromEnv()
+	if err != nil {
+		return err
+	}
+	defer m.Close() //nolint:all
+	return run(m)
+}
+
+func reportVersion(cmd *cobra.Command, m *db.Migrator, changed bool) error {
+	versions, err := describeVersions(m)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		fmt.Fprintf(cmd.OutOrStdout(), "✅ No change, %s\n", versions)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ Migrated, %s\n", versions)
+	return nil
+}
+
+// describeVersions reports both the fern-reporter and fern-mycelium schema versions.
+func describeVersions(m *db.Migrator) (string, error) {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return "", err
+	}
+	myceliumVersion, myceliumDirty, err := m.MyceliumVersion()
+	if err != nil {
+		return "", err
+	}
+	return formatVersion("schema", version, dirty) + ", " + formatVersion("mycelium schema", myceliumVersion, myceliumDirty), nil
+}
+
+func formatVersion(name string, version uint, dirty bool) string {
+	if dirty {
+		return fmt.Sprintf("%s version %d (dirty)", name, version)
+	}
+	return fmt.Sprintf("%s version %d", name, version)
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateVersionCmd)
+
+	migrateDownCmd.Flags().Int("steps", 1, "Number of migrations to roll back")
+	migrateDownCmd.Flags().Bool("all", false, "Roll back every migration")
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
+	"github.com/guidewire-oss/fern-mycelium/internal/report"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query test intelligence from the fern-reporter database",
+}
+
+var queryFlakyCmd = &cobra.Command{
+	Use:   "flaky",
+	Short: "List the flakiest tests of a project",
+	Example: `  mycel query flaky --project demo --limit 5
+  mycel query flaky --project demo --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		limit, _ := cmd.Flags().GetInt("limit")
+		format, _ := cmd.Flags().GetString("format")
+		if err := report.ValidateFormat(format); err != nil {
+			return err
+		}
+
+		pool, err := db.ConnectContext(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		tests, err := repo.NewFlakyTestRepo(pool).GetFlakyTests(cmd.Context(), project, limit, repo.FlakyTestOptions{})
+		if err != nil {
+			return err
+		}
+		return report.WriteFlakyTests(cmd.OutOrStdout(), tests, format)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+	queryCmd.AddCommand(queryFlakyCmd)
+
+	queryFlakyCmd.Flags().StringP("project", "p", "", "Project name or UUID")
+	queryFlakyCmd.Flags().IntP("limit", "n", 10, "Maximum number of tests to list")
+	queryFlakyCmd.Flags().StringP("format", "o", report.FormatTable, "Output format: table or json")
+	_ = queryFlakyCmd.MarkFlagRequired("project")
+}
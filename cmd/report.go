@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
+	"github.com/guidewire-oss/fern-mycelium/internal/notify"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report test intelligence to external systems",
+}
+
+var reportGitHubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "File GitHub issues for a project's high-risk flaky tests",
+	Long: `File a GitHub issue for each flaky test of a project whose failure rate is at
+least the threshold. An open issue already filed for a test is updated
+instead of opening another. The token is read from GITHUB_TOKEN.`,
+	Example: `  GITHUB_TOKEN=... mycel report github --project demo --repo acme/webapp
+  mycel report github --project demo --threshold 0.5`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		repository, _ := cmd.Flags().GetString("repo")
+		limit, _ := cmd.Flags().GetInt("limit")
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		if repository == "" {
+			repository = os.Getenv("GITHUB_REPOSITORY")
+		}
+		if repository == "" {
+			return errors.New("--repo or GITHUB_REPOSITORY is required")
+		}
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			return errors.New("GITHUB_TOKEN is required")
+		}
+
+		pool, err := db.ConnectContext(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		tests, err := repo.NewFlakyTestRepo(pool).GetFlakyTests(cmd.Context(), project, limit, repo.FlakyTestOptions{MinFailureRate: threshold})
+		if err != nil {
+			return err
+		}
+
+		issues := notify.NewGitHubIssues(repository, token, notify.WithGitHubAPIURL(os.Getenv("GITHUB_API_URL")))
+		for _, test := range tests {
+			issue, err := issues.FileIssue(cmd.Context(), project, test)
+			if err != nil {
+				return err
+			}
+			action := "Updated"
+			if issue.Created {
+				action = "Created"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s #%d for %s: %s\n", action, issue.Number, test.TestName, issue.URL)
+		}
+		if len(tests) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No high-risk flaky tests found")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportGitHubCmd)
+
+	reportGitHubCmd.Flags().StringP("project", "p", "", "Project name or UUID")
+	reportGitHubCmd.Flags().String("repo", "", "Repository to file issues in, as owner/name (default $GITHUB_REPOSITORY)")
+	reportGitHubCmd.Flags().IntP("limit", "n", 50, "Maximum number of tests to file")
+	reportGitHubCmd.Flags().Float64("threshold", notify.HighRiskFailureRate, "Failure rate from which a test is high risk")
+	_ = reportGitHubCmd.MarkFlagRequired("project")
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 
+	"github.com/guidewire-oss/fern-mycelium/internal/config"
+	"github.com/guidewire-oss/fern-mycelium/internal/logging"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +22,32 @@ Use this CLI to run the mycelium API server, interact with test data,
 query agents like the Test Coach or Postmortem Generator, and more.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("config")
+		used, err := config.Load(path)
+		if err != nil {
+			return err
+		}
+
+		level := slog.LevelInfo
+		if debug, _ := cmd.Flags().GetBool("debug"); debug {
+			level = slog.LevelDebug
+		} else if raw := os.Getenv("LOG_LEVEL"); raw != "" {
+			if level, err = logging.ParseLevel(raw); err != nil {
+				return err
+			}
+		}
+		format, err := logging.FormatFromEnv()
+		if err != nil {
+			return err
+		}
+		logging.SetupFormat(level, format)
+
+		if used != "" {
+			slog.Debug("Loaded config file", "path", used)
+		}
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root and sets flags appropriately.
@@ -31,6 +60,6 @@ func Execute() {
 
 func init() {
 	// Global flags can be added here
-	rootCmd.PersistentFlags().StringP("config", "c", "", "Path to custom config file (optional)")
+	rootCmd.PersistentFlags().StringP("config", "c", "", "Path to a YAML or TOML config file (default ./mycel.yaml if present)")
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable verbose debug logging")
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+
 	"github.com/guidewire-oss/fern-mycelium/internal/server"
 	"github.com/spf13/cobra"
 )
@@ -10,12 +11,28 @@ var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the fern-mycelium MCP API server",
 	Long:  "Launches the fern-mycelium server exposing GraphQL and REST APIs for test context and agent interaction.",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := server.LoadConfig()
+		if err != nil {
+			return err
+		}
+		if addr, _ := cmd.Flags().GetString("addr"); addr != "" {
+			cfg.Addr = addr
+		}
+		cfg.Migrate, _ = cmd.Flags().GetBool("migrate")
+		if requireDB, _ := cmd.Flags().GetBool("require-db"); requireDB {
+			cfg.RequireDB = true
+		}
+
 		fmt.Println("🌱 Starting Mycelium MCP API server...")
-		server.Start()
+		server.Start(cfg)
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().String("addr", "", "Address to listen on (overrides LISTEN_ADDR and PORT, default :8080)")
+	serveCmd.Flags().Bool("migrate", false, "Apply pending database migrations before starting the server")
+	serveCmd.Flags().Bool("require-db", false, "Exit instead of starting when the database does not answer a ping (or set REQUIRE_DB)")
 }
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/version"
+)
+
+var _ = Describe("mycel version", func() {
+	It("should print the version and commit to the command's output", func() {
+		original := []string{version.Version, version.Commit}
+		DeferCleanup(func() {
+			version.Version, version.Commit = original[0], original[1]
+		})
+		version.Version = "v1.4.0"
+		version.Commit = "5523beb"
+
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetArgs([]string{"version"})
+		DeferCleanup(func() {
+			rootCmd.SetOut(nil)
+			rootCmd.SetArgs(nil)
+		})
+
+		Expect(rootCmd.Execute()).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("mycel v1.4.0"))
+		Expect(out.String()).To(ContainSubstring("commit: 5523beb"))
+	})
+})
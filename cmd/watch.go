@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
+	"github.com/guidewire-oss/fern-mycelium/internal/report"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Follow test intelligence as it changes",
+}
+
+var watchFlakyCmd = &cobra.Command{
+	Use:   "flaky",
+	Short: "Print changes to a project's flaky tests as they happen",
+	Long: `Print the flaky tests of a project, then poll on an interval and print each
+test that becomes flaky (+) or recovers (-). A test is flaky while its
+failure rate is at least the threshold. Stop with Ctrl-C.`,
+	Example: `  mycel watch flaky --project demo
+  mycel watch flaky --project demo --interval 10s --threshold 0.2`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		limit, _ := cmd.Flags().GetInt("limit")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive, got %s", interval)
+		}
+		if threshold <= 0 || threshold > 1 {
+			return fmt.Errorf("--threshold must be in (0, 1], got %g", threshold)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		pool, err := db.ConnectContext(ctx)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		flaky := repo.NewFlakyTestRepo(pool)
+		opts := repo.FlakyTestOptions{MinFailureRate: threshold}
+		previous, err := flaky.GetFlakyTests(ctx, project, limit, opts)
+		if err != nil {
+			return err
+		}
+		if err := report.FlakyTestsTable(os.Stdout, previous); err != nil {
+			return err
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			current, err := flaky.GetFlakyTests(ctx, project, limit, opts)
+			if err != nil {
+				// Keep watching through transient failures; the next poll
+				// diffs against the last successful one.
+				if ctx.Err() == nil {
+					slog.Warn("⚠️ Flaky test poll failed", "project", project, "error", err)
+				}
+				continue
+			}
+			if err := report.WriteFlakyDiff(os.Stdout, report.DiffFlakyTests(previous, current), time.Now()); err != nil {
+				return err
+			}
+			previous = current
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.AddCommand(watchFlakyCmd)
+
+	watchFlakyCmd.Flags().StringP("project", "p", "", "Project name or UUID")
+	watchFlakyCmd.Flags().DurationP("interval", "i", 30*time.Second, "How often to poll for changes")
+	watchFlakyCmd.Flags().Float64("threshold", 0.1, "Failure rate from which a test counts as flaky")
+	watchFlakyCmd.Flags().IntP("limit", "n", 100, "Maximum number of tests to track")
+	_ = watchFlakyCmd.MarkFlagRequired("project")
+}
@@ -0,0 +1,144 @@
+// Package capabilities describes the analyses fern-mycelium offers, so
+// agents can discover the GraphQL queries and MCP tools and their arguments
+// without reading the source.
+package capabilities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+)
+
+// Registry lists the capabilities read from a GraphQL schema and a set of
+// MCP tools. It is built once, as neither changes while the server runs.
+type Registry struct {
+	capabilities []*gql.Capability
+}
+
+// NewRegistry lists the fields of the schema's Query type followed by the
+// tools, both in declaration order. Introspection fields are left out.
+func NewRegistry(schema *ast.Schema, tools []mcp.ToolDescriptor) *Registry {
+	r := &Registry{capabilities: []*gql.Capability{}}
+	if schema != nil && schema.Query != nil {
+		for _, field := range schema.Query.Fields {
+			if strings.HasPrefix(field.Name, "__") {
+				continue
+			}
+			r.capabilities = append(r.capabilities, queryCapability(field))
+		}
+	}
+	for _, tool := range tools {
+		r.capabilities = append(r.capabilities, toolCapability(tool))
+	}
+	return r
+}
+
+// List returns the capabilities. A nil registry has none.
+func (r *Registry) List() []*gql.Capability {
+	if r == nil {
+		return []*gql.Capability{}
+	}
+	return r.capabilities
+}
+
+func queryCapability(field *ast.FieldDefinition) *gql.Capability {
+	c := &gql.Capability{
+		Name:        field.Name,
+		Kind:        gql.CapabilityKindQuery,
+		Description: field.Description,
+		Arguments:   []*gql.CapabilityArgument{},
+	}
+	for _, arg := range field.Arguments {
+		a := &gql.CapabilityArgument{
+			Name:        arg.Name,
+			Type:        arg.Type.String(),
+			Required:    arg.Type.NonNull && arg.DefaultValue == nil,
+			Description: arg.Description,
+		}
+		if arg.DefaultValue != nil {
+			value := arg.DefaultValue.String()
+			a.DefaultValue = &value
+		}
+		c.Arguments = append(c.Arguments, a)
+	}
+	return c
+}
+
+func toolCapability(tool mcp.ToolDescriptor) *gql.Capability {
+	args, err := toolArguments(tool.InputSchema)
+	if err != nil {
+		slog.Warn("⚠️ Failed to read MCP tool input schema", "tool", tool.Name, "error", err)
+		args = []*gql.CapabilityArgument{}
+	}
+	return &gql.Capability{
+		Name:        tool.Name,
+		Kind:        gql.CapabilityKindTool,
+		Description: tool.Description,
+		Arguments:   args,
+	}
+}
+
+type schemaProperty struct {
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Default     json.RawMessage `json:"default"`
+}
+
+// toolArguments reads the properties of a tool's JSON Schema input object,
+// keeping their declaration order.
+func toolArguments(raw json.RawMessage) ([]*gql.CapabilityArgument, error) {
+	args := []*gql.CapabilityArgument{}
+	if len(raw) == 0 {
+		return args, nil
+	}
+
+	var schema struct {
+		Properties json.RawMessage `json:"properties"`
+		Required   []string        `json:"required"`
+	}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, err
+	}
+	if len(schema.Properties) == 0 {
+		return args, nil
+	}
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(schema.Properties))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("properties must be an object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := tok.(string)
+		var prop schemaProperty
+		if err := dec.Decode(&prop); err != nil {
+			return nil, fmt.Errorf("property %q: %w", name, err)
+		}
+		a := &gql.CapabilityArgument{
+			Name:        name,
+			Type:        prop.Type,
+			Required:    required[name],
+			Description: prop.Description,
+		}
+		if len(prop.Default) > 0 {
+			value := string(prop.Default)
+			a.DefaultValue = &value
+		}
+		args = append(args, a)
+	}
+	return args, nil
+}
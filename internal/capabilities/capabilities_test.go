@@ -0,0 +1,99 @@
+package capabilities_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/capabilities"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+)
+
+func strPtr(s string) *string { return &s }
+
+// find returns the capability of the given kind and name, or nil.
+func find(list []*gql.Capability, kind gql.CapabilityKind, name string) *gql.Capability {
+	for _, c := range list {
+		if c.Kind == kind && c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Registry", func() {
+	It("should list query fields and tools in declaration order", func() {
+		schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+			type Query {
+				"Describe a widget."
+				widget(
+					"Widget name."
+					name: String!
+					size: Int! = 3
+					color: String
+				): String
+				ping: String
+			}`})
+		tools := []mcp.ToolDescriptor{{
+			Name:        "count_widgets",
+			Description: "Count the widgets.",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{
+				"zone": {"type":"string","description":"Zone to count"},
+				"all": {"type":"boolean","default":false}
+			},"required":["zone"]}`),
+		}}
+
+		Expect(capabilities.NewRegistry(schema, tools).List()).To(Equal([]*gql.Capability{
+			{Name: "widget", Kind: gql.CapabilityKindQuery, Description: "Describe a widget.", Arguments: []*gql.CapabilityArgument{
+				{Name: "name", Type: "String!", Required: true, Description: "Widget name."},
+				{Name: "size", Type: "Int!", DefaultValue: strPtr("3")},
+				{Name: "color", Type: "String"},
+			}},
+			{Name: "ping", Kind: gql.CapabilityKindQuery, Arguments: []*gql.CapabilityArgument{}},
+			{Name: "count_widgets", Kind: gql.CapabilityKindTool, Description: "Count the widgets.", Arguments: []*gql.CapabilityArgument{
+				{Name: "zone", Type: "string", Required: true, Description: "Zone to count"},
+				{Name: "all", Type: "boolean", DefaultValue: strPtr("false")},
+			}},
+		}))
+	})
+
+	It("should list the flaky test query and tool of the service with their arguments", func() {
+		registry := capabilities.NewRegistry(gql.NewExecutableSchema(gql.Config{}).Schema(), mcp.NewServer(nil).Tools())
+		list := registry.List()
+
+		query := find(list, gql.CapabilityKindQuery, "flakyTests")
+		Expect(query).NotTo(BeNil())
+		Expect(query.Description).NotTo(BeEmpty())
+		Expect(query.Arguments).To(ContainElements(
+			&gql.CapabilityArgument{Name: "projectID", Type: "ID!", Required: true, Description: "Project name or UUID."},
+			HaveField("Name", "limit"),
+			&gql.CapabilityArgument{Name: "sortBy", Type: "FlakyTestSortField!", DefaultValue: strPtr("FAILURE_RATE")},
+		))
+
+		tool := find(list, gql.CapabilityKindTool, "detect_flaky_tests")
+		Expect(tool).NotTo(BeNil())
+		Expect(tool.Arguments).To(Equal([]*gql.CapabilityArgument{
+			{Name: "projectID", Type: "string", Required: true, Description: "Project name or UUID"},
+			{Name: "limit", Type: "integer", Description: "Maximum number of tests to return", DefaultValue: strPtr("10")},
+			{Name: "sinceDays", Type: "integer", Description: "Only consider runs from the last N days (default 30)"},
+		}))
+
+		Expect(list).NotTo(ContainElement(HaveField("Name", HavePrefix("__"))))
+	})
+
+	It("should list nothing for a nil registry", func() {
+		var registry *capabilities.Registry
+		Expect(registry.List()).To(BeEmpty())
+	})
+
+	It("should list a tool without arguments when its schema is invalid", func() {
+		tools := []mcp.ToolDescriptor{{Name: "broken", InputSchema: json.RawMessage(`{"properties":[]}`)}}
+		list := capabilities.NewRegistry(nil, tools).List()
+		Expect(list).To(HaveLen(1))
+		Expect(list[0].Arguments).To(BeEmpty())
+	})
+})
@@ -2,31 +2,244 @@ package db
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
+	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var DB *pgxpool.Pool
 
+// ErrMissingURL is returned by Connect when DB_URL is not set.
+var ErrMissingURL = errors.New("DB_URL not set in environment")
+
+// Pool defaults applied when the corresponding environment variable is unset.
+const (
+	defaultMaxConns        = 10
+	defaultMinConns        = 0
+	defaultMaxConnIdleTime = 5 * time.Minute
+)
+
+// connectTimeout bounds a single connection attempt.
+const connectTimeout = 5 * time.Second
+
+// CloseTimeout bounds how long Close waits for in-flight queries to release
+// their connections.
+const CloseTimeout = 10 * time.Second
+
 func Connect() (*pgxpool.Pool, error) {
+	return ConnectContext(context.Background())
+}
+
+// ConnectContext creates the pool and verifies it with a ping, retrying
+// transient failures according to LoadRetryPolicy until ctx is done.
+func ConnectContext(ctx context.Context) (*pgxpool.Pool, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	pool, err := connect(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	DB = pool
+
+	slog.Info("✅ Connected to fern-reporter database")
+	return DB, nil
+}
+
+// PingContext opens a single connection to DB_URL and closes it again,
+// without retrying, so a caller that cannot work without the database fails
+// at once rather than after the retry policy runs out.
+func PingContext(ctx context.Context) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	conn, err := pgx.ConnectConfig(ctx, cfg.ConnConfig)
+	if err != nil {
+		return fmt.Errorf("database is unreachable: %w", err)
+	}
+	return conn.Close(ctx)
+}
+
+// ConnectReplicaContext creates a pool for the read replica at DB_READ_URL,
+// retrying like ConnectContext. It returns a nil pool when DB_READ_URL is
+// unset, in which case reads should go to the primary.
+func ConnectReplicaContext(ctx context.Context) (*pgxpool.Pool, error) {
+	cfg, err := LoadReplicaConfig()
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+	pool, err := connect(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+
+	slog.Info("✅ Connected to fern-reporter read replica")
+	return pool, nil
+}
+
+// Close closes the pool opened by Connect, waiting up to CloseTimeout for
+// in-flight queries. It is a no-op when no pool is open.
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), CloseTimeout)
+	defer cancel()
+
+	err := CloseContext(ctx, DB)
+	DB = nil
+	return err
+}
+
+// CloseContext closes pool once its acquired connections are released, so
+// in-flight queries can finish. If ctx ends first it returns without waiting
+// further; the pool rejects new queries either way, and the remaining
+// connections are closed as they are released.
+func CloseContext(ctx context.Context, pool *pgxpool.Pool) error {
+	done := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for in-flight queries before closing the pool: %w", ctx.Err())
+	}
+}
+
+func connect(ctx context.Context, cfg *pgxpool.Config) (*pgxpool.Pool, error) {
+	policy, err := LoadRetryPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	var pool *pgxpool.Pool
+	err = Retry(ctx, policy, func(ctx context.Context) error {
+		attemptCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+		defer cancel()
+
+		p, err := pgxpool.NewWithConfig(attemptCtx, cfg.Copy())
+		if err != nil {
+			return err
+		}
+		if err := p.Ping(attemptCtx); err != nil {
+			p.Close()
+			return err
+		}
+		pool = p
+		return nil
+	})
+	return pool, err
+}
+
+// LoadConfig builds the pool configuration from DB_URL, applying the
+// DB_MAX_CONNS, DB_MIN_CONNS and DB_MAX_CONN_IDLE_TIME overrides. When
+// DB_STATEMENT_TIMEOUT is set, every connection has Postgres abort
+// statements that run longer, whatever the caller's context allows.
+func LoadConfig() (*pgxpool.Config, error) {
 	url := os.Getenv("DB_URL")
 	if url == "" {
-		log.Fatal("❌ DB_URL not set in environment")
+		return nil, ErrMissingURL
 	}
+	return loadConfig("DB_URL", url)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// LoadReplicaConfig builds the read replica pool configuration from
+// DB_READ_URL with the same pool overrides as LoadConfig. It returns nil
+// when DB_READ_URL is unset.
+func LoadReplicaConfig() (*pgxpool.Config, error) {
+	url := os.Getenv("DB_READ_URL")
+	if url == "" {
+		return nil, nil
+	}
+	return loadConfig("DB_READ_URL", url)
+}
+
+func loadConfig(key, url string) (*pgxpool.Config, error) {
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", key, err)
+	}
 
-	var err error
-	DB, err = pgxpool.New(ctx, url)
+	maxConns, err := envInt32("DB_MAX_CONNS", defaultMaxConns)
+	if err != nil {
+		return nil, err
+	}
+	if maxConns < 1 {
+		return nil, fmt.Errorf("DB_MAX_CONNS must be at least 1, got %d", maxConns)
+	}
+	minConns, err := envInt32("DB_MIN_CONNS", defaultMinConns)
+	if err != nil {
+		return nil, err
+	}
+	if minConns > maxConns {
+		return nil, fmt.Errorf("DB_MIN_CONNS (%d) must not exceed DB_MAX_CONNS (%d)", minConns, maxConns)
+	}
+	idleTime, err := envDuration("DB_MAX_CONN_IDLE_TIME", defaultMaxConnIdleTime)
+	if err != nil {
+		return nil, err
+	}
+	statementTimeout, err := envDuration("DB_STATEMENT_TIMEOUT", 0)
 	if err != nil {
-		log.Fatalf("❌ Failed to connect to database: %v", err)
 		return nil, err
 	}
 
-	log.Println("✅ Connected to fern-reporter database")
-	return DB, nil
+	cfg.MaxConns = maxConns
+	cfg.MinConns = minConns
+	cfg.MaxConnIdleTime = idleTime
+	if statementTimeout > 0 {
+		cfg.AfterConnect = setStatementTimeout(statementTimeout)
+	}
+
+	return cfg, nil
+}
+
+// setStatementTimeout returns an AfterConnect hook setting the session's
+// statement_timeout to d, rounded up to Postgres' millisecond resolution.
+func setStatementTimeout(d time.Duration) func(context.Context, *pgx.Conn) error {
+	ms := (d + time.Millisecond - 1) / time.Millisecond
+	return func(ctx context.Context, conn *pgx.Conn) error {
+		if _, err := conn.Exec(ctx, fmt.Sprintf("SET statement_timeout = %d", ms)); err != nil {
+			return fmt.Errorf("failed to set statement_timeout: %w", err)
+		}
+		return nil
+	}
+}
+
+func envInt32(key string, fallback int32) (int32, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative integer", key, raw)
+	}
+	return int32(v), nil
+}
+
+func envDuration(key string, fallback time.Duration) (time.Duration, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := time.ParseDuration(raw)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative duration", key, raw)
+	}
+	return v, nil
 }
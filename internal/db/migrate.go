@@ -0,0 +1,160 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	myceliummigrations "github.com/guidewire-oss/fern-mycelium/internal/db/migrations"
+	"github.com/guidewire/fern-reporter/pkg/db/migrations"
+	_ "github.com/lib/pq" // registers the "postgres" driver with database/sql
+)
+
+// Migrator applies fern-reporter's embedded schema migrations followed by the
+// migrations for fern-mycelium's own tables. The two sets are versioned
+// independently so upgrading fern-reporter never reorders them.
+type Migrator struct {
+	reporter *migrate.Migrate
+	mycelium *migrate.Migrate
+}
+
+// myceliumMigrationsTable tracks fern-mycelium's migrations separately from
+// fern-reporter's schema_migrations table.
+const myceliumMigrationsTable = "mycelium_schema_migrations"
+
+// NewMigrator opens a migrator against dsn. Callers must Close it.
+func NewMigrator(dsn string) (*Migrator, error) {
+	reporter, err := newMigrate(dsn, migrations.EmbeddedMigrations, &postgres.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	mycelium, err := newMigrate(dsn, myceliummigrations.EmbeddedMigrations, &postgres.Config{MigrationsTable: myceliumMigrationsTable})
+	if err != nil {
+		reporter.Close() //nolint:all
+		return nil, err
+	}
+
+	return &Migrator{reporter: reporter, mycelium: mycelium}, nil
+}
+
+// newMigrate opens a connection dedicated to one migration set; closing the
+// returned instance closes it.
+func newMigrate(dsn string, source fs.FS, config *postgres.Config) (*migrate.Migrate, error) {
+	conn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open db: %w", err)
+	}
+
+	driver, err := postgres.WithInstance(conn, config)
+	if err != nil {
+		conn.Close() //nolint:all
+		return nil, fmt.Errorf("failed to create postgres driver: %w", err)
+	}
+
+	sourceDriver, err := iofs.New(source, ".")
+	if err != nil {
+		conn.Close() //nolint:all
+		return nil, fmt.Errorf("failed to init embedded migration source: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", sourceDriver, "postgres", driver)
+	if err != nil {
+		conn.Close() //nolint:all
+		return nil, fmt.Errorf("failed to init migrate instance: %w", err)
+	}
+	return m, nil
+}
+
+// NewMigratorFromEnv opens a migrator against DB_URL.
+func NewMigratorFromEnv() (*Migrator, error) {
+	dsn := os.Getenv("DB_URL")
+	if dsn == "" {
+		return nil, ErrMissingURL
+	}
+	return NewMigrator(dsn)
+}
+
+// Up applies all pending migrations. It reports whether anything changed.
+func (m *Migrator) Up() (bool, error) {
+	reporterChanged, err := changed(m.reporter.Up())
+	if err != nil {
+		return reporterChanged, err
+	}
+	myceliumChanged, err := changed(m.mycelium.Up())
+	return reporterChanged || myceliumChanged, err
+}
+
+// Down rolls back steps migrations, or every migration when steps is zero.
+// fern-mycelium's migrations are rolled back before fern-reporter's. It
+// reports whether anything changed.
+func (m *Migrator) Down(steps int) (bool, error) {
+	if steps == 0 {
+		myceliumChanged, err := changed(m.mycelium.Down())
+		if err != nil {
+			return myceliumChanged, err
+		}
+		reporterChanged, err := changed(m.reporter.Down())
+		return myceliumChanged || reporterChanged, err
+	}
+
+	// Migration versions are sequential from 1, so the applied version is
+	// also the number of steps available to roll back.
+	applied, _, err := versionOf(m.mycelium)
+	if err != nil {
+		return false, err
+	}
+	myceliumSteps := min(steps, int(applied))
+	if myceliumSteps > 0 {
+		if _, err := changed(m.mycelium.Steps(-myceliumSteps)); err != nil {
+			return false, err
+		}
+	}
+	if steps == myceliumSteps {
+		return true, nil
+	}
+	reporterChanged, err := changed(m.reporter.Steps(-(steps - myceliumSteps)))
+	return myceliumSteps > 0 || reporterChanged, err
+}
+
+// Version returns the applied fern-reporter schema version. A database without
+// any applied migrations reports version 0.
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	return versionOf(m.reporter)
+}
+
+// MyceliumVersion returns the applied version of fern-mycelium's own
+// migrations, reporting 0 when none are applied.
+func (m *Migrator) MyceliumVersion() (version uint, dirty bool, err error) {
+	return versionOf(m.mycelium)
+}
+
+// Close releases the database connection.
+func (m *Migrator) Close() error {
+	reporterSrcErr, reporterDBErr := m.reporter.Close()
+	myceliumSrcErr, myceliumDBErr := m.mycelium.Close()
+	return errors.Join(reporterSrcErr, reporterDBErr, myceliumSrcErr, myceliumDBErr)
+}
+
+func versionOf(m *migrate.Migrate) (uint, bool, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+func changed(err error) (bool, error) {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("migration failed: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,14 @@
+CREATE TABLE test_quarantines (
+    id             BIGSERIAL PRIMARY KEY,
+    project_id     INTEGER NOT NULL REFERENCES project_details (id) ON DELETE CASCADE,
+    test_name      TEXT NOT NULL,
+    reason         TEXT,
+    quarantined_by TEXT,
+    quarantined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at     TIMESTAMPTZ,
+    lifted_at      TIMESTAMPTZ
+);
+
+-- Lookups are always by project and test name, usually for active rows only.
+CREATE INDEX idx_test_quarantines_project_test ON test_quarantines (project_id, test_name)
+    WHERE lifted_at IS NULL;
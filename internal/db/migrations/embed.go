@@ -0,0 +1,10 @@
+// Package migrations holds the schema for tables owned by fern-mycelium
+// rather than fern-reporter.
+package migrations
+
+import "embed"
+
+// EmbeddedMigrations exposes the embedded migration SQL files.
+//
+//go:embed *.sql
+var EmbeddedMigrations embed.FS
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Retry defaults applied when the corresponding environment variable is unset.
+const (
+	defaultConnectMaxAttempts = 5
+	defaultConnectBaseDelay   = 500 * time.Millisecond
+	maxConnectDelay           = 10 * time.Second
+)
+
+// RetryPolicy controls how connection attempts are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the wait before the second attempt; it doubles after each failure.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between attempts.
+	MaxDelay time.Duration
+}
+
+// LoadRetryPolicy reads DB_CONNECT_MAX_ATTEMPTS and DB_CONNECT_BASE_DELAY.
+func LoadRetryPolicy() (RetryPolicy, error) {
+	attempts, err := envInt32("DB_CONNECT_MAX_ATTEMPTS", defaultConnectMaxAttempts)
+	if err != nil {
+		return RetryPolicy{}, err
+	}
+	if attempts < 1 {
+		return RetryPolicy{}, fmt.Errorf("DB_CONNECT_MAX_ATTEMPTS must be at least 1, got %d", attempts)
+	}
+	delay, err := envDuration("DB_CONNECT_BASE_DELAY", defaultConnectBaseDelay)
+	if err != nil {
+		return RetryPolicy{}, err
+	}
+
+	return RetryPolicy{
+		MaxAttempts: int(attempts),
+		BaseDelay:   delay,
+		MaxDelay:    maxConnectDelay,
+	}, nil
+}
+
+// Retry calls op until it succeeds, the attempts are exhausted or ctx is done,
+// backing off exponentially between attempts. It returns the last error from op.
+func Retry(ctx context.Context, policy RetryPolicy, op func(context.Context) error) error {
+	delay := policy.BaseDelay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = op(ctx); err == nil {
+			return nil
+		}
+		if attempt >= policy.MaxAttempts {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		slog.Warn("⚠️ Database connection attempt failed",
+			"attempt", attempt, "max_attempts", policy.MaxAttempts, "error", err, "retry_in", delay)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w after %d attempts: %w", ctx.Err(), attempt, err)
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/db"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+var _ = Describe("Retry", func() {
+	var policy db.RetryPolicy
+
+	BeforeEach(func() {
+		policy = db.RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	})
+
+	It("should retry until the operation succeeds", func() {
+		attempts := 0
+		err := db.Retry(context.Background(), policy, func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("should return the last error once attempts are exhausted", func() {
+		attempts := 0
+		err := db.Retry(context.Background(), policy, func(context.Context) error {
+			attempts++
+			return errors.New("still starting")
+		})
+
+		Expect(attempts).To(Equal(4))
+		Expect(err).To(MatchError(ContainSubstring("giving up after 4 attempts")))
+		Expect(err).To(MatchError(ContainSubstring("still starting")))
+	})
+
+	It("should stop retrying when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		policy.BaseDelay = time.Hour
+
+		attempts := 0
+		err := db.Retry(ctx, policy, func(context.Context) error {
+			attempts++
+			cancel()
+			return errors.New("connection refused")
+		})
+
+		Expect(attempts).To(Equal(1))
+		Expect(err).To(MatchError(context.Canceled))
+	})
+})
+
+var _ = Describe("Retry Policy", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv("DB_CONNECT_MAX_ATTEMPTS", "")
+		GinkgoT().Setenv("DB_CONNECT_BASE_DELAY", "")
+	})
+
+	It("should apply defaults when no overrides are set", func() {
+		policy, err := db.LoadRetryPolicy()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy.MaxAttempts).To(Equal(5))
+		Expect(policy.BaseDelay).To(Equal(500 * time.Millisecond))
+	})
+
+	It("should reflect environment overrides", func() {
+		GinkgoT().Setenv("DB_CONNECT_MAX_ATTEMPTS", "8")
+		GinkgoT().Setenv("DB_CONNECT_BASE_DELAY", "2s")
+
+		policy, err := db.LoadRetryPolicy()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy.MaxAttempts).To(Equal(8))
+		Expect(policy.BaseDelay).To(Equal(2 * time.Second))
+	})
+
+	It("should reject zero attempts", func() {
+		GinkgoT().Setenv("DB_CONNECT_MAX_ATTEMPTS", "0")
+
+		_, err := db.LoadRetryPolicy()
+		Expect(err).To(MatchError(ContainSubstring("at least 1")))
+	})
+})
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LoadTenantConfigs builds a pool configuration per tenant from
+// DB_TENANT_URLS, a comma-separated list of name=url pairs such as
+// "staging=postgres://...,prod=postgres://...". Each pool gets the same
+// overrides as LoadConfig. It returns nil when DB_TENANT_URLS is unset.
+func LoadTenantConfigs() (map[string]*pgxpool.Config, error) {
+	raw := os.Getenv("DB_TENANT_URLS")
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	configs := map[string]*pgxpool.Config{}
+	for _, entry := range strings.Split(raw, ",") {
+		name, url, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.TrimSpace(url) == "" {
+			return nil, fmt.Errorf("invalid DB_TENANT_URLS entry %q: must be name=url", entry)
+		}
+		if _, dup := configs[name]; dup {
+			return nil, fmt.Errorf("invalid DB_TENANT_URLS: tenant %q is listed twice", name)
+		}
+		cfg, err := loadConfig("DB_TENANT_URLS tenant "+name, strings.TrimSpace(url))
+		if err != nil {
+			return nil, err
+		}
+		configs[name] = cfg
+	}
+	return configs, nil
+}
+
+// ConnectTenantsContext creates a pool for each tenant of DB_TENANT_URLS,
+// retrying like ConnectContext. If any tenant cannot be reached, the pools
+// already opened are closed. It returns nil when no tenants are configured.
+func ConnectTenantsContext(ctx context.Context) (map[string]*pgxpool.Pool, error) {
+	configs, err := LoadTenantConfigs()
+	if err != nil || configs == nil {
+		return nil, err
+	}
+
+	pools := make(map[string]*pgxpool.Pool, len(configs))
+	for name, cfg := range configs {
+		pool, err := connect(ctx, cfg)
+		if err != nil {
+			for _, opened := range pools {
+				opened.Close()
+			}
+			return nil, fmt.Errorf("failed to connect to database of tenant %q: %w", name, err)
+		}
+		pools[name] = pool
+		slog.Info("✅ Connected to fern-reporter database", "tenant", name)
+	}
+	return pools, nil
+}
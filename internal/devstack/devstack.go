@@ -0,0 +1,221 @@
+// Package devstack runs a local development database in Docker: it starts a
+// Postgres container, loads the fern-reporter schema, seeds demo data and
+// hands the database over to the server, and tears the container down again.
+package devstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/guidewire-oss/fern-mycelium/acceptance/fixtures"
+)
+
+// Defaults of the development database. The port is off Postgres' usual one
+// so the stack can run next to a locally installed server.
+const (
+	DefaultContainer = "mycel-dev-postgres"
+	DefaultImage     = "postgres:16-alpine"
+	DefaultPort      = 55432
+
+	// DefaultReadyTimeout bounds the wait for a new container to accept
+	// connections.
+	DefaultReadyTimeout = 30 * time.Second
+
+	devUser     = "fern"
+	devPassword = "fern"
+	devDatabase = "fern"
+)
+
+// Runner runs an external command and returns its combined output.
+type Runner interface {
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// ExecRunner runs commands with os/exec.
+type ExecRunner struct{}
+
+// Run implements Runner. The command's output is included in the error when
+// it fails, as docker explains its failures there.
+func (ExecRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return out, fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, msg)
+		}
+		return out, fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+// Stack is the local development stack. The database steps are fields so
+// tests can follow the orchestration without Docker or Postgres.
+type Stack struct {
+	Runner    Runner
+	Container string
+	Image     string
+	Port      int
+	// Out receives progress messages.
+	Out io.Writer
+
+	// Ping checks that the database accepts connections.
+	Ping func(ctx context.Context, dsn string) error
+	// LoadSchema applies the migrations and Seed loads the demo data. Both
+	// must be safe to run against a database set up before.
+	LoadSchema func(ctx context.Context, dsn string) error
+	Seed       func(ctx context.Context, dsn string) error
+	// Serve runs the server against the database until ctx is cancelled.
+	// Up stops after seeding when it is nil.
+	Serve func(ctx context.Context, dsn string) error
+
+	ReadyTimeout time.Duration
+	PollInterval time.Duration
+}
+
+// NewStack returns a stack with the defaults, running docker through runner,
+// loading the schema with fixtures.LoadSchema and seeding it with
+// fixtures.SeedFlakyTests.
+func NewStack(runner Runner, out io.Writer) *Stack {
+	return &Stack{
+		Runner:       runner,
+		Container:    DefaultContainer,
+		Image:        DefaultImage,
+		Port:         DefaultPort,
+		Out:          out,
+		Ping:         ping,
+		LoadSchema:   fixtures.LoadSchema,
+		Seed:         fixtures.SeedFlakyTests,
+		ReadyTimeout: DefaultReadyTimeout,
+		PollInterval: 500 * time.Millisecond,
+	}
+}
+
+// DSN is the connection string of the stack's database.
+func (s *Stack) DSN() string {
+	return fmt.Sprintf("postgres://%s:%s@127.0.0.1:%d/%s?sslmode=disable", devUser, devPassword, s.Port, devDatabase)
+}
+
+// Up starts the database container, or reuses the one left by an earlier
+// Up, waits for it to accept connections, migrates and seeds it, and then
+// runs Serve.
+func (s *Stack) Up(ctx context.Context) error {
+	if err := s.startContainer(ctx); err != nil {
+		return err
+	}
+
+	dsn := s.DSN()
+	if err := s.waitReady(ctx, dsn); err != nil {
+		return err
+	}
+	fmt.Fprintln(s.Out, "📦 Applying migrations...")
+	if err := s.LoadSchema(ctx, dsn); err != nil {
+		return fmt.Errorf("failed to migrate the dev database: %w", err)
+	}
+	fmt.Fprintln(s.Out, "🌱 Seeding demo data...")
+	if err := s.Seed(ctx, dsn); err != nil {
+		return fmt.Errorf("failed to seed the dev database: %w", err)
+	}
+	fmt.Fprintf(s.Out, "✅ Dev database ready at %s\n", dsn)
+
+	if s.Serve == nil {
+		return nil
+	}
+	return s.Serve(ctx, dsn)
+}
+
+// Down removes the database container and its data. It does nothing when
+// there is no container.
+func (s *Stack) Down(ctx context.Context) error {
+	if _, exists, err := s.inspect(ctx); err != nil {
+		return err
+	} else if !exists {
+		fmt.Fprintf(s.Out, "✅ No dev database container %q to remove\n", s.Container)
+		return nil
+	}
+	if _, err := s.Runner.Run(ctx, "docker", "rm", "--force", "--volumes", s.Container); err != nil {
+		return fmt.Errorf("failed to remove the dev database container: %w", err)
+	}
+	fmt.Fprintf(s.Out, "🧹 Removed dev database container %q\n", s.Container)
+	return nil
+}
+
+func (s *Stack) startContainer(ctx context.Context) error {
+	running, exists, err := s.inspect(ctx)
+	if err != nil {
+		return err
+	}
+	switch {
+	case running:
+		fmt.Fprintf(s.Out, "♻️ Reusing running dev database container %q\n", s.Container)
+		return nil
+	case exists:
+		fmt.Fprintf(s.Out, "▶️ Starting dev database container %q\n", s.Container)
+		if _, err := s.Runner.Run(ctx, "docker", "start", s.Container); err != nil {
+			return fmt.Errorf("failed to start the dev database container: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(s.Out, "🐳 Creating dev database container %q from %s\n", s.Container, s.Image)
+	_, err = s.Runner.Run(ctx, "docker", "run", "--detach",
+		"--name", s.Container,
+		"--env", "POSTGRES_USER="+devUser,
+		"--env", "POSTGRES_PASSWORD="+devPassword,
+		"--env", "POSTGRES_DB="+devDatabase,
+		"--publish", "127.0.0.1:"+strconv.Itoa(s.Port)+":5432",
+		s.Image)
+	if err != nil {
+		return fmt.Errorf("failed to create the dev database container: %w", err)
+	}
+	return nil
+}
+
+// inspect reports whether the container exists and is running. docker
+// inspect fails for a missing container; any other failure, such as docker
+// not being installed, is returned.
+func (s *Stack) inspect(ctx context.Context) (running, exists bool, err error) {
+	out, err := s.Runner.Run(ctx, "docker", "inspect", "--format", "{{.State.Running}}", s.Container)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()+string(out)), "no such") {
+			return false, false, nil
+		}
+		return false, false, fmt.Errorf("failed to inspect the dev database container: %w", err)
+	}
+	return strings.TrimSpace(string(out)) == "true", true, nil
+}
+
+// waitReady polls Ping until the database answers or ReadyTimeout passes.
+func (s *Stack) waitReady(ctx context.Context, dsn string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.ReadyTimeout)
+	defer cancel()
+
+	for {
+		err := s.Ping(ctx, dsn)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("dev database not ready after %s: %w", s.ReadyTimeout, err)
+			}
+			return ctx.Err()
+		case <-time.After(s.PollInterval):
+		}
+	}
+}
+
+func ping(ctx context.Context, dsn string) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	return conn.Close(ctx)
+}
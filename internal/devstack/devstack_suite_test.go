@@ -0,0 +1,13 @@
+package devstack_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+func TestDevstack(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Devstack Suite")
+}
@@ -2,14 +2,427 @@
 
 package gql
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type ActorCount struct {
+	Actor string `json:"actor"`
+	Count int    `json:"count"`
+}
+
+type Capability struct {
+	Name        string                `json:"name"`
+	Kind        CapabilityKind        `json:"kind"`
+	Description string                `json:"description"`
+	Arguments   []*CapabilityArgument `json:"arguments"`
+}
+
+type CapabilityArgument struct {
+	Name string `json:"name"`
+	// The GraphQL type of a query argument, or the JSON Schema type of a tool argument.
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+	// The default value, encoded as GraphQL or JSON respectively.
+	DefaultValue *string `json:"defaultValue,omitempty"`
+}
+
+type CoFailure struct {
+	TestA          string  `json:"testA"`
+	TestB          string  `json:"testB"`
+	CoFailureCount int     `json:"coFailureCount"`
+	TestAFailures  int     `json:"testAFailures"`
+	TestBFailures  int     `json:"testBFailures"`
+	Lift           float64 `json:"lift"`
+}
+
+type DurationOutlier struct {
+	SpecRunID        string  `json:"specRunID"`
+	TestRunID        string  `json:"testRunID"`
+	TestName         string  `json:"testName"`
+	StartTime        *string `json:"startTime,omitempty"`
+	DurationMs       float64 `json:"durationMs"`
+	MeanDurationMs   float64 `json:"meanDurationMs"`
+	StdDevDurationMs float64 `json:"stdDevDurationMs"`
+	// How many standard deviations the run was slower than the mean.
+	Deviations float64 `json:"deviations"`
+}
+
+type DurationPercentiles struct {
+	P50Ms float64 `json:"p50Ms"`
+	P95Ms float64 `json:"p95Ms"`
+	P99Ms float64 `json:"p99Ms"`
+}
+
+type FailureMessage struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
 type FlakyTest struct {
-	TestID      string  `json:"testID"`
-	TestName    string  `json:"testName"`
+	TestID             string            `json:"testID"`
+	TestName           string            `json:"testName"`
+	PassRate           float64           `json:"passRate"`
+	FailureRate        float64           `json:"failureRate"`
+	FlakinessScore     float64           `json:"flakinessScore"`
+	LastFailure        *string           `json:"lastFailure,omitempty"`
+	LastFailureBranch  *string           `json:"lastFailureBranch,omitempty"`
+	LastFailureSha     *string           `json:"lastFailureSha,omitempty"`
+	RunCount           int               `json:"runCount"`
+	TopFailureMessages []*FailureMessage `json:"topFailureMessages,omitempty"`
+	ProjectName        *string           `json:"projectName,omitempty"`
+	// How the spec's runs ended, including the skipped runs left out of runCount.
+	StatusCounts *StatusCounts `json:"statusCounts,omitempty"`
+	// True when the database could not be reached and these are the last results fetched from it.
+	IsStale bool `json:"isStale"`
+}
+
+type FlakyTestConnection struct {
+	Edges    []*FlakyTestEdge `json:"edges"`
+	PageInfo *PageInfo        `json:"pageInfo"`
+}
+
+type FlakyTestEdge struct {
+	Cursor string     `json:"cursor"`
+	Node   *FlakyTest `json:"node"`
+}
+
+type FlakyTestPage struct {
+	Items      []*FlakyTest `json:"items"`
+	TotalCount int          `json:"totalCount"`
+}
+
+type HealthScore struct {
+	ProjectID          string  `json:"projectID"`
+	Score              float64 `json:"score"`
+	SinceDays          int     `json:"sinceDays"`
+	TestCount          int     `json:"testCount"`
+	FlakyTestCount     int     `json:"flakyTestCount"`
+	AverageFailureRate float64 `json:"averageFailureRate"`
+	SlowTestCount      int     `json:"slowTestCount"`
+}
+
+type HealthStatus struct {
+	Status        string `json:"status"`
+	Database      string `json:"database"`
+	SchemaVersion *int   `json:"schemaVersion,omitempty"`
+}
+
+type IngestResult struct {
+	TestRunID   string   `json:"testRunID"`
+	SuiteRunIDs []string `json:"suiteRunIDs"`
+	SpecRunIDs  []string `json:"specRunIDs"`
+}
+
+type Mutation struct {
+}
+
+type PageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor,omitempty"`
+}
+
+type Project struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	TeamName *string `json:"teamName,omitempty"`
+}
+
+type QuarantineResult struct {
+	ID            string  `json:"id"`
+	ProjectID     string  `json:"projectID"`
+	TestName      string  `json:"testName"`
+	Reason        *string `json:"reason,omitempty"`
+	QuarantinedAt string  `json:"quarantinedAt"`
+}
+
+type QuarantinedTest struct {
+	TestName      string  `json:"testName"`
+	Reason        *string `json:"reason,omitempty"`
+	QuarantinedAt string  `json:"quarantinedAt"`
+	// The API key that quarantined the test, as a fingerprint, or null when authentication is disabled.
+	QuarantinedBy *string `json:"quarantinedBy,omitempty"`
+	ExpiresAt     *string `json:"expiresAt,omitempty"`
+	LiftedAt      *string `json:"liftedAt,omitempty"`
+	Active        bool    `json:"active"`
+}
+
+type Query struct {
+}
+
+type SlowTest struct {
+	TestName      string               `json:"testName"`
+	AvgDurationMs float64              `json:"avgDurationMs"`
+	MaxDurationMs float64              `json:"maxDurationMs"`
+	RunCount      int                  `json:"runCount"`
+	Percentiles   *DurationPercentiles `json:"percentiles"`
+}
+
+type SpecRunInput struct {
+	SpecDescription string  `json:"specDescription"`
+	Status          string  `json:"status"`
+	Message         *string `json:"message,omitempty"`
+	StartTime       string  `json:"startTime"`
+	EndTime         string  `json:"endTime"`
+}
+
+// Runs of a spec by outcome. error counts runs that crashed or timed out;
+// failed counts assertion failures and any status that is not recognised.
+type StatusCounts struct {
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Error   int `json:"error"`
+	Skipped int `json:"skipped"`
+}
+
+type Subscription struct {
+}
+
+type SuiteHealth struct {
+	SuiteName      string  `json:"suiteName"`
+	TotalRuns      int     `json:"totalRuns"`
+	FailureCount   int     `json:"failureCount"`
+	PassRate       float64 `json:"passRate"`
+	FlakyTestCount int     `json:"flakyTestCount"`
+}
+
+type SuiteRunInput struct {
+	SuiteName string          `json:"suiteName"`
+	StartTime string          `json:"startTime"`
+	EndTime   string          `json:"endTime"`
+	Specs     []*SpecRunInput `json:"specs"`
+}
+
+type TeamHealth struct {
+	TeamName           string  `json:"teamName"`
+	ProjectCount       int     `json:"projectCount"`
+	TotalRuns          int     `json:"totalRuns"`
+	FlakyTestCount     int     `json:"flakyTestCount"`
+	AverageFailureRate float64 `json:"averageFailureRate"`
+}
+
+// The team owning a spec. team is null when no owner is known.
+type TestOwner struct {
+	ProjectID string          `json:"projectID"`
+	TestName  string          `json:"testName"`
+	Team      *string         `json:"team,omitempty"`
+	Source    OwnershipSource `json:"source"`
+	// The owners file pattern that matched, when source is OWNERS_FILE.
+	Rule *string `json:"rule,omitempty"`
+}
+
+type TestRun struct {
+	ID                string  `json:"id"`
+	GitBranch         *string `json:"gitBranch,omitempty"`
+	GitSha            *string `json:"gitSha,omitempty"`
+	BuildTriggerActor *string `json:"buildTriggerActor,omitempty"`
+	BuildURL          *string `json:"buildUrl,omitempty"`
+	StartTime         *string `json:"startTime,omitempty"`
+	EndTime           *string `json:"endTime,omitempty"`
+}
+
+type TestRunInput struct {
+	ProjectID         string           `json:"projectID"`
+	TestSeed          *int             `json:"testSeed,omitempty"`
+	StartTime         string           `json:"startTime"`
+	EndTime           string           `json:"endTime"`
+	GitBranch         *string          `json:"gitBranch,omitempty"`
+	GitSha            *string          `json:"gitSha,omitempty"`
+	BuildTriggerActor *string          `json:"buildTriggerActor,omitempty"`
+	BuildURL          *string          `json:"buildUrl,omitempty"`
+	Suites            []*SuiteRunInput `json:"suites"`
+}
+
+type TestRunResult struct {
+	SpecRunID string  `json:"specRunID"`
+	TestRunID string  `json:"testRunID"`
+	Status    string  `json:"status"`
+	Passed    bool    `json:"passed"`
+	Message   *string `json:"message,omitempty"`
+	StartTime *string `json:"startTime,omitempty"`
+	EndTime   *string `json:"endTime,omitempty"`
+	GitBranch *string `json:"gitBranch,omitempty"`
+	GitSha    *string `json:"gitSha,omitempty"`
+}
+
+type TrendPoint struct {
+	PeriodStart string  `json:"periodStart"`
 	PassRate    float64 `json:"passRate"`
-	FailureRate float64 `json:"failureRate"`
-	LastFailure *string `json:"lastFailure,omitempty"`
 	RunCount    int     `json:"runCount"`
 }
 
-type Query struct {
+type CapabilityKind string
+
+const (
+	// A field of the GraphQL Query type.
+	CapabilityKindQuery CapabilityKind = "QUERY"
+	// A tool of the MCP server.
+	CapabilityKindTool CapabilityKind = "TOOL"
+)
+
+var AllCapabilityKind = []CapabilityKind{
+	CapabilityKindQuery,
+	CapabilityKindTool,
+}
+
+func (e CapabilityKind) IsValid() bool {
+	switch e {
+	case CapabilityKindQuery, CapabilityKindTool:
+		return true
+	}
+	return false
+}
+
+func (e CapabilityKind) String() string {
+	return string(e)
+}
+
+func (e *CapabilityKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CapabilityKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CapabilityKind", str)
+	}
+	return nil
+}
+
+func (e CapabilityKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FlakyTestSortField string
+
+const (
+	FlakyTestSortFieldFailureRate FlakyTestSortField = "FAILURE_RATE"
+	FlakyTestSortFieldRunCount    FlakyTestSortField = "RUN_COUNT"
+	FlakyTestSortFieldLastFailure FlakyTestSortField = "LAST_FAILURE"
+)
+
+var AllFlakyTestSortField = []FlakyTestSortField{
+	FlakyTestSortFieldFailureRate,
+	FlakyTestSortFieldRunCount,
+	FlakyTestSortFieldLastFailure,
+}
+
+func (e FlakyTestSortField) IsValid() bool {
+	switch e {
+	case FlakyTestSortFieldFailureRate, FlakyTestSortFieldRunCount, FlakyTestSortFieldLastFailure:
+		return true
+	}
+	return false
+}
+
+func (e FlakyTestSortField) String() string {
+	return string(e)
+}
+
+func (e *FlakyTestSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FlakyTestSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FlakyTestSortField", str)
+	}
+	return nil
+}
+
+func (e FlakyTestSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OwnershipSource string
+
+const (
+	// A rule of the owners file matched the spec or one of its suites.
+	OwnershipSourceOwnersFile OwnershipSource = "OWNERS_FILE"
+	// The team of the spec's project.
+	OwnershipSourceProject OwnershipSource = "PROJECT"
+	// Neither the owners file nor the project names a team.
+	OwnershipSourceUnknown OwnershipSource = "UNKNOWN"
+)
+
+var AllOwnershipSource = []OwnershipSource{
+	OwnershipSourceOwnersFile,
+	OwnershipSourceProject,
+	OwnershipSourceUnknown,
+}
+
+func (e OwnershipSource) IsValid() bool {
+	switch e {
+	case OwnershipSourceOwnersFile, OwnershipSourceProject, OwnershipSourceUnknown:
+		return true
+	}
+	return false
+}
+
+func (e OwnershipSource) String() string {
+	return string(e)
+}
+
+func (e *OwnershipSource) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OwnershipSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OwnershipSource", str)
+	}
+	return nil
+}
+
+func (e OwnershipSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "ASC"
+	SortOrderDesc SortOrder = "DESC"
+)
+
+var AllSortOrder = []SortOrder{
+	SortOrderAsc,
+	SortOrderDesc,
+}
+
+func (e SortOrder) IsValid() bool {
+	switch e {
+	case SortOrderAsc, SortOrderDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortOrder) String() string {
+	return string(e)
+}
+
+func (e *SortOrder) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortOrder", str)
+	}
+	return nil
+}
+
+func (e SortOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
@@ -0,0 +1,81 @@
+package resolvers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+const (
+	// DefaultAlertInterval is how often a flakyTestAlerts subscriber's project
+	// is polled when Resolver.AlertInterval is unset.
+	DefaultAlertInterval = 30 * time.Second
+	// DefaultAlertThreshold is the failure rate a test must reach to raise an
+	// alert when Resolver.AlertThreshold is unset.
+	DefaultAlertThreshold = 0.5
+	// alertScanLimit caps how many tests each poll inspects.
+	alertScanLimit = 100
+)
+
+// alertSettings returns the configured poll interval and threshold, falling
+// back to the defaults for zero values.
+func (r *Resolver) alertSettings() (time.Duration, float64) {
+	interval, threshold := r.AlertInterval, r.AlertThreshold
+	if interval <= 0 {
+		interval = DefaultAlertInterval
+	}
+	if threshold <= 0 {
+		threshold = DefaultAlertThreshold
+	}
+	return interval, threshold
+}
+
+// watchFlakyTests polls flaky every interval and sends each test that has
+// newly reached threshold. The first poll only records the tests already
+// over it, so subscribers hear about changes rather than the current state.
+// The returned channel is closed once ctx is done.
+func watchFlakyTests(ctx context.Context, flaky repo.FlakyTestProvider, projectID string, interval time.Duration, threshold float64) <-chan *gql.FlakyTest {
+	alerts := make(chan *gql.FlakyTest)
+	opts := repo.FlakyTestOptions{MinFailureRate: threshold}
+
+	go func() {
+		defer close(alerts)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var alerted map[string]bool
+		for {
+			tests, err := flaky.GetFlakyTests(ctx, projectID, alertScanLimit, opts)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				slog.WarnContext(ctx, "⚠️ Flaky test alert poll failed", "projectID", projectID, "error", err)
+			} else {
+				current := make(map[string]bool, len(tests))
+				for _, test := range tests {
+					current[test.TestName] = true
+					if alerted == nil || alerted[test.TestName] {
+						continue
+					}
+					select {
+					case alerts <- test:
+					case <-ctx.Done():
+						return
+					}
+				}
+				alerted = current
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return alerts
+}
@@ -0,0 +1,96 @@
+package resolvers_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestAlerts Subscription", func() {
+	var (
+		fakeRepo *fakes.FakeFlakyTestProvider
+		resolver *resolvers.Resolver
+		ctx      context.Context
+		cancel   context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeFlakyTestProvider{}
+		resolver = &resolvers.Resolver{
+			FlakyRepo:      fakeRepo,
+			AlertInterval:  5 * time.Millisecond,
+			AlertThreshold: 0.4,
+		}
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(func() { cancel() })
+	})
+
+	It("should alert on tests that newly reach the threshold", func() {
+		login := &gql.FlakyTest{TestName: "login", FailureRate: 0.5}
+		logout := &gql.FlakyTest{TestName: "logout", FailureRate: 0.6}
+		fakeRepo.GetFlakyTestsReturnsOnCall(0, []*gql.FlakyTest{login}, nil)
+		fakeRepo.GetFlakyTestsReturns([]*gql.FlakyTest{login, logout}, nil)
+
+		alerts, err := resolver.Subscription().FlakyTestAlerts(ctx, "demo")
+		Expect(err).To(BeNil())
+
+		Eventually(alerts).Should(Receive(Equal(logout)))
+		Consistently(alerts, 30*time.Millisecond).ShouldNot(Receive())
+
+		_, projectID, _, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(opts.MinFailureRate).To(Equal(0.4))
+	})
+
+	It("should alert again when a test falls below and re-crosses the threshold", func() {
+		login := &gql.FlakyTest{TestName: "login", FailureRate: 0.5}
+		fakeRepo.GetFlakyTestsReturnsOnCall(0, nil, nil)
+		fakeRepo.GetFlakyTestsReturnsOnCall(1, []*gql.FlakyTest{login}, nil)
+		fakeRepo.GetFlakyTestsReturnsOnCall(2, nil, nil)
+		fakeRepo.GetFlakyTestsReturns([]*gql.FlakyTest{login}, nil)
+
+		alerts, err := resolver.Subscription().FlakyTestAlerts(ctx, "demo")
+		Expect(err).To(BeNil())
+
+		Eventually(alerts).Should(Receive(Equal(login)))
+		Eventually(alerts).Should(Receive(Equal(login)))
+	})
+
+	It("should keep polling after a failed poll", func() {
+		login := &gql.FlakyTest{TestName: "login", FailureRate: 0.5}
+		fakeRepo.GetFlakyTestsReturnsOnCall(0, nil, nil)
+		fakeRepo.GetFlakyTestsReturnsOnCall(1, nil, errors.New("connection refused"))
+		fakeRepo.GetFlakyTestsReturns([]*gql.FlakyTest{login}, nil)
+
+		alerts, err := resolver.Subscription().FlakyTestAlerts(ctx, "demo")
+		Expect(err).To(BeNil())
+
+		Eventually(alerts).Should(Receive(Equal(login)))
+	})
+
+	It("should stop polling and close the channel when the subscriber leaves", func() {
+		fakeRepo.GetFlakyTestsReturns(nil, nil)
+
+		alerts, err := resolver.Subscription().FlakyTestAlerts(ctx, "demo")
+		Expect(err).To(BeNil())
+		Eventually(fakeRepo.GetFlakyTestsCallCount).Should(BeNumerically(">=", 2))
+
+		cancel()
+		Eventually(alerts).Should(BeClosed())
+		calls := fakeRepo.GetFlakyTestsCallCount()
+		Consistently(fakeRepo.GetFlakyTestsCallCount, 30*time.Millisecond).Should(Equal(calls))
+	})
+
+	It("should reject an empty project ID", func() {
+		_, err := resolver.Subscription().FlakyTestAlerts(ctx, " ")
+		Expect(err).To(MatchError("projectID must not be empty"))
+		Expect(fakeRepo.GetFlakyTestsCallCount()).To(BeZero())
+	})
+})
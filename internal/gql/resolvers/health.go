@@ -0,0 +1,59 @@
+package resolvers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// healthCheckTimeout bounds the database checks performed by the health resolver.
+const healthCheckTimeout = 2 * time.Second
+
+// Values reported in HealthStatus.
+const (
+	healthOK          = "ok"
+	healthDegraded    = "degraded"
+	healthUnreachable = "unreachable"
+	healthUnknown     = "unknown"
+)
+
+// healthStatus pings the database and reads the schema version. Failures
+// are reported in the result, marking it degraded, rather than as errors so
+// callers always learn which subsystem is unhealthy. Without a DB the
+// database status is unknown.
+func (r *Resolver) healthStatus(ctx context.Context) *gql.HealthStatus {
+	status := &gql.HealthStatus{Status: healthOK, Database: healthUnknown}
+	if r.DB == nil {
+		return status
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	if err := r.DB.Ping(ctx); err != nil {
+		slog.WarnContext(ctx, "⚠️ Health check ping failed", "error", err)
+		status.Status = healthDegraded
+		status.Database = healthUnreachable
+		return status
+	}
+	status.Database = healthOK
+
+	if r.Schema == nil {
+		return status
+	}
+	version, dirty, err := r.Schema.SchemaVersion(ctx)
+	switch {
+	case err != nil:
+		slog.WarnContext(ctx, "⚠️ Health check could not read the schema version", "error", err)
+		status.Status = healthDegraded
+	case dirty:
+		// A failed migration leaves the schema in an unknown state.
+		status.Status = healthDegraded
+		status.SchemaVersion = &version
+	case version > 0:
+		status.SchemaVersion = &version
+	}
+	return status
+}
@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// maxIngestSpecs caps the spec runs a single ingestTestRun call may write, so
+// one request cannot hold a transaction open indefinitely.
+const maxIngestSpecs = 5000
+
+// ingestStatuses are the spec statuses ingestTestRun accepts.
+var ingestStatuses = slices.Concat(repo.DefaultSuccessStatuses, repo.DefaultFailureStatuses, repo.DefaultIgnoredStatuses)
+
+// validateIngestInput rejects test runs with blank required fields, unknown
+// statuses, or times that are not RFC 3339 or end before they start.
+func validateIngestInput(input *gql.TestRunInput) error {
+	if strings.TrimSpace(input.ProjectID) == "" {
+		return repo.InvalidArgumentf("projectID must not be empty")
+	}
+	if err := validateTimeRange("testRun", input.StartTime, input.EndTime); err != nil {
+		return err
+	}
+	if len(input.Suites) == 0 {
+		return repo.InvalidArgumentf("suites must not be empty")
+	}
+
+	specs := 0
+	for i, suite := range input.Suites {
+		suiteField := fmt.Sprintf("suites[%d]", i)
+		if strings.TrimSpace(suite.SuiteName) == "" {
+			return repo.InvalidArgumentf("%s.suiteName must not be empty", suiteField)
+		}
+		if err := validateTimeRange(suiteField, suite.StartTime, suite.EndTime); err != nil {
+			return err
+		}
+		for j, spec := range suite.Specs {
+			field := fmt.Sprintf("%s.specs[%d]", suiteField, j)
+			if strings.TrimSpace(spec.SpecDescription) == "" {
+				return repo.InvalidArgumentf("%s.specDescription must not be empty", field)
+			}
+			if !slices.Contains(ingestStatuses, spec.Status) {
+				return repo.InvalidArgumentf("%s.status %q must be one of %s", field, spec.Status, strings.Join(ingestStatuses, ", "))
+			}
+			if err := validateTimeRange(field, spec.StartTime, spec.EndTime); err != nil {
+				return err
+			}
+		}
+		specs += len(suite.Specs)
+	}
+	if specs > maxIngestSpecs {
+		return repo.InvalidArgumentf("at most %d specs may be ingested at once, got %d", maxIngestSpecs, specs)
+	}
+	return nil
+}
+
+// validateTimeRange checks that field's startTime and endTime are RFC 3339
+// timestamps and that it does not end before it starts.
+func validateTimeRange(field, startTime, endTime string) error {
+	start, err := time.Parse(time.RFC3339, startTime)
+	if err != nil {
+		return repo.InvalidArgumentf("%s.startTime must be an RFC 3339 timestamp, got %q", field, startTime)
+	}
+	end, err := time.Parse(time.RFC3339, endTime)
+	if err != nil {
+		return repo.InvalidArgumentf("%s.endTime must be an RFC 3339 timestamp, got %q", field, endTime)
+	}
+	if end.Before(start) {
+		return repo.InvalidArgumentf("%s.endTime must not be before its startTime", field)
+	}
+	return nil
+}
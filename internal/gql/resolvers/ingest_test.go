@@ -0,0 +1,92 @@
+package resolvers_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("IngestTestRun Resolver", func() {
+	var (
+		fakeRepo *fakes.FakeIngestProvider
+		resolver *resolvers.Resolver
+		ctx      context.Context
+		input    gql.TestRunInput
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeIngestProvider{}
+		resolver = &resolvers.Resolver{IngestRepo: fakeRepo}
+		ctx = context.Background()
+		input = gql.TestRunInput{
+			ProjectID: "demo",
+			StartTime: "2026-10-01T12:00:00Z",
+			EndTime:   "2026-10-01T12:05:00Z",
+			Suites: []*gql.SuiteRunInput{{
+				SuiteName: "Auth Suite",
+				StartTime: "2026-10-01T12:00:00Z",
+				EndTime:   "2026-10-01T12:05:00Z",
+				Specs: []*gql.SpecRunInput{
+					{SpecDescription: "login", Status: "passed", StartTime: "2026-10-01T12:00:00Z", EndTime: "2026-10-01T12:01:00Z"},
+					{SpecDescription: "logout", Status: "failed", StartTime: "2026-10-01T12:01:00Z", EndTime: "2026-10-01T12:02:00Z"},
+				},
+			}},
+		}
+	})
+
+	It("should store a valid test run and return the created IDs", func() {
+		expected := &gql.IngestResult{TestRunID: "10", SuiteRunIDs: []string{"20"}, SpecRunIDs: []string{"30", "31"}}
+		fakeRepo.IngestTestRunReturns(expected, nil)
+
+		result, err := resolver.Mutation().IngestTestRun(ctx, input)
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		Expect(fakeRepo.IngestTestRunCallCount()).To(Equal(1))
+		_, stored := fakeRepo.IngestTestRunArgsForCall(0)
+		Expect(stored.ProjectID).To(Equal("demo"))
+		Expect(stored.Suites[0].Specs).To(HaveLen(2))
+	})
+
+	DescribeTable("should reject invalid input without touching the repository",
+		func(mutate func(*gql.TestRunInput), message string) {
+			mutate(&input)
+
+			_, err := resolver.Mutation().IngestTestRun(ctx, input)
+
+			Expect(err).To(MatchError(message))
+			Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+			Expect(fakeRepo.IngestTestRunCallCount()).To(Equal(0))
+		},
+		Entry("a blank project", func(in *gql.TestRunInput) { in.ProjectID = " " },
+			"projectID must not be empty"),
+		Entry("a malformed start time", func(in *gql.TestRunInput) { in.StartTime = "yesterday" },
+			`testRun.startTime must be an RFC 3339 timestamp, got "yesterday"`),
+		Entry("a run ending before it starts", func(in *gql.TestRunInput) { in.EndTime = "2026-10-01T11:00:00Z" },
+			"testRun.endTime must not be before its startTime"),
+		Entry("no suites", func(in *gql.TestRunInput) { in.Suites = nil },
+			"suites must not be empty"),
+		Entry("a blank suite name", func(in *gql.TestRunInput) { in.Suites[0].SuiteName = "" },
+			"suites[0].suiteName must not be empty"),
+		Entry("a blank spec description", func(in *gql.TestRunInput) { in.Suites[0].Specs[1].SpecDescription = "" },
+			"suites[0].specs[1].specDescription must not be empty"),
+		Entry("an unknown status", func(in *gql.TestRunInput) { in.Suites[0].Specs[0].Status = "exploded" },
+			`suites[0].specs[0].status "exploded" must be one of passed, pass, failed, fail, skipped, pending`),
+		Entry("a malformed spec end time", func(in *gql.TestRunInput) { in.Suites[0].Specs[0].EndTime = "" },
+			`suites[0].specs[0].endTime must be an RFC 3339 timestamp, got ""`),
+	)
+
+	It("should propagate repository errors", func() {
+		fakeRepo.IngestTestRunReturns(nil, repo.ErrProjectNotFound)
+
+		_, err := resolver.Mutation().IngestTestRun(ctx, input)
+		Expect(err).To(MatchError(repo.ErrProjectNotFound))
+	})
+})
@@ -0,0 +1,70 @@
+package resolvers
+
+import (
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// DefaultMaxLimit is the largest flakyTests limit accepted when
+// Resolver.MaxLimit is unset.
+const DefaultMaxLimit = 100
+
+// DefaultLimit is the flakyTests limit used when the query omits it and
+// Resolver.DefaultLimit is unset.
+const DefaultLimit = 20
+
+// resolveLimit returns limit, or the default limit when it is nil, after
+// checking it with validateLimit.
+func (r *Resolver) resolveLimit(limit *int) (int, error) {
+	resolved := r.DefaultLimit
+	if resolved <= 0 {
+		resolved = DefaultLimit
+	}
+	if limit != nil {
+		resolved = *limit
+	}
+	return resolved, r.validateLimit(resolved)
+}
+
+// validateLimit rejects limits outside [1, MaxLimit].
+func (r *Resolver) validateLimit(limit int) error {
+	maxLimit := r.MaxLimit
+	if maxLimit <= 0 {
+		maxLimit = DefaultMaxLimit
+	}
+	if limit < 1 || limit > maxLimit {
+		return repo.InvalidArgumentf("limit must be between 1 and %d, got %d", maxLimit, limit)
+	}
+	return nil
+}
+
+// namePattern returns the pattern removed from spec names when normalize is
+// set, and the empty pattern, grouping by raw name, otherwise.
+func (r *Resolver) namePattern(normalize bool) string {
+	if !normalize {
+		return ""
+	}
+	if r.NamePattern == "" {
+		return repo.DefaultNamePattern
+	}
+	return r.NamePattern
+}
+
+// flakyTestOptions collects the filter and sort arguments shared by the
+// flakyTests and flakyTestsPage queries.
+func flakyTestOptions(suiteName *string, offset int, sinceDays *int, sortBy gql.FlakyTestSortField, sortOrder gql.SortOrder, minRuns int, minFailureRate float64) repo.FlakyTestOptions {
+	opts := repo.FlakyTestOptions{
+		Offset:         offset,
+		SortBy:         sortBy,
+		SortOrder:      sortOrder,
+		MinRuns:        minRuns,
+		MinFailureRate: minFailureRate,
+	}
+	if suiteName != nil {
+		opts.SuiteName = *suiteName
+	}
+	if sinceDays != nil {
+		opts.SinceDays = *sinceDays
+	}
+	return opts
+}
@@ -0,0 +1,27 @@
+package resolvers
+
+import (
+	"strings"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// maxQuarantineReasonLength caps the free-form reason stored with a quarantine.
+const maxQuarantineReasonLength = 1000
+
+// ErrAlreadyQuarantined is returned when a test already has an active quarantine.
+var ErrAlreadyQuarantined = repo.ErrAlreadyQuarantined
+
+// validateQuarantineInput rejects blank identifiers and oversized reasons.
+func validateQuarantineInput(projectID, testName string, reason *string) error {
+	if strings.TrimSpace(projectID) == "" {
+		return repo.InvalidArgumentf("projectID must not be empty")
+	}
+	if strings.TrimSpace(testName) == "" {
+		return repo.InvalidArgumentf("testName must not be empty")
+	}
+	if reason != nil && len(*reason) > maxQuarantineReasonLength {
+		return repo.InvalidArgumentf("reason must be at most %d characters, got %d", maxQuarantineReasonLength, len(*reason))
+	}
+	return nil
+}
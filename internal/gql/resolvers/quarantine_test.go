@@ -0,0 +1,96 @@
+package resolvers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("QuarantineTest Resolver", func() {
+	var (
+		fakeRepo *fakes.FakeQuarantineProvider
+		resolver *resolvers.Resolver
+		ctx      context.Context
+		reason   string
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeQuarantineProvider{}
+		resolver = &resolvers.Resolver{QuarantineRepo: fakeRepo}
+		ctx = context.Background()
+		reason = "fails on slow runners"
+	})
+
+	It("should quarantine a test", func() {
+		expected := &gql.QuarantineResult{ID: "1", ProjectID: "uuid-1", TestName: "login", Reason: &reason, QuarantinedAt: "2025-04-01T10:00:00Z"}
+		fakeRepo.QuarantineTestReturns(expected, nil)
+
+		result, err := resolver.Mutation().QuarantineTest(ctx, "demo", "login", &reason)
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+
+		_, projID, testName, gotReason := fakeRepo.QuarantineTestArgsForCall(0)
+		Expect(projID).To(Equal("demo"))
+		Expect(testName).To(Equal("login"))
+		Expect(gotReason).To(Equal(&reason))
+	})
+
+	It("should reject a test that is already quarantined", func() {
+		fakeRepo.QuarantineTestReturns(nil, fmt.Errorf("%w: %q", repo.ErrAlreadyQuarantined, "login"))
+
+		result, err := resolver.Mutation().QuarantineTest(ctx, "demo", "login", nil)
+
+		Expect(err).To(MatchError(resolvers.ErrAlreadyQuarantined))
+		Expect(result).To(BeNil())
+	})
+
+	It("should reject blank identifiers and oversized reasons without touching the repository", func() {
+		long := strings.Repeat("x", 1001)
+
+		_, err := resolver.Mutation().QuarantineTest(ctx, " ", "login", nil)
+		Expect(err).To(MatchError("projectID must not be empty"))
+
+		_, err = resolver.Mutation().QuarantineTest(ctx, "demo", "", nil)
+		Expect(err).To(MatchError("testName must not be empty"))
+
+		_, err = resolver.Mutation().QuarantineTest(ctx, "demo", "login", &long)
+		Expect(err).To(MatchError(ContainSubstring("reason must be at most 1000 characters")))
+
+		Expect(fakeRepo.QuarantineTestCallCount()).To(Equal(0))
+	})
+
+	It("should propagate repository errors", func() {
+		fakeRepo.QuarantineTestReturns(nil, errors.New("db down"))
+
+		_, err := resolver.Mutation().QuarantineTest(ctx, "demo", "login", nil)
+		Expect(err).To(MatchError("db down"))
+	})
+})
+
+var _ = Describe("QuarantinedTests Resolver", func() {
+	It("should pass the includeInactive flag to the repository", func() {
+		fakeRepo := &fakes.FakeQuarantineProvider{}
+		resolver := &resolvers.Resolver{QuarantineRepo: fakeRepo}
+		expected := []*gql.QuarantinedTest{{TestName: "login", QuarantinedAt: "2025-04-01T10:00:00Z", Active: true}}
+		fakeRepo.ListQuarantinedTestsReturns(expected, nil)
+
+		result, err := resolver.Query().QuarantinedTests(context.Background(), "demo", true)
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+
+		_, projID, includeInactive := fakeRepo.ListQuarantinedTestsArgsForCall(0)
+		Expect(projID).To(Equal("demo"))
+		Expect(includeInactive).To(BeTrue())
+	})
+})
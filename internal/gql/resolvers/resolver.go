@@ -1,11 +1,57 @@
 package resolvers
 
-import "github.com/guidewire-oss/fern-mycelium/pkg/repo"
+import (
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/capabilities"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
 
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	FlakyRepo repo.FlakyTestProvider
+	FlakyRepo      repo.FlakyTestProvider
+	FlakyPager     repo.FlakyTestPager
+	FlakyCounter   repo.FlakyTestCounter
+	SlowRepo       repo.SlowTestProvider
+	OutlierRepo    repo.DurationOutlierProvider
+	TrendRepo      repo.TrendProvider
+	ActorRepo      repo.FailureActorProvider
+	CoFailures     repo.CoFailureProvider
+	ProjectRepo    repo.ProjectProvider
+	SuiteRepo      repo.SuiteHealthProvider
+	TeamRepo       repo.TeamHealthProvider
+	OwnerRepo      repo.OwnershipProvider
+	HealthScores   repo.HealthScoreProvider
+	TopFailing     repo.TopFailingTestProvider
+	TestImpact     repo.TestImpactProvider
+	NewlyFlaky     repo.NewlyFlakyTestProvider
+	TestRunRepo    repo.TestRunProvider
+	HistoryRepo    repo.TestHistoryProvider
+	QuarantineRepo repo.QuarantineProvider
+	IngestRepo     repo.IngestProvider
+	// FlakyBatcher, when set, lets the flakyTests fields of one request share
+	// a single query; see loader.NewProvider.
+	FlakyBatcher repo.FlakyTestBatcher
+	// AlertInterval and AlertThreshold tune the flakyTestAlerts subscription;
+	// zero values use DefaultAlertInterval and DefaultAlertThreshold.
+	AlertInterval  time.Duration
+	AlertThreshold float64
+	// MaxLimit is the largest flakyTests limit accepted; zero uses
+	// DefaultMaxLimit.
+	MaxLimit int
+	// DefaultLimit is the flakyTests limit applied when the query omits
+	// one; zero uses DefaultLimit.
+	DefaultLimit int
+	// NamePattern is removed from spec names when a flakyTests query asks
+	// for normalizeNames; empty uses repo.DefaultNamePattern.
+	NamePattern string
+	// DB is used by the health resolver to verify the database is reachable.
+	DB repo.Pinger
+	// Schema reports the migration version to the health resolver.
+	Schema repo.SchemaVersionReader
+	// Registry lists the queries and tools reported by capabilities.
+	Registry *capabilities.Registry
 }
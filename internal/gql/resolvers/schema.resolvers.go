@@ -6,35 +6,261 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
+	"strings"
 
 	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// QuarantineTest is the resolver for the quarantineTest field.
+func (r *mutationResolver) QuarantineTest(ctx context.Context, projectID string, testName string, reason *string) (*gql.QuarantineResult, error) {
+	if err := validateQuarantineInput(projectID, testName, reason); err != nil {
+		return nil, err
+	}
+
+	return r.QuarantineRepo.QuarantineTest(ctx, projectID, testName, reason)
+}
+
+// IngestTestRun is the resolver for the ingestTestRun field.
+func (r *mutationResolver) IngestTestRun(ctx context.Context, input gql.TestRunInput) (*gql.IngestResult, error) {
+	if err := validateIngestInput(&input); err != nil {
+		return nil, err
+	}
+	return r.IngestRepo.IngestTestRun(ctx, &input)
+}
+
 // Health is the resolver for the health field.
-func (r *queryResolver) Health(ctx context.Context) (string, error) {
-	panic(fmt.Errorf("not implemented: Health - health"))
+func (r *queryResolver) Health(ctx context.Context) (*gql.HealthStatus, error) {
+	return r.healthStatus(ctx), nil
+}
+
+// Capabilities is the resolver for the capabilities field.
+func (r *queryResolver) Capabilities(ctx context.Context) ([]*gql.Capability, error) {
+	return r.Registry.List(), nil
 }
 
 // FlakyTests is the resolver for the flakyTests field.
-func (r *queryResolver) FlakyTests(ctx context.Context, limit int, projectID string) ([]*gql.FlakyTest, error) {
-	// mock := []*gql.FlakyTest{
-	// 	{
-	// 		TestID:      "auth-invalid-token",
-	// 		TestName:    "LoginService handles expired tokens",
-	// 		PassRate:    0.72,
-	// 		FailureRate: 0.28,
-	// 		LastFailure: "2025-03-30T18:44:10Z",
-	// 		RunCount:    50,
-	// 	},
-	// }
-
-	return r.FlakyRepo.GetFlakyTests(ctx, projectID, limit)
-	// Eventually: fetch by projectID from DB
-	// return mock, nil
+func (r *queryResolver) FlakyTests(ctx context.Context, limit *int, projectID string, suiteName *string, offset int, sinceDays *int, sortBy gql.FlakyTestSortField, sortOrder gql.SortOrder, minRuns int, minFailureRate float64, normalizeNames bool) ([]*gql.FlakyTest, error) {
+	n, err := r.resolveLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	opts := flakyTestOptions(suiteName, offset, sinceDays, sortBy, sortOrder, minRuns, minFailureRate)
+	opts.NamePattern = r.namePattern(normalizeNames)
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "queryResolver.FlakyTests", trace.WithAttributes(
+		attribute.String("mycelium.project_id", projectID),
+		attribute.Int("mycelium.limit", n),
+	))
+	defer span.End()
+
+	tests, err := r.FlakyRepo.GetFlakyTests(ctx, projectID, n, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return tests, nil
+}
+
+// FlakyTestsPage is the resolver for the flakyTestsPage field.
+func (r *queryResolver) FlakyTestsPage(ctx context.Context, limit int, projectID string, suiteName *string, offset int, sinceDays *int, sortBy gql.FlakyTestSortField, sortOrder gql.SortOrder, minRuns int, minFailureRate float64, normalizeNames bool) (*gql.FlakyTestPage, error) {
+	if err := r.validateLimit(limit); err != nil {
+		return nil, err
+	}
+	opts := flakyTestOptions(suiteName, offset, sinceDays, sortBy, sortOrder, minRuns, minFailureRate)
+	opts.NamePattern = r.namePattern(normalizeNames)
+
+	items, err := r.FlakyRepo.GetFlakyTests(ctx, projectID, limit, opts)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.FlakyCounter.CountFlakyTests(ctx, projectID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &gql.FlakyTestPage{Items: items, TotalCount: total}, nil
+}
+
+// FlakyTestsConnection is the resolver for the flakyTestsConnection field.
+func (r *queryResolver) FlakyTestsConnection(ctx context.Context, projectID string, first int, after *string) (*gql.FlakyTestConnection, error) {
+	var cursor *repo.FlakyTestCursor
+	if after != nil {
+		decoded, err := repo.DecodeCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		cursor = decoded
+	}
+
+	tests, hasNext, err := r.FlakyPager.GetFlakyTestsPage(ctx, projectID, first, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &gql.FlakyTestConnection{
+		Edges:    make([]*gql.FlakyTestEdge, 0, len(tests)),
+		PageInfo: &gql.PageInfo{HasNextPage: hasNext},
+	}
+	for _, test := range tests {
+		conn.Edges = append(conn.Edges, &gql.FlakyTestEdge{
+			Cursor: repo.EncodeCursor(repo.CursorFor(test)),
+			Node:   test,
+		})
+	}
+	if n := len(conn.Edges); n > 0 {
+		conn.PageInfo.EndCursor = &conn.Edges[n-1].Cursor
+	}
+
+	return conn, nil
+}
+
+// SlowestTests is the resolver for the slowestTests field.
+func (r *queryResolver) SlowestTests(ctx context.Context, limit int, projectID string) ([]*gql.SlowTest, error) {
+	return r.SlowRepo.GetSlowestTests(ctx, projectID, limit)
+}
+
+// DurationOutliers is the resolver for the durationOutliers field.
+func (r *queryResolver) DurationOutliers(ctx context.Context, projectID string, stdDevThreshold *float64) ([]*gql.DurationOutlier, error) {
+	threshold := repo.DefaultOutlierStdDevs
+	if stdDevThreshold != nil {
+		threshold = *stdDevThreshold
+	}
+	return r.OutlierRepo.GetDurationOutliers(ctx, projectID, threshold)
+}
+
+// PassRateTrend is the resolver for the passRateTrend field.
+func (r *queryResolver) PassRateTrend(ctx context.Context, projectID string, testName string, bucket string) ([]*gql.TrendPoint, error) {
+	return r.TrendRepo.GetPassRateTrend(ctx, projectID, testName, bucket)
+}
+
+// FailureActors is the resolver for the failureActors field.
+func (r *queryResolver) FailureActors(ctx context.Context, projectID string, testName string, sinceDays *int) ([]*gql.ActorCount, error) {
+	var days int
+	if sinceDays != nil {
+		days = *sinceDays
+	}
+	return r.ActorRepo.GetFailureActors(ctx, projectID, testName, days)
+}
+
+// CoFailingTests is the resolver for the coFailingTests field.
+func (r *queryResolver) CoFailingTests(ctx context.Context, projectID string, limit int) ([]*gql.CoFailure, error) {
+	if err := r.validateLimit(limit); err != nil {
+		return nil, err
+	}
+	return r.CoFailures.GetCoFailingTests(ctx, projectID, limit)
 }
 
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context, teamName *string) ([]*gql.Project, error) {
+	var team string
+	if teamName != nil {
+		team = *teamName
+	}
+	return r.ProjectRepo.ListProjects(ctx, team)
+}
+
+// SuiteHealth is the resolver for the suiteHealth field.
+func (r *queryResolver) SuiteHealth(ctx context.Context, projectID string) ([]*gql.SuiteHealth, error) {
+	return r.SuiteRepo.GetSuiteHealth(ctx, projectID)
+}
+
+// TeamHealth is the resolver for the teamHealth field.
+func (r *queryResolver) TeamHealth(ctx context.Context, teamName string) (*gql.TeamHealth, error) {
+	if strings.TrimSpace(teamName) == "" {
+		return nil, repo.InvalidArgumentf("teamName must not be empty")
+	}
+	return r.TeamRepo.GetTeamHealth(ctx, teamName)
+}
+
+// TestOwner is the resolver for the testOwner field.
+func (r *queryResolver) TestOwner(ctx context.Context, projectID string, testName string) (*gql.TestOwner, error) {
+	if strings.TrimSpace(testName) == "" {
+		return nil, repo.InvalidArgumentf("testName must not be empty")
+	}
+	return r.OwnerRepo.GetTestOwner(ctx, projectID, testName)
+}
+
+// ProjectHealthScore is the resolver for the projectHealthScore field.
+func (r *queryResolver) ProjectHealthScore(ctx context.Context, projectID string) (*gql.HealthScore, error) {
+	return r.HealthScores.GetProjectHealthScore(ctx, projectID)
+}
+
+// TopFailingTests is the resolver for the topFailingTests field.
+func (r *queryResolver) TopFailingTests(ctx context.Context, limit int, sinceDays *int) ([]*gql.FlakyTest, error) {
+	if err := r.validateLimit(limit); err != nil {
+		return nil, err
+	}
+	var days int
+	if sinceDays != nil {
+		days = *sinceDays
+	}
+	return r.TopFailing.GetTopFailingTests(ctx, limit, days)
+}
+
+// RecentTestRuns is the resolver for the recentTestRuns field.
+func (r *queryResolver) RecentTestRuns(ctx context.Context, projectID *string, limit int) ([]*gql.TestRun, error) {
+	if err := r.validateLimit(limit); err != nil {
+		return nil, err
+	}
+	var project string
+	if projectID != nil {
+		project = *projectID
+	}
+	return r.TestRunRepo.GetRecentTestRuns(ctx, project, limit)
+}
+
+// TestHistory is the resolver for the testHistory field.
+func (r *queryResolver) TestHistory(ctx context.Context, projectID string, testName string, limit int) ([]*gql.TestRunResult, error) {
+	if err := r.validateLimit(limit); err != nil {
+		return nil, err
+	}
+	return r.HistoryRepo.GetTestHistory(ctx, projectID, testName, limit)
+}
+
+// QuarantinedTests is the resolver for the quarantinedTests field.
+func (r *queryResolver) QuarantinedTests(ctx context.Context, projectID string, includeInactive bool) ([]*gql.QuarantinedTest, error) {
+	return r.QuarantineRepo.ListQuarantinedTests(ctx, projectID, includeInactive)
+}
+
+// TestsForFiles is the resolver for the testsForFiles field.
+func (r *queryResolver) TestsForFiles(ctx context.Context, projectID string, files []string) ([]*gql.FlakyTest, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, repo.InvalidArgumentf("projectID must not be empty")
+	}
+	return r.TestImpact.GetTestsForFiles(ctx, projectID, files)
+}
+
+// NewlyFlakyTests is the resolver for the newlyFlakyTests field.
+func (r *queryResolver) NewlyFlakyTests(ctx context.Context, projectID string, recentDays int, baselineDays int, minIncrease float64) ([]*gql.FlakyTest, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, repo.InvalidArgumentf("projectID must not be empty")
+	}
+	return r.NewlyFlaky.GetNewlyFlakyTests(ctx, projectID, recentDays, baselineDays, minIncrease)
+}
+
+// FlakyTestAlerts is the resolver for the flakyTestAlerts field.
+func (r *subscriptionResolver) FlakyTestAlerts(ctx context.Context, projectID string) (<-chan *gql.FlakyTest, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, repo.InvalidArgumentf("projectID must not be empty")
+	}
+	interval, threshold := r.alertSettings()
+	return watchFlakyTests(ctx, r.FlakyRepo, projectID, interval, threshold), nil
+}
+
+// Mutation returns gql.MutationResolver implementation.
+func (r *Resolver) Mutation() gql.MutationResolver { return &mutationResolver{r} }
+
 // Query returns gql.QueryResolver implementation.
 func (r *Resolver) Query() gql.QueryResolver { return &queryResolver{r} }
 
+// Subscription returns gql.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() gql.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
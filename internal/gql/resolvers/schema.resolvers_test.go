@@ -2,13 +2,18 @@ package resolvers_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/guidewire-oss/fern-mycelium/internal/gql"
 	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/loader"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
 	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
 )
 
@@ -17,6 +22,8 @@ func TestResolvers(t *testing.T) {
 	RunSpecs(t, "Resolver Suite")
 }
 
+func intPtr(n int) *int { return &n }
+
 var _ = Describe("FlakyTests Resolver", func() {
 	var (
 		fakeRepo *fakes.FakeFlakyTestProvider
@@ -44,14 +51,801 @@ var _ = Describe("FlakyTests Resolver", func() {
 
 		fakeRepo.GetFlakyTestsReturns(expected, nil)
 
-		result, err := resolver.Query().FlakyTests(ctx, 1, "policy-admin-ui")
+		result, err := resolver.Query().FlakyTests(ctx, intPtr(1), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
 
 		Expect(err).To(BeNil())
 		Expect(result).To(Equal(expected))
 		Expect(fakeRepo.GetFlakyTestsCallCount()).To(Equal(1))
 
-		_, projID, limit := fakeRepo.GetFlakyTestsArgsForCall(0)
+		_, projID, limit, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
 		Expect(projID).To(Equal("policy-admin-ui"))
 		Expect(limit).To(Equal(1))
+		Expect(opts.SuiteName).To(BeEmpty())
+	})
+
+	It("should pass the optional suite name filter to the repository", func() {
+		suiteName := "Auth Suite"
+
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", &suiteName, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+
+		Expect(err).To(BeNil())
+		_, _, _, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(opts.SuiteName).To(Equal("Auth Suite"))
+	})
+
+	It("should pass the offset to the repository", func() {
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", nil, 10, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+
+		Expect(err).To(BeNil())
+		_, _, _, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(opts.Offset).To(Equal(10))
+	})
+
+	It("should pass the lookback window to the repository", func() {
+		sinceDays := 7
+
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", nil, 0, &sinceDays, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+
+		Expect(err).To(BeNil())
+		_, _, _, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(opts.SinceDays).To(Equal(7))
+	})
+
+	It("should normalize names with the configured pattern only when asked", func() {
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+		Expect(err).To(BeNil())
+		_, _, _, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(opts.NamePattern).To(BeEmpty())
+
+		_, err = resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, true)
+		Expect(err).To(BeNil())
+		_, _, _, opts = fakeRepo.GetFlakyTestsArgsForCall(1)
+		Expect(opts.NamePattern).To(Equal(repo.DefaultNamePattern))
+
+		resolver.NamePattern = `\s*\(.*\)`
+		_, err = resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, true)
+		Expect(err).To(BeNil())
+		_, _, _, opts = fakeRepo.GetFlakyTestsArgsForCall(2)
+		Expect(opts.NamePattern).To(Equal(`\s*\(.*\)`))
+	})
+
+	It("should pass the sort options to the repository", func() {
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldRunCount, gql.SortOrderAsc, 1, 0, false)
+
+		Expect(err).To(BeNil())
+		_, _, _, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(opts.SortBy).To(Equal(gql.FlakyTestSortFieldRunCount))
+		Expect(opts.SortOrder).To(Equal(gql.SortOrderAsc))
+	})
+
+	It("should pass the minimum run count to the repository", func() {
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 3, 0, false)
+
+		Expect(err).To(BeNil())
+		_, _, _, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(opts.MinRuns).To(Equal(3))
+	})
+
+	It("should pass the failure rate threshold to the repository", func() {
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(5), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0.25, false)
+
+		Expect(err).To(BeNil())
+		_, _, _, opts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(opts.MinFailureRate).To(Equal(0.25))
+	})
+
+	It("should reject limits outside the allowed range without querying", func() {
+		for _, limit := range []int{0, -5, resolvers.DefaultMaxLimit + 1} {
+			result, err := resolver.Query().FlakyTests(ctx, intPtr(limit), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+
+			Expect(err).To(MatchError(ContainSubstring("limit must be between 1 and 100")))
+			Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+			Expect(result).To(BeNil())
+		}
+		Expect(fakeRepo.GetFlakyTestsCallCount()).To(Equal(0))
+	})
+
+	It("should apply the default limit when none is given", func() {
+		_, err := resolver.Query().FlakyTests(ctx, nil, "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+		Expect(err).To(BeNil())
+		_, _, limit, _ := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(limit).To(Equal(resolvers.DefaultLimit))
+
+		resolver.DefaultLimit = 7
+		_, err = resolver.Query().FlakyTests(ctx, nil, "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+		Expect(err).To(BeNil())
+		_, _, limit, _ = fakeRepo.GetFlakyTestsArgsForCall(1)
+		Expect(limit).To(Equal(7))
+	})
+
+	It("should prefer an explicit limit over the default", func() {
+		resolver.DefaultLimit = 7
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(3), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+		Expect(err).To(BeNil())
+		_, _, limit, _ := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(limit).To(Equal(3))
+	})
+
+	It("should honour a configured maximum limit", func() {
+		resolver.MaxLimit = 500
+
+		_, err := resolver.Query().FlakyTests(ctx, intPtr(500), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+		Expect(err).To(BeNil())
+
+		_, err = resolver.Query().FlakyTests(ctx, intPtr(501), "policy-admin-ui", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+		Expect(err).To(MatchError("limit must be between 1 and 500, got 501"))
+		Expect(fakeRepo.GetFlakyTestsCallCount()).To(Equal(1))
+	})
+})
+
+var _ = Describe("Health Resolver", func() {
+	var (
+		fakePinger *fakes.FakePinger
+		fakeSchema *fakes.FakeSchemaVersionReader
+		resolver   *resolvers.Resolver
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		fakePinger = &fakes.FakePinger{}
+		fakeSchema = &fakes.FakeSchemaVersionReader{}
+		resolver = &resolvers.Resolver{DB: fakePinger, Schema: fakeSchema}
+		ctx = context.Background()
+	})
+
+	It("should report ok with the schema version when the database is reachable", func() {
+		fakeSchema.SchemaVersionReturns(12, false, nil)
+
+		status, err := resolver.Query().Health(ctx)
+
+		Expect(err).To(BeNil())
+		version := 12
+		Expect(status).To(Equal(&gql.HealthStatus{Status: "ok", Database: "ok", SchemaVersion: &version}))
+		Expect(fakePinger.PingCallCount()).To(Equal(1))
+	})
+
+	It("should report a degraded database when it is unreachable", func() {
+		fakePinger.PingReturns(errors.New("connection refused"))
+
+		status, err := resolver.Query().Health(ctx)
+
+		Expect(err).To(BeNil())
+		Expect(status).To(Equal(&gql.HealthStatus{Status: "degraded", Database: "unreachable"}))
+		Expect(fakeSchema.SchemaVersionCallCount()).To(BeZero())
+	})
+
+	It("should report degraded when the last migration left the schema dirty", func() {
+		fakeSchema.SchemaVersionReturns(12, true, nil)
+
+		status, err := resolver.Query().Health(ctx)
+
+		Expect(err).To(BeNil())
+		Expect(status.Status).To(Equal("degraded"))
+		Expect(status.Database).To(Equal("ok"))
+		Expect(*status.SchemaVersion).To(Equal(12))
+	})
+
+	It("should report degraded when the schema version cannot be read", func() {
+		fakeSchema.SchemaVersionReturns(0, false, errors.New("relation \"schema_migrations\" does not exist"))
+
+		status, err := resolver.Query().Health(ctx)
+
+		Expect(err).To(BeNil())
+		Expect(status).To(Equal(&gql.HealthStatus{Status: "degraded", Database: "ok"}))
+	})
+
+	It("should omit the schema version before any migration has run", func() {
+		fakeSchema.SchemaVersionReturns(0, false, nil)
+
+		status, err := resolver.Query().Health(ctx)
+
+		Expect(err).To(BeNil())
+		Expect(status).To(Equal(&gql.HealthStatus{Status: "ok", Database: "ok"}))
+	})
+
+	It("should report ok without a database dependency", func() {
+		resolver = &resolvers.Resolver{}
+
+		status, err := resolver.Query().Health(ctx)
+
+		Expect(err).To(BeNil())
+		Expect(status).To(Equal(&gql.HealthStatus{Status: "ok", Database: "unknown"}))
+	})
+})
+
+var _ = Describe("SlowestTests Resolver", func() {
+	var (
+		fakeRepo *fakes.FakeSlowTestProvider
+		resolver *resolvers.Resolver
+		ctx      context.Context
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeSlowTestProvider{}
+		resolver = &resolvers.Resolver{SlowRepo: fakeRepo}
+		ctx = context.Background()
+	})
+
+	It("should return slow test data from the fake repository", func() {
+		expected := []*gql.SlowTest{
+			{TestName: "ReportExporter renders large PDFs", AvgDurationMs: 5400, MaxDurationMs: 9100, RunCount: 12},
+		}
+
+		fakeRepo.GetSlowestTestsReturns(expected, nil)
+
+		result, err := resolver.Query().SlowestTests(ctx, 3, "policy-admin-ui")
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+
+		_, projID, limit := fakeRepo.GetSlowestTestsArgsForCall(0)
+		Expect(projID).To(Equal("policy-admin-ui"))
+		Expect(limit).To(Equal(3))
+	})
+})
+
+var _ = Describe("PassRateTrend Resolver", func() {
+	It("should return trend points from the fake repository", func() {
+		fakeRepo := &fakes.FakeTrendProvider{}
+		resolver := &resolvers.Resolver{TrendRepo: fakeRepo}
+		expected := []*gql.TrendPoint{
+			{PeriodStart: "2025-03-24T00:00:00Z", PassRate: 0.9, RunCount: 10},
+		}
+
+		fakeRepo.GetPassRateTrendReturns(expected, nil)
+
+		result, err := resolver.Query().PassRateTrend(context.Background(), "policy-admin-ui", "LoginService handles expired tokens", "day")
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+
+		_, projID, testName, bucket := fakeRepo.GetPassRateTrendArgsForCall(0)
+		Expect(projID).To(Equal("policy-admin-ui"))
+		Expect(testName).To(Equal("LoginService handles expired tokens"))
+		Expect(bucket).To(Equal("day"))
+	})
+})
+
+var _ = Describe("FlakyTestsConnection Resolver", func() {
+	var (
+		fakePager *fakes.FakeFlakyTestPager
+		resolver  *resolvers.Resolver
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		fakePager = &fakes.FakeFlakyTestPager{}
+		resolver = &resolvers.Resolver{FlakyPager: fakePager}
+		ctx = context.Background()
+	})
+
+	It("should wrap results in edges with cursors and page info", func() {
+		tests := []*gql.FlakyTest{
+			{TestID: "spec_a", TestName: "spec_a", FailureRate: 1},
+			{TestID: "spec_b", TestName: "spec_b", FailureRate: 0.5},
+		}
+		fakePager.GetFlakyTestsPageReturns(tests, true, nil)
+
+		conn, err := resolver.Query().FlakyTestsConnection(ctx, "paging", 2, nil)
+
+		Expect(err).To(BeNil())
+		Expect(conn.Edges).To(HaveLen(2))
+		Expect(conn.Edges[0].Node).To(Equal(tests[0]))
+		Expect(conn.PageInfo.HasNextPage).To(BeTrue())
+		Expect(conn.PageInfo.EndCursor).To(Equal(&conn.Edges[1].Cursor))
+
+		decoded, err := repo.DecodeCursor(*conn.PageInfo.EndCursor)
+		Expect(err).To(BeNil())
+		Expect(decoded.TestName).To(Equal("spec_b"))
+
+		_, _, first, after := fakePager.GetFlakyTestsPageArgsForCall(0)
+		Expect(first).To(Equal(2))
+		Expect(after).To(BeNil())
+	})
+
+	It("should decode the after cursor before querying", func() {
+		fakePager.GetFlakyTestsPageReturns(nil, false, nil)
+		after := repo.EncodeCursor(repo.FlakyTestCursor{FailureRate: 0.5, TestName: "spec_b"})
+
+		conn, err := resolver.Query().FlakyTestsConnection(ctx, "paging", 2, &after)
+
+		Expect(err).To(BeNil())
+		Expect(conn.Edges).To(BeEmpty())
+		Expect(conn.PageInfo.EndCursor).To(BeNil())
+
+		_, _, _, cursor := fakePager.GetFlakyTestsPageArgsForCall(0)
+		Expect(cursor).To(Equal(&repo.FlakyTestCursor{FailureRate: 0.5, TestName: "spec_b"}))
+	})
+
+	It("should reject a malformed cursor", func() {
+		bad := "%%%"
+
+		_, err := resolver.Query().FlakyTestsConnection(ctx, "paging", 2, &bad)
+
+		Expect(err).To(MatchError(repo.ErrInvalidCursor))
+		Expect(fakePager.GetFlakyTestsPageCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("FlakyTests Resolver cancellation", func() {
+	var (
+		fakeDB           *fakes.FakePgxQuerier
+		started, aborted chan struct{}
+	)
+
+	BeforeEach(func() {
+		// The fake database holds every query until its context is done,
+		// like a long-running statement that pgx aborts on cancellation.
+		started = make(chan struct{}, 1)
+		aborted = make(chan struct{}, 1)
+		fakeDB = &fakes.FakePgxQuerier{}
+		fakeDB.QueryStub = func(ctx context.Context, _ string, _ ...any) (pgx.Rows, error) {
+			started <- struct{}{}
+			<-ctx.Done()
+			aborted <- struct{}{}
+			return nil, ctx.Err()
+		}
+	})
+
+	// resolveCancelled runs the flakyTests resolver, cancels its context once
+	// the query has reached the database, and returns the resolver's error.
+	resolveCancelled := func(ctx context.Context, resolver *resolvers.Resolver) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := resolver.Query().FlakyTests(ctx, intPtr(5), "demo", nil, 0, nil, "", "", 0, 0, false)
+			errs <- err
+		}()
+		Eventually(started).Should(Receive())
+		cancel()
+
+		var err error
+		Eventually(errs).Should(Receive(&err))
+		return err
+	}
+
+	It("should abort the query when the request is cancelled", func() {
+		resolver := &resolvers.Resolver{FlakyRepo: repo.NewFlakyTestRepo(fakeDB)}
+
+		Expect(resolveCancelled(context.Background(), resolver)).To(MatchError(context.Canceled))
+		Eventually(aborted).Should(Receive())
+	})
+
+	It("should abort a batched query when the request is cancelled", func() {
+		flakyRepo := repo.NewFlakyTestRepo(fakeDB)
+		resolver := &resolvers.Resolver{FlakyRepo: loader.NewProvider(flakyRepo)}
+		ctx := loader.WithFlakyTests(context.Background(), loader.NewFlakyTests(flakyRepo, time.Millisecond))
+
+		Expect(resolveCancelled(ctx, resolver)).To(MatchError(context.Canceled))
+		// The batch does not outlive its only caller.
+		Eventually(aborted).Should(Receive())
+		Expect(fakeDB.QueryCallCount()).To(Equal(1))
+	})
+})
+
+var _ = Describe("FlakyTestsPage Resolver", func() {
+	var (
+		fakeRepo    *fakes.FakeFlakyTestProvider
+		fakeCounter *fakes.FakeFlakyTestCounter
+		resolver    *resolvers.Resolver
+		ctx         context.Context
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeFlakyTestProvider{}
+		fakeCounter = &fakes.FakeFlakyTestCounter{}
+		resolver = &resolvers.Resolver{FlakyRepo: fakeRepo, FlakyCounter: fakeCounter}
+		ctx = context.Background()
+	})
+
+	It("should return the page of items with the total count for the same filters", func() {
+		items := []*gql.FlakyTest{{TestID: "spec_c", TestName: "spec_c", FailureRate: 0.4}}
+		fakeRepo.GetFlakyTestsReturns(items, nil)
+		fakeCounter.CountFlakyTestsReturns(3, nil)
+		suite := "auth"
+
+		page, err := resolver.Query().FlakyTestsPage(ctx, 2, "demo", &suite, 2, nil, gql.FlakyTestSortFieldRunCount, gql.SortOrderAsc, 5, 0.25, false)
+
+		Expect(err).To(BeNil())
+		Expect(page.Items).To(Equal(items))
+		Expect(page.TotalCount).To(Equal(3))
+
+		_, _, limit, itemOpts := fakeRepo.GetFlakyTestsArgsForCall(0)
+		Expect(limit).To(Equal(2))
+		_, projectID, countOpts := fakeCounter.CountFlakyTestsArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(countOpts).To(Equal(itemOpts))
+		Expect(countOpts).To(Equal(repo.FlakyTestOptions{
+			SuiteName: "auth", Offset: 2, SortBy: gql.FlakyTestSortFieldRunCount, SortOrder: gql.SortOrderAsc,
+			MinRuns: 5, MinFailureRate: 0.25,
+		}))
+	})
+
+	It("should reject limits outside the allowed range without querying", func() {
+		_, err := resolver.Query().FlakyTestsPage(ctx, 0, "demo", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeRepo.GetFlakyTestsCallCount()).To(Equal(0))
+		Expect(fakeCounter.CountFlakyTestsCallCount()).To(Equal(0))
+	})
+
+	It("should return the count error", func() {
+		fakeCounter.CountFlakyTestsReturns(0, errors.New("db down"))
+
+		_, err := resolver.Query().FlakyTestsPage(ctx, 5, "demo", nil, 0, nil, gql.FlakyTestSortFieldFailureRate, gql.SortOrderDesc, 1, 0, false)
+		Expect(err).To(MatchError("db down"))
+	})
+})
+
+var _ = Describe("DurationOutliers Resolver", func() {
+	It("should pass the threshold to the repository, defaulting when omitted", func() {
+		fakeRepo := &fakes.FakeDurationOutlierProvider{}
+		resolver := &resolvers.Resolver{OutlierRepo: fakeRepo}
+		expected := []*gql.DurationOutlier{{SpecRunID: "7", TestRunID: "3", TestName: "login", DurationMs: 900, Deviations: 4}}
+		fakeRepo.GetDurationOutliersReturns(expected, nil)
+
+		threshold := 2.5
+		result, err := resolver.Query().DurationOutliers(context.Background(), "demo", &threshold)
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, projectID, got := fakeRepo.GetDurationOutliersArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(got).To(Equal(2.5))
+
+		_, err = resolver.Query().DurationOutliers(context.Background(), "demo", nil)
+		Expect(err).To(BeNil())
+		_, _, got = fakeRepo.GetDurationOutliersArgsForCall(1)
+		Expect(got).To(Equal(repo.DefaultOutlierStdDevs))
+	})
+})
+
+var _ = Describe("FailureActors Resolver", func() {
+	It("should pass the test and lookback window to the repository", func() {
+		fakeRepo := &fakes.FakeFailureActorProvider{}
+		resolver := &resolvers.Resolver{ActorRepo: fakeRepo}
+		expected := []*gql.ActorCount{{Actor: "alice", Count: 2}, {Actor: "bob", Count: 1}}
+		fakeRepo.GetFailureActorsReturns(expected, nil)
+
+		result, err := resolver.Query().FailureActors(context.Background(), "demo", "login", intPtr(7))
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, projectID, testName, days := fakeRepo.GetFailureActorsArgsForCall(0)
+		Expect([]any{projectID, testName, days}).To(Equal([]any{"demo", "login", 7}))
+
+		_, err = resolver.Query().FailureActors(context.Background(), "demo", "login", nil)
+		Expect(err).To(BeNil())
+		_, _, _, days = fakeRepo.GetFailureActorsArgsForCall(1)
+		Expect(days).To(BeZero())
+	})
+})
+
+var _ = Describe("CoFailingTests Resolver", func() {
+	It("should return the pairs from the repository", func() {
+		fakeRepo := &fakes.FakeCoFailureProvider{}
+		resolver := &resolvers.Resolver{CoFailures: fakeRepo}
+		expected := []*gql.CoFailure{{TestA: "login", TestB: "logout", CoFailureCount: 3, TestAFailures: 4, TestBFailures: 3, Lift: 2.5}}
+		fakeRepo.GetCoFailingTestsReturns(expected, nil)
+
+		result, err := resolver.Query().CoFailingTests(context.Background(), "demo", 20)
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, projectID, limit := fakeRepo.GetCoFailingTestsArgsForCall(0)
+		Expect([]any{projectID, limit}).To(Equal([]any{"demo", 20}))
+	})
+
+	It("should reject an out-of-range limit without querying", func() {
+		fakeRepo := &fakes.FakeCoFailureProvider{}
+		resolver := &resolvers.Resolver{CoFailures: fakeRepo}
+
+		_, err := resolver.Query().CoFailingTests(context.Background(), "demo", 0)
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeRepo.GetCoFailingTestsCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("TestHistory Resolver", func() {
+	It("should return the spec's runs from the repository", func() {
+		fakeRepo := &fakes.FakeTestHistoryProvider{}
+		resolver := &resolvers.Resolver{HistoryRepo: fakeRepo}
+		expected := []*gql.TestRunResult{{SpecRunID: "1", TestRunID: "1", Status: "passed", Passed: true}}
+		fakeRepo.GetTestHistoryReturns(expected, nil)
+
+		result, err := resolver.Query().TestHistory(context.Background(), "demo", "login", 10)
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, projectID, testName, limit := fakeRepo.GetTestHistoryArgsForCall(0)
+		Expect([]any{projectID, testName, limit}).To(Equal([]any{"demo", "login", 10}))
+	})
+
+	It("should reject an out-of-range limit without querying", func() {
+		fakeRepo := &fakes.FakeTestHistoryProvider{}
+		resolver := &resolvers.Resolver{HistoryRepo: fakeRepo}
+
+		_, err := resolver.Query().TestHistory(context.Background(), "demo", "login", 0)
+		Expect(err).To(HaveOccurred())
+		Expect(fakeRepo.GetTestHistoryCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("Projects Resolver", func() {
+	var (
+		fakeRepo *fakes.FakeProjectProvider
+		resolver *resolvers.Resolver
+		ctx      context.Context
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeProjectProvider{}
+		resolver = &resolvers.Resolver{ProjectRepo: fakeRepo}
+		ctx = context.Background()
+	})
+
+	It("should list every project when no team is given", func() {
+		expected := []*gql.Project{{ID: "uuid-1", Name: "demo"}}
+		fakeRepo.ListProjectsReturns(expected, nil)
+
+		result, err := resolver.Query().Projects(ctx, nil)
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+
+		_, team := fakeRepo.ListProjectsArgsForCall(0)
+		Expect(team).To(BeEmpty())
+	})
+
+	It("should pass the team filter to the repository", func() {
+		team := "team-b"
+
+		_, err := resolver.Query().Projects(ctx, &team)
+
+		Expect(err).To(BeNil())
+		_, got := fakeRepo.ListProjectsArgsForCall(0)
+		Expect(got).To(Equal("team-b"))
+	})
+})
+
+var _ = Describe("SuiteHealth Resolver", func() {
+	It("should return the suite rollup from the fake repository", func() {
+		fakeRepo := &fakes.FakeSuiteHealthProvider{}
+		resolver := &resolvers.Resolver{SuiteRepo: fakeRepo}
+		expected := []*gql.SuiteHealth{
+			{SuiteName: "Auth Suite", TotalRuns: 4, FailureCount: 1, PassRate: 0.75, FlakyTestCount: 1},
+		}
+
+		fakeRepo.GetSuiteHealthReturns(expected, nil)
+
+		result, err := resolver.Query().SuiteHealth(context.Background(), "policy-admin-ui")
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+
+		_, projID := fakeRepo.GetSuiteHealthArgsForCall(0)
+		Expect(projID).To(Equal("policy-admin-ui"))
+	})
+})
+
+var _ = Describe("ProjectHealthScore Resolver", func() {
+	It("should return the project's score from the repository", func() {
+		fakeRepo := &fakes.FakeHealthScoreProvider{}
+		resolver := &resolvers.Resolver{HealthScores: fakeRepo}
+		expected := &gql.HealthScore{ProjectID: "demo", Score: 64.2, SinceDays: 30, TestCount: 3}
+		fakeRepo.GetProjectHealthScoreReturns(expected, nil)
+
+		result, err := resolver.Query().ProjectHealthScore(context.Background(), "demo")
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, projectID := fakeRepo.GetProjectHealthScoreArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+	})
+})
+
+var _ = Describe("TeamHealth Resolver", func() {
+	var (
+		fakeRepo *fakes.FakeTeamHealthProvider
+		resolver *resolvers.Resolver
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeTeamHealthProvider{}
+		resolver = &resolvers.Resolver{TeamRepo: fakeRepo}
+	})
+
+	It("should return the team rollup from the fake repository", func() {
+		expected := &gql.TeamHealth{TeamName: "team-d", ProjectCount: 2, TotalRuns: 9, FlakyTestCount: 2, AverageFailureRate: 0.5}
+		fakeRepo.GetTeamHealthReturns(expected, nil)
+
+		result, err := resolver.Query().TeamHealth(context.Background(), "team-d")
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, teamName := fakeRepo.GetTeamHealthArgsForCall(0)
+		Expect(teamName).To(Equal("team-d"))
+	})
+
+	It("should reject an empty team name without querying", func() {
+		_, err := resolver.Query().TeamHealth(context.Background(), " ")
+
+		Expect(err).To(MatchError("teamName must not be empty"))
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeRepo.GetTeamHealthCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("TestOwner Resolver", func() {
+	var (
+		fakeRepo *fakes.FakeOwnershipProvider
+		resolver *resolvers.Resolver
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeOwnershipProvider{}
+		resolver = &resolvers.Resolver{OwnerRepo: fakeRepo}
+	})
+
+	It("should return the owner from the fake repository", func() {
+		team := "team-a"
+		expected := &gql.TestOwner{ProjectID: "demo", TestName: "login", Team: &team, Source: gql.OwnershipSourceProject}
+		fakeRepo.GetTestOwnerReturns(expected, nil)
+
+		result, err := resolver.Query().TestOwner(context.Background(), "demo", "login")
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, projectID, testName := fakeRepo.GetTestOwnerArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(testName).To(Equal("login"))
+	})
+
+	It("should reject an empty test name without querying", func() {
+		_, err := resolver.Query().TestOwner(context.Background(), "demo", "")
+
+		Expect(err).To(MatchError("testName must not be empty"))
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeRepo.GetTestOwnerCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("TopFailingTests Resolver", func() {
+	It("should pass the limit and lookback window to the repository", func() {
+		fakeRepo := &fakes.FakeTopFailingTestProvider{}
+		resolver := &resolvers.Resolver{TopFailing: fakeRepo}
+		project := "demo"
+		expected := []*gql.FlakyTest{{TestID: "login", TestName: "login", FailureRate: 1, ProjectName: &project}}
+
+		fakeRepo.GetTopFailingTestsReturns(expected, nil)
+
+		days := 14
+		result, err := resolver.Query().TopFailingTests(context.Background(), 5, &days)
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+
+		_, limit, sinceDays := fakeRepo.GetTopFailingTestsArgsForCall(0)
+		Expect(limit).To(Equal(5))
+		Expect(sinceDays).To(Equal(14))
+	})
+
+	It("should reject limits outside the allowed range without querying", func() {
+		fakeRepo := &fakes.FakeTopFailingTestProvider{}
+		resolver := &resolvers.Resolver{TopFailing: fakeRepo}
+
+		for _, limit := range []int{0, -5, resolvers.DefaultMaxLimit + 1} {
+			result, err := resolver.Query().TopFailingTests(context.Background(), limit, nil)
+
+			Expect(err).To(MatchError(ContainSubstring("limit must be between 1 and 100")))
+			Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+			Expect(result).To(BeNil())
+		}
+		Expect(fakeRepo.GetTopFailingTestsCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("TestsForFiles Resolver", func() {
+	var (
+		fakeRepo *fakes.FakeTestImpactProvider
+		resolver *resolvers.Resolver
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeTestImpactProvider{}
+		resolver = &resolvers.Resolver{TestImpact: fakeRepo}
+	})
+
+	It("should return the tests mapped to the files", func() {
+		expected := []*gql.FlakyTest{{TestID: "login", TestName: "login", FailureRate: 0.5}}
+		fakeRepo.GetTestsForFilesReturns(expected, nil)
+
+		result, err := resolver.Query().TestsForFiles(context.Background(), "demo", []string{"pkg/auth/login.go"})
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, projectID, files := fakeRepo.GetTestsForFilesArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(files).To(Equal([]string{"pkg/auth/login.go"}))
+	})
+
+	It("should reject an empty project ID", func() {
+		_, err := resolver.Query().TestsForFiles(context.Background(), " ", []string{"pkg/auth/login.go"})
+
+		Expect(err).To(MatchError("projectID must not be empty"))
+		Expect(fakeRepo.GetTestsForFilesCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("NewlyFlakyTests Resolver", func() {
+	It("should pass the windows and minimum increase to the repository", func() {
+		fakeRepo := &fakes.FakeNewlyFlakyTestProvider{}
+		resolver := &resolvers.Resolver{NewlyFlaky: fakeRepo}
+		expected := []*gql.FlakyTest{{TestID: "login", TestName: "login", FailureRate: 0.75}}
+		fakeRepo.GetNewlyFlakyTestsReturns(expected, nil)
+
+		result, err := resolver.Query().NewlyFlakyTests(context.Background(), "demo", 7, 30, 0.25)
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+		_, projectID, recentDays, baselineDays, minIncrease := fakeRepo.GetNewlyFlakyTestsArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(recentDays).To(Equal(7))
+		Expect(baselineDays).To(Equal(30))
+		Expect(minIncrease).To(Equal(0.25))
+	})
+
+	It("should reject an empty project ID", func() {
+		fakeRepo := &fakes.FakeNewlyFlakyTestProvider{}
+		resolver := &resolvers.Resolver{NewlyFlaky: fakeRepo}
+
+		_, err := resolver.Query().NewlyFlakyTests(context.Background(), "", 7, 30, 0.2)
+		Expect(err).To(MatchError("projectID must not be empty"))
+		Expect(fakeRepo.GetNewlyFlakyTestsCallCount()).To(Equal(0))
+	})
+})
+
+var _ = Describe("RecentTestRuns Resolver", func() {
+	var (
+		fakeRepo *fakes.FakeTestRunProvider
+		resolver *resolvers.Resolver
+		ctx      context.Context
+	)
+
+	BeforeEach(func() {
+		fakeRepo = &fakes.FakeTestRunProvider{}
+		resolver = &resolvers.Resolver{TestRunRepo: fakeRepo}
+		ctx = context.Background()
+	})
+
+	It("should return test runs for a project from the fake repository", func() {
+		expected := []*gql.TestRun{{ID: "42"}}
+		fakeRepo.GetRecentTestRunsReturns(expected, nil)
+
+		project := "policy-admin-ui"
+		result, err := resolver.Query().RecentTestRuns(ctx, &project, 3)
+
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+
+		_, projID, limit := fakeRepo.GetRecentTestRunsArgsForCall(0)
+		Expect(projID).To(Equal("policy-admin-ui"))
+		Expect(limit).To(Equal(3))
+	})
+
+	It("should query every project when none is given", func() {
+		_, err := resolver.Query().RecentTestRuns(ctx, nil, 3)
+
+		Expect(err).To(BeNil())
+		_, projID, _ := fakeRepo.GetRecentTestRunsArgsForCall(0)
+		Expect(projID).To(BeEmpty())
+	})
+
+	It("should reject limits outside the allowed range without querying", func() {
+		for _, limit := range []int{0, -5, resolvers.DefaultMaxLimit + 1} {
+			result, err := resolver.Query().RecentTestRuns(ctx, nil, limit)
+
+			Expect(err).To(MatchError(ContainSubstring("limit must be between 1 and 100")))
+			Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+			Expect(result).To(BeNil())
+		}
+		Expect(fakeRepo.GetRecentTestRunsCallCount()).To(Equal(0))
 	})
 })
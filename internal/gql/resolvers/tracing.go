@@ -0,0 +1,4 @@
+package resolvers
+
+// tracerName identifies the spans emitted by the GraphQL resolvers.
+const tracerName = "github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
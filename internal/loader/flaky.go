@@ -0,0 +1,140 @@
+// Package loader batches the flaky test lookups made while resolving a single
+// GraphQL request, so a document asking about many projects costs one query.
+package loader
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// DefaultWait is how long a batch collects lookups before it is sent. gqlgen
+// resolves sibling root fields concurrently, so they all arrive well within it.
+const DefaultWait = 2 * time.Millisecond
+
+// FlakyTests collects GetFlakyTests lookups for a short window and resolves
+// those sharing a limit and options with one GetFlakyTestsBatch call.
+type FlakyTests struct {
+	batcher repo.FlakyTestBatcher
+	wait    time.Duration
+
+	mu      sync.Mutex
+	pending map[batchKey]*batch
+}
+
+type batchKey struct {
+	limit int
+	opts  repo.FlakyTestOptions
+}
+
+type batch struct {
+	projectIDs []string
+	done       chan struct{}
+	results    map[string][]*gql.FlakyTest
+	err        error
+
+	// cancel aborts the batch query; it is called once every caller waiting
+	// on the batch has given up, and after the batch completes.
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// NewFlakyTests returns a loader that sends each batch after wait.
+func NewFlakyTests(batcher repo.FlakyTestBatcher, wait time.Duration) *FlakyTests {
+	return &FlakyTests{batcher: batcher, wait: wait, pending: map[batchKey]*batch{}}
+}
+
+// Load returns the flaky tests for projectID, waiting for the batch it joins.
+func (l *FlakyTests) Load(ctx context.Context, projectID string, limit int, opts repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+	key := batchKey{limit: limit, opts: opts}
+
+	l.mu.Lock()
+	b, ok := l.pending[key]
+	if !ok {
+		// The batch outlives any single caller, so it keeps the first
+		// caller's values (such as the trace) but not its cancellation.
+		batchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		b = &batch{done: make(chan struct{}), cancel: cancel}
+		l.pending[key] = b
+		time.AfterFunc(l.wait, func() { l.dispatch(batchCtx, key, b) })
+	}
+	if !slices.Contains(b.projectIDs, projectID) {
+		b.projectIDs = append(b.projectIDs, projectID)
+	}
+	b.waiters++
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		// When the client has gone, nobody is left to read the results, so
+		// the query is aborted rather than left running.
+		l.mu.Lock()
+		b.waiters--
+		if b.waiters == 0 {
+			b.cancel()
+			// Later lookups must not join a batch that is already aborted.
+			if l.pending[key] == b {
+				delete(l.pending, key)
+			}
+		}
+		l.mu.Unlock()
+		return nil, ctx.Err()
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.results[projectID], nil
+}
+
+func (l *FlakyTests) dispatch(ctx context.Context, key batchKey, b *batch) {
+	l.mu.Lock()
+	if l.pending[key] == b {
+		delete(l.pending, key)
+	}
+	projectIDs := b.projectIDs
+	l.mu.Unlock()
+
+	if ctx.Err() != nil {
+		// Every caller gave up before the batch was sent.
+		b.err = ctx.Err()
+	} else {
+		b.results, b.err = l.batcher.GetFlakyTestsBatch(ctx, projectIDs, key.limit, key.opts)
+	}
+	b.cancel()
+	close(b.done)
+}
+
+type contextKey struct{}
+
+// WithFlakyTests returns a context carrying l for NewProvider to use.
+func WithFlakyTests(ctx context.Context, l *FlakyTests) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FlakyTestsFromContext returns the loader stored by WithFlakyTests, or nil.
+func FlakyTestsFromContext(ctx context.Context) *FlakyTests {
+	l, _ := ctx.Value(contextKey{}).(*FlakyTests)
+	return l
+}
+
+// NewProvider returns a FlakyTestProvider that goes through the request's
+// loader when the context carries one, and calls fallback directly otherwise.
+func NewProvider(fallback repo.FlakyTestProvider) repo.FlakyTestProvider {
+	return &provider{fallback: fallback}
+}
+
+type provider struct {
+	fallback repo.FlakyTestProvider
+}
+
+func (p *provider) GetFlakyTests(ctx context.Context, projectID string, limit int, opts repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+	if l := FlakyTestsFromContext(ctx); l != nil {
+		return l.Load(ctx, projectID, limit, opts)
+	}
+	return p.fallback.GetFlakyTests(ctx, projectID, limit, opts)
+}
@@ -0,0 +1,230 @@
+package loader_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/loader"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTests loader", func() {
+	var (
+		ctx     context.Context
+		batcher *fakes.FakeFlakyTestBatcher
+		l       *loader.FlakyTests
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		batcher = &fakes.FakeFlakyTestBatcher{}
+		batcher.GetFlakyTestsBatchStub = func(_ context.Context, projectIDs []string, _ int, _ repo.FlakyTestOptions) (map[string][]*gql.FlakyTest, error) {
+			results := map[string][]*gql.FlakyTest{}
+			for _, projectID := range projectIDs {
+				results[projectID] = []*gql.FlakyTest{{TestName: projectID + " spec"}}
+			}
+			return results, nil
+		}
+		l = loader.NewFlakyTests(batcher, 20*time.Millisecond)
+	})
+
+	// loadAll runs one Load per project concurrently and returns the results
+	// in the same order.
+	loadAll := func(projectIDs []string, limit int, opts repo.FlakyTestOptions) ([][]*gql.FlakyTest, []error) {
+		results := make([][]*gql.FlakyTest, len(projectIDs))
+		errs := make([]error, len(projectIDs))
+		var wg sync.WaitGroup
+		for i, projectID := range projectIDs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], errs[i] = l.Load(ctx, projectID, limit, opts)
+			}()
+		}
+		wg.Wait()
+		return results, errs
+	}
+
+	It("collapses concurrent lookups into one batch", func() {
+		results, errs := loadAll([]string{"demo", "billing", "paging", "demo"}, 5, repo.FlakyTestOptions{})
+
+		Expect(errs).To(HaveEach(BeNil()))
+		Expect(results[0][0].TestName).To(Equal("demo spec"))
+		Expect(results[1][0].TestName).To(Equal("billing spec"))
+		Expect(results[2][0].TestName).To(Equal("paging spec"))
+		Expect(results[3][0].TestName).To(Equal("demo spec"))
+
+		Expect(batcher.GetFlakyTestsBatchCallCount()).To(Equal(1))
+		_, projectIDs, limit, _ := batcher.GetFlakyTestsBatchArgsForCall(0)
+		Expect(projectIDs).To(ConsistOf("demo", "billing", "paging"))
+		Expect(limit).To(Equal(5))
+	})
+
+	It("batches lookups with different arguments separately", func() {
+		var wg sync.WaitGroup
+		for _, limit := range []int{5, 10} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := l.Load(ctx, "demo", limit, repo.FlakyTestOptions{})
+				Expect(err).To(BeNil())
+			}()
+		}
+		wg.Wait()
+
+		Expect(batcher.GetFlakyTestsBatchCallCount()).To(Equal(2))
+	})
+
+	It("starts a new batch once the previous one was sent", func() {
+		_, err := l.Load(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		_, err = l.Load(ctx, "billing", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		Expect(batcher.GetFlakyTestsBatchCallCount()).To(Equal(2))
+	})
+
+	It("returns an empty result for a project without flaky tests", func() {
+		batcher.GetFlakyTestsBatchReturns(map[string][]*gql.FlakyTest{}, nil)
+		batcher.GetFlakyTestsBatchStub = nil
+
+		results, err := l.Load(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(BeEmpty())
+	})
+
+	It("shares a batch error with every caller", func() {
+		batcher.GetFlakyTestsBatchStub = nil
+		batcher.GetFlakyTestsBatchReturns(nil, errors.New("boom"))
+
+		_, errs := loadAll([]string{"demo", "billing"}, 5, repo.FlakyTestOptions{})
+		Expect(errs).To(HaveEach(MatchError("boom")))
+		Expect(batcher.GetFlakyTestsBatchCallCount()).To(Equal(1))
+	})
+
+	It("stops waiting when the caller's context is cancelled", func() {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := l.Load(cancelled, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+	Context("with a batch query in flight", func() {
+		var started, release chan struct{}
+		var batchCtx chan context.Context
+
+		BeforeEach(func() {
+			started = make(chan struct{})
+			release = make(chan struct{})
+			batchCtx = make(chan context.Context, 1)
+			// The stub may still be returning when the next spec starts, so
+			// it holds on to this spec's channels.
+			started, release, batchCtx := started, release, batchCtx
+			batcher.GetFlakyTestsBatchStub = func(ctx context.Context, projectIDs []string, _ int, _ repo.FlakyTestOptions) (map[string][]*gql.FlakyTest, error) {
+				batchCtx <- ctx
+				close(started)
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-release:
+					return map[string][]*gql.FlakyTest{"billing": {{TestName: "billing spec"}}}, nil
+				}
+			}
+		})
+
+		It("aborts the batch once its only caller is cancelled", func() {
+			cancelled, cancel := context.WithCancel(ctx)
+			errs := make(chan error, 1)
+			go func() {
+				_, err := l.Load(cancelled, "demo", 5, repo.FlakyTestOptions{})
+				errs <- err
+			}()
+			Eventually(started).Should(BeClosed())
+			cancel()
+
+			Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+			var queryCtx context.Context
+			Expect(batchCtx).To(Receive(&queryCtx))
+			Eventually(queryCtx.Done()).Should(BeClosed())
+		})
+
+		It("keeps the batch running while another caller still waits", func() {
+			cancelled, cancel := context.WithCancel(ctx)
+			errs := make(chan error, 1)
+			go func() {
+				_, err := l.Load(cancelled, "demo", 5, repo.FlakyTestOptions{})
+				errs <- err
+			}()
+			results := make(chan []*gql.FlakyTest, 1)
+			go func() {
+				defer GinkgoRecover()
+				tests, err := l.Load(ctx, "billing", 5, repo.FlakyTestOptions{})
+				Expect(err).To(BeNil())
+				results <- tests
+			}()
+			Eventually(started).Should(BeClosed())
+			cancel()
+			Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+
+			var queryCtx context.Context
+			Expect(batchCtx).To(Receive(&queryCtx))
+			Consistently(queryCtx.Done(), 50*time.Millisecond).ShouldNot(BeClosed())
+			close(release)
+			Eventually(results).Should(Receive(Equal([]*gql.FlakyTest{{TestName: "billing spec"}})))
+		})
+
+		It("does not send a batch whose callers all left before it was due", func() {
+			cancelled, cancel := context.WithCancel(ctx)
+			errs := make(chan error, 1)
+			go func() {
+				_, err := l.Load(cancelled, "demo", 5, repo.FlakyTestOptions{})
+				errs <- err
+			}()
+			cancel()
+			Eventually(errs).Should(Receive(MatchError(context.Canceled)))
+
+			Consistently(batcher.GetFlakyTestsBatchCallCount, 50*time.Millisecond).Should(BeZero())
+		})
+	})
+})
+
+var _ = Describe("NewProvider", func() {
+	var (
+		fallback *fakes.FakeFlakyTestProvider
+		batcher  *fakes.FakeFlakyTestBatcher
+		provider repo.FlakyTestProvider
+	)
+
+	BeforeEach(func() {
+		fallback = &fakes.FakeFlakyTestProvider{}
+		batcher = &fakes.FakeFlakyTestBatcher{}
+		provider = loader.NewProvider(fallback)
+	})
+
+	It("uses the loader carried by the context", func() {
+		batcher.GetFlakyTestsBatchReturns(map[string][]*gql.FlakyTest{"demo": {{TestName: "login"}}}, nil)
+		ctx := loader.WithFlakyTests(context.Background(), loader.NewFlakyTests(batcher, time.Millisecond))
+
+		results, err := provider.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal([]*gql.FlakyTest{{TestName: "login"}}))
+		Expect(fallback.GetFlakyTestsCallCount()).To(Equal(0))
+	})
+
+	It("falls back to the wrapped provider without a loader", func() {
+		fallback.GetFlakyTestsReturns([]*gql.FlakyTest{{TestName: "login"}}, nil)
+
+		results, err := provider.GetFlakyTests(context.Background(), "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal([]*gql.FlakyTest{{TestName: "login"}}))
+		Expect(fallback.GetFlakyTestsCallCount()).To(Equal(1))
+	})
+})
@@ -0,0 +1,80 @@
+// Package logging configures the process-wide structured logger.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Format selects how log records are encoded.
+type Format string
+
+const (
+	// FormatText writes key=value lines for people reading a terminal.
+	FormatText Format = "text"
+	// FormatJSON writes one JSON object per record for log ingestion.
+	FormatJSON Format = "json"
+)
+
+// ParseLevel parses a LOG_LEVEL value such as "debug" or "warn".
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: must be debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+// ParseFormat parses a LOG_FORMAT value, "text" or "json".
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(s))); format {
+	case FormatText, FormatJSON:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid log format %q: must be text or json", s)
+}
+
+// DetectFormat returns FormatText when f is a terminal and FormatJSON
+// otherwise, so local runs stay readable while containers emit JSON.
+func DetectFormat(f *os.File) Format {
+	if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return FormatText
+	}
+	return FormatJSON
+}
+
+// FormatFromEnv returns the format named by LOG_FORMAT, or the one detected
+// for stderr when it is unset.
+func FormatFromEnv() (Format, error) {
+	raw := os.Getenv("LOG_FORMAT")
+	if strings.TrimSpace(raw) == "" {
+		return DetectFormat(os.Stderr), nil
+	}
+	return ParseFormat(raw)
+}
+
+// NewHandler returns the handler encoding records in format, writing those
+// at level or above to w.
+func NewHandler(w io.Writer, level slog.Level, format Format) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == FormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// NewWithFormat returns a logger writing to w at level in format. Records
+// logged with a request context include its request ID.
+func NewWithFormat(w io.Writer, level slog.Level, format Format) *slog.Logger {
+	return slog.New(NewContextHandler(NewHandler(w, level, format)))
+}
+
+// SetupFormat installs a stderr logger at level in format as the slog
+// default. Packages using the standard log package are routed through it as
+// well.
+func SetupFormat(level slog.Level, format Format) {
+	slog.SetDefault(NewWithFormat(os.Stderr, level, format))
+}
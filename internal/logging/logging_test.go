@@ -0,0 +1,116 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/logging"
+)
+
+var _ = Describe("Logging", func() {
+	It("should drop records below the level", func() {
+		var buf bytes.Buffer
+		logger := logging.NewWithFormat(&buf, slog.LevelInfo, logging.FormatText)
+		logger.Debug("hidden")
+		logger.Info("shown")
+
+		Expect(buf.String()).NotTo(ContainSubstring("hidden"))
+		Expect(buf.String()).To(ContainSubstring("shown"))
+	})
+
+	It("should log debug records at debug level", func() {
+		var buf bytes.Buffer
+		logging.NewWithFormat(&buf, slog.LevelDebug, logging.FormatText).Debug("query timing")
+		Expect(buf.String()).To(ContainSubstring("query timing"))
+	})
+
+	It("should install the logger as the slog default", func() {
+		previous := slog.Default()
+		DeferCleanup(func() { slog.SetDefault(previous) })
+
+		logging.SetupFormat(slog.LevelDebug, logging.FormatText)
+		Expect(slog.Default().Enabled(context.Background(), slog.LevelDebug)).To(BeTrue())
+
+		logging.SetupFormat(slog.LevelInfo, logging.FormatJSON)
+		Expect(slog.Default().Enabled(context.Background(), slog.LevelDebug)).To(BeFalse())
+	})
+
+	It("should parse log level names", func() {
+		Expect(logging.ParseLevel("debug")).To(Equal(slog.LevelDebug))
+		Expect(logging.ParseLevel("WARN")).To(Equal(slog.LevelWarn))
+
+		_, err := logging.ParseLevel("loud")
+		Expect(err).To(MatchError(ContainSubstring(`invalid log level "loud"`)))
+	})
+})
+
+var _ = Describe("Log format", func() {
+	DescribeTable("should select the handler named by LOG_FORMAT",
+		func(value string, expected slog.Handler) {
+			GinkgoT().Setenv("LOG_FORMAT", value)
+
+			format, err := logging.FormatFromEnv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logging.NewHandler(&bytes.Buffer{}, slog.LevelInfo, format)).To(BeAssignableToTypeOf(expected))
+		},
+		Entry("text", "text", &slog.TextHandler{}),
+		Entry("json", "json", &slog.JSONHandler{}),
+		Entry("mixed case", " JSON ", &slog.JSONHandler{}),
+	)
+
+	It("should reject unknown formats", func() {
+		GinkgoT().Setenv("LOG_FORMAT", "xml")
+
+		_, err := logging.FormatFromEnv()
+		Expect(err).To(MatchError(`invalid log format "xml": must be text or json`))
+	})
+
+	It("should default to JSON when stderr is not a terminal", func() {
+		GinkgoT().Setenv("LOG_FORMAT", "")
+		f, err := os.CreateTemp(GinkgoT().TempDir(), "log")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(f.Close)
+
+		Expect(logging.DetectFormat(f)).To(Equal(logging.FormatJSON))
+		Expect(logging.FormatFromEnv()).To(Equal(logging.DetectFormat(os.Stderr)))
+	})
+
+	It("should write JSON records with the request ID", func() {
+		var buf bytes.Buffer
+		logger := logging.NewWithFormat(&buf, slog.LevelInfo, logging.FormatJSON)
+		logger.InfoContext(logging.WithRequestID(context.Background(), "req-9"), "tagged")
+
+		var record map[string]any
+		Expect(json.Unmarshal(buf.Bytes(), &record)).To(Succeed())
+		Expect(record).To(HaveKeyWithValue("msg", "tagged"))
+		Expect(record).To(HaveKeyWithValue(logging.RequestIDKey, "req-9"))
+	})
+})
+
+var _ = Describe("Request IDs", func() {
+	It("should add the context's request ID to each record", func() {
+		var buf bytes.Buffer
+		logger := logging.NewWithFormat(&buf, slog.LevelInfo, logging.FormatText).With("component", "test")
+
+		logger.InfoContext(logging.WithRequestID(context.Background(), "req-123"), "tagged")
+		logger.InfoContext(context.Background(), "untagged")
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(ContainSubstring("requestId=req-123"))
+		Expect(lines[0]).To(ContainSubstring("component=test"))
+		Expect(lines[1]).NotTo(ContainSubstring("requestId"))
+	})
+
+	It("should return an empty ID for contexts without one", func() {
+		Expect(logging.RequestID(context.Background())).To(BeEmpty())
+		Expect(logging.RequestID(logging.WithRequestID(context.Background(), "abc"))).To(Equal("abc"))
+	})
+})
@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+const (
+	// defaultFlakyLimit is used when detect_flaky_tests is called without a limit.
+	defaultFlakyLimit = 10
+	// maxFlakyLimit caps how many tests a single call may return.
+	maxFlakyLimit = 100
+)
+
+const detectFlakyTestsInputSchema = `{
+  "type": "object",
+  "properties": {
+    "projectID": {"type": "string", "minLength": 1, "description": "Project name or UUID"},
+    "limit": {"type": "integer", "minimum": 1, "maximum": 100, "default": 10, "description": "Maximum number of tests to return"},
+    "sinceDays": {"type": "integer", "minimum": 0, "description": "Only consider runs from the last N days (default 30)"}
+  },
+  "required": ["projectID"],
+  "additionalProperties": false
+}`
+
+const detectFlakyTestsOutputSchema = `{
+  "type": "object",
+  "properties": {
+    "tests": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "testID": {"type": "string"},
+          "testName": {"type": "string"},
+          "passRate": {"type": "number"},
+          "failureRate": {"type": "number"},
+          "flakinessScore": {"type": "number"},
+          "lastFailure": {"type": "string", "format": "date-time"},
+          "lastFailureBranch": {"type": "string"},
+          "lastFailureSha": {"type": "string"},
+          "runCount": {"type": "integer"},
+          "topFailureMessages": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "message": {"type": "string"},
+                "count": {"type": "integer"}
+              },
+              "required": ["message", "count"]
+            }
+          },
+          "isStale": {"type": "boolean"}
+        },
+        "required": ["testID", "testName", "passRate", "failureRate", "flakinessScore", "runCount", "isStale"]
+      }
+    }
+  },
+  "required": ["tests"]
+}`
+
+type detectFlakyTestsArgs struct {
+	ProjectID string `json:"projectID"`
+	Limit     *int   `json:"limit"`
+	SinceDays *int   `json:"sinceDays"`
+}
+
+type detectFlakyTestsOutput struct {
+	Tests []*gql.FlakyTest `json:"tests"`
+}
+
+// detectFlakyTestsTool reports the flakiest specs of a project.
+func detectFlakyTestsTool(flaky repo.FlakyTestProvider) Tool {
+	return Tool{
+		Descriptor: ToolDescriptor{
+			Name:         "detect_flaky_tests",
+			Description:  "List the tests of a project with the highest failure rate.",
+			InputSchema:  json.RawMessage(detectFlakyTestsInputSchema),
+			OutputSchema: json.RawMessage(detectFlakyTestsOutputSchema),
+		},
+		Handler: func(ctx context.Context, raw json.RawMessage) (CallToolResult, error) {
+			args, limit, err := decodeDetectFlakyTestsArgs(raw)
+			if err != nil {
+				return CallToolResult{}, err
+			}
+
+			var opts repo.FlakyTestOptions
+			if args.SinceDays != nil {
+				opts.SinceDays = *args.SinceDays
+			}
+
+			tests, err := flaky.GetFlakyTests(ctx, args.ProjectID, limit, opts)
+			if err != nil {
+				return CallToolResult{}, err
+			}
+			if tests == nil {
+				tests = []*gql.FlakyTest{}
+			}
+			return jsonResult(detectFlakyTestsOutput{Tests: tests})
+		},
+	}
+}
+
+// decodeDetectFlakyTestsArgs strictly decodes and validates the tool
+// arguments, returning the effective limit.
+func decodeDetectFlakyTestsArgs(raw json.RawMessage) (detectFlakyTestsArgs, int, error) {
+	var args detectFlakyTestsArgs
+	if len(raw) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&args); err != nil {
+			return args, 0, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("invalid arguments: %v", err)}
+		}
+	}
+
+	if args.ProjectID == "" {
+		return args, 0, invalidArgument("projectID", "is required")
+	}
+	limit := defaultFlakyLimit
+	if args.Limit != nil {
+		limit = *args.Limit
+	}
+	if limit < 1 || limit > maxFlakyLimit {
+		return args, 0, invalidArgument("limit", fmt.Sprintf("must be between 1 and %d", maxFlakyLimit))
+	}
+	if args.SinceDays != nil && *args.SinceDays < 0 {
+		return args, 0, invalidArgument("sinceDays", "must be non-negative")
+	}
+	return args, limit, nil
+}
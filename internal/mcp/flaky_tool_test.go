@@ -0,0 +1,102 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("detect_flaky_tests", func() {
+	var (
+		fakeFlaky *fakes.FakeFlakyTestProvider
+		srv       *mcp.Server
+	)
+
+	call := func(arguments string) (mcp.CallToolResult, *mcp.Error) {
+		msg := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"detect_flaky_tests","arguments":` + arguments + `}}`
+		var resp struct {
+			Result mcp.CallToolResult `json:"result"`
+			Error  *mcp.Error         `json:"error"`
+		}
+		Expect(json.Unmarshal(srv.HandleMessage(context.Background(), []byte(msg)), &resp)).To(Succeed())
+		return resp.Result, resp.Error
+	}
+
+	BeforeEach(func() {
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		srv = mcp.NewServer(fakeFlaky)
+	})
+
+	It("should describe its input and output schema", func() {
+		var list struct {
+			Result mcp.ListToolsResult `json:"result"`
+		}
+		Expect(json.Unmarshal(srv.HandleMessage(context.Background(),
+			[]byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)), &list)).To(Succeed())
+
+		tool := list.Result.Tools[0]
+		var input struct {
+			Properties map[string]any `json:"properties"`
+			Required   []string       `json:"required"`
+		}
+		Expect(json.Unmarshal(tool.InputSchema, &input)).To(Succeed())
+		Expect(input.Properties).To(HaveKey("projectID"))
+		Expect(input.Properties).To(HaveKey("limit"))
+		Expect(input.Properties).To(HaveKey("sinceDays"))
+		Expect(input.Required).To(ConsistOf("projectID"))
+		Expect(tool.OutputSchema).To(ContainSubstring(`"tests"`))
+	})
+
+	It("should return the flaky tests as text and structured content", func() {
+		fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{
+			{TestID: "login", TestName: "login", PassRate: 0.25, FailureRate: 0.75, RunCount: 4},
+		}, nil)
+
+		result, rpcErr := call(`{"projectID":"demo","limit":5,"sinceDays":7}`)
+		Expect(rpcErr).To(BeNil())
+		Expect(result.IsError).To(BeFalse())
+		Expect(result.Content[0].Text).To(MatchJSON(`{"tests":[{"testID":"login","testName":"login","passRate":0.25,"failureRate":0.75,"flakinessScore":0,"runCount":4,"isStale":false}]}`))
+
+		structured, err := json.Marshal(result.StructuredContent)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(structured).To(MatchJSON(result.Content[0].Text))
+
+		_, projectID, limit, opts := fakeFlaky.GetFlakyTestsArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(limit).To(Equal(5))
+		Expect(opts).To(Equal(repo.FlakyTestOptions{SinceDays: 7}))
+	})
+
+	It("should default the limit", func() {
+		_, rpcErr := call(`{"projectID":"demo"}`)
+		Expect(rpcErr).To(BeNil())
+
+		_, _, limit, _ := fakeFlaky.GetFlakyTestsArgsForCall(0)
+		Expect(limit).To(Equal(10))
+	})
+
+	DescribeTable("should reject invalid arguments with a structured error",
+		func(arguments, field string) {
+			_, rpcErr := call(arguments)
+			Expect(rpcErr).ToNot(BeNil())
+			Expect(rpcErr.Code).To(Equal(mcp.CodeInvalidParams))
+			if field != "" {
+				Expect(rpcErr.Data).To(HaveKeyWithValue("field", field))
+			}
+			Expect(fakeFlaky.GetFlakyTestsCallCount()).To(Equal(0))
+		},
+		Entry("missing projectID", `{"limit":5}`, "projectID"),
+		Entry("negative limit", `{"projectID":"demo","limit":-1}`, "limit"),
+		Entry("limit above the maximum", `{"projectID":"demo","limit":1000}`, "limit"),
+		Entry("negative sinceDays", `{"projectID":"demo","sinceDays":-2}`, "sinceDays"),
+		Entry("wrong type", `{"projectID":42}`, ""),
+		Entry("unknown field", `{"projectID":"demo","project":"demo"}`, ""),
+	)
+})
@@ -0,0 +1,13 @@
+package mcp_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+func TestMCP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MCP Suite")
+}
@@ -0,0 +1,159 @@
+// Package mcp implements a Model Context Protocol server exposing
+// fern-mycelium test intelligence as tools.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/version"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+const serverName = "fern-mycelium"
+
+// maxMessageSize bounds a single newline-delimited stdio message.
+const maxMessageSize = 4 << 20
+
+// Server dispatches MCP requests to the registered tools.
+type Server struct {
+	version string
+	tools   []Tool
+}
+
+// NewServer returns a server exposing the flaky-test tools backed by flaky.
+func NewServer(flaky repo.FlakyTestProvider) *Server {
+	return &Server{
+		version: version.Version,
+		tools:   []Tool{detectFlakyTestsTool(flaky)},
+	}
+}
+
+// Tools returns the descriptors of the registered tools, in the order
+// tools/list reports them.
+func (s *Server) Tools() []ToolDescriptor {
+	tools := make([]ToolDescriptor, 0, len(s.tools))
+	for _, tool := range s.tools {
+		tools = append(tools, tool.Descriptor)
+	}
+	return tools
+}
+
+// ServeStdio reads newline-delimited JSON-RPC messages from r and writes
+// responses to w until r is exhausted or ctx is done.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		resp := s.HandleMessage(ctx, line)
+		if resp == nil {
+			continue
+		}
+		if _, err := w.Write(append(resp, '\n')); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+	return scanner.Err()
+}
+
+// HandleMessage processes a single JSON-RPC message and returns the encoded
+// response, or nil when the message is a notification.
+func (s *Server) HandleMessage(ctx context.Context, msg []byte) []byte {
+	var req Request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return encode(Response{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &Error{Code: CodeParseError, Message: "parse error"}})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return encode(Response{JSONRPC: "2.0", ID: idOrNull(req.ID),
+			Error: &Error{Code: CodeInvalidRequest, Message: "invalid request"}})
+	}
+
+	result, rpcErr := s.dispatch(ctx, req)
+	if req.ID == nil {
+		return nil
+	}
+
+	resp := Response{JSONRPC: "2.0", ID: req.ID}
+	if rpcErr != nil {
+		resp.Error = rpcErr
+	} else {
+		resp.Result = result
+	}
+	return encode(resp)
+}
+
+func (s *Server) dispatch(ctx context.Context, req Request) (any, *Error) {
+	switch req.Method {
+	case "initialize":
+		return InitializeResult{
+			ProtocolVersion: ProtocolVersion,
+			Capabilities:    map[string]any{"tools": map[string]any{}},
+			ServerInfo:      ServerInfo{Name: serverName, Version: s.version},
+		}, nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return ListToolsResult{Tools: s.Tools()}, nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (any, *Error) {
+	var params CallToolParams
+	if err := json.Unmarshal(raw, &params); err != nil || params.Name == "" {
+		return nil, &Error{Code: CodeInvalidParams, Message: "tools/call requires a tool name"}
+	}
+
+	for _, tool := range s.tools {
+		if tool.Descriptor.Name != params.Name {
+			continue
+		}
+		result, err := tool.Handler(ctx, params.Arguments)
+		var rpcErr *Error
+		if errors.As(err, &rpcErr) {
+			return nil, rpcErr
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "MCP tool call failed", "tool", params.Name, "error", err)
+			return CallToolResult{Content: []Content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		return result, nil
+	}
+	return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+}
+
+func idOrNull(id json.RawMessage) json.RawMessage {
+	if id == nil {
+		return json.RawMessage("null")
+	}
+	return id
+}
+
+func encode(resp Response) []byte {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(Response{JSONRPC: "2.0", ID: idOrNull(resp.ID),
+			Error: &Error{Code: CodeInternalError, Message: "failed to encode response"}})
+	}
+	return data
+}
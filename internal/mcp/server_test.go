@@ -0,0 +1,127 @@
+package mcp_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *mcp.Error      `json:"error"`
+}
+
+var _ = Describe("Server", func() {
+	var (
+		fakeFlaky *fakes.FakeFlakyTestProvider
+		srv       *mcp.Server
+	)
+
+	// exchange feeds newline-delimited messages to ServeStdio and returns the
+	// decoded responses in order.
+	exchange := func(messages ...string) []rpcResponse {
+		var out bytes.Buffer
+		Expect(srv.ServeStdio(context.Background(), strings.NewReader(strings.Join(messages, "\n")+"\n"), &out)).To(Succeed())
+
+		var responses []rpcResponse
+		scanner := bufio.NewScanner(&out)
+		for scanner.Scan() {
+			var resp rpcResponse
+			Expect(json.Unmarshal(scanner.Bytes(), &resp)).To(Succeed())
+			responses = append(responses, resp)
+		}
+		return responses
+	}
+
+	BeforeEach(func() {
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		srv = mcp.NewServer(fakeFlaky)
+	})
+
+	It("should complete the initialize handshake without answering notifications", func() {
+		responses := exchange(
+			`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+			`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		)
+
+		Expect(responses).To(HaveLen(1))
+		Expect(string(responses[0].ID)).To(Equal("1"))
+		Expect(responses[0].Error).To(BeNil())
+
+		var result mcp.InitializeResult
+		Expect(json.Unmarshal(responses[0].Result, &result)).To(Succeed())
+		Expect(result.ProtocolVersion).To(Equal(mcp.ProtocolVersion))
+		Expect(result.ServerInfo.Name).To(Equal("fern-mycelium"))
+		Expect(result.Capabilities).To(HaveKey("tools"))
+	})
+
+	It("should list the flaky test tool", func() {
+		responses := exchange(`{"jsonrpc":"2.0","id":"list","method":"tools/list"}`)
+
+		var result mcp.ListToolsResult
+		Expect(json.Unmarshal(responses[0].Result, &result)).To(Succeed())
+		Expect(result.Tools).To(HaveLen(1))
+		Expect(result.Tools[0].Name).To(Equal("detect_flaky_tests"))
+		Expect(result.Tools[0].InputSchema).To(ContainSubstring(`"projectID"`))
+	})
+
+	It("should call the flaky test provider and return its results as text content", func() {
+		fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{
+			{TestID: "login", TestName: "login", PassRate: 0.5, FailureRate: 0.5, RunCount: 4},
+		}, nil)
+
+		responses := exchange(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"detect_flaky_tests","arguments":{"projectID":"demo","limit":3}}}`)
+		Expect(responses[0].Error).To(BeNil())
+
+		var result mcp.CallToolResult
+		Expect(json.Unmarshal(responses[0].Result, &result)).To(Succeed())
+		Expect(result.IsError).To(BeFalse())
+		Expect(result.Content).To(HaveLen(1))
+		Expect(result.Content[0].Type).To(Equal("text"))
+		Expect(result.Content[0].Text).To(MatchJSON(`{"tests":[{"testID":"login","testName":"login","passRate":0.5,"failureRate":0.5,"flakinessScore":0,"runCount":4,"isStale":false}]}`))
+
+		_, projectID, limit, opts := fakeFlaky.GetFlakyTestsArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(limit).To(Equal(3))
+		Expect(opts).To(Equal(repo.FlakyTestOptions{}))
+	})
+
+	It("should report provider failures as tool errors", func() {
+		fakeFlaky.GetFlakyTestsReturns(nil, errors.New("db down"))
+
+		responses := exchange(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"detect_flaky_tests","arguments":{"projectID":"demo"}}}`)
+		Expect(responses[0].Error).To(BeNil())
+
+		var result mcp.CallToolResult
+		Expect(json.Unmarshal(responses[0].Result, &result)).To(Succeed())
+		Expect(result.IsError).To(BeTrue())
+		Expect(result.Content[0].Text).To(Equal("db down"))
+	})
+
+	It("should return JSON-RPC errors for malformed and unknown requests", func() {
+		responses := exchange(
+			`{not json`,
+			`{"jsonrpc":"2.0","id":4,"method":"resources/list"}`,
+			`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"nope"}}`,
+		)
+
+		Expect(responses).To(HaveLen(3))
+		Expect(responses[0].Error.Code).To(Equal(mcp.CodeParseError))
+		Expect(string(responses[0].ID)).To(Equal("null"))
+		Expect(responses[1].Error.Code).To(Equal(mcp.CodeMethodNotFound))
+		Expect(responses[2].Error.Code).To(Equal(mcp.CodeInvalidParams))
+	})
+})
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// sseBacklog is the number of responses buffered per session before
+// POSTs block waiting for the stream to drain.
+const sseBacklog = 16
+
+// SSEHandler serves the MCP HTTP+SSE transport. Clients open an event stream
+// on ServeSSE, receive an "endpoint" event naming the URL to POST messages
+// to, and get responses back as "message" events on the stream.
+type SSEHandler struct {
+	server      *Server
+	messagePath string
+
+	mu       sync.Mutex
+	sessions map[string]*sseSession
+	closed   chan struct{}
+	once     sync.Once
+}
+
+type sseSession struct {
+	messages chan []byte
+	done     chan struct{}
+}
+
+// NewSSEHandler returns an SSE transport for server. messagePath is the URL
+// path clients POST JSON-RPC messages to.
+func NewSSEHandler(server *Server, messagePath string) *SSEHandler {
+	return &SSEHandler{
+		server:      server,
+		messagePath: messagePath,
+		sessions:    map[string]*sseSession{},
+		closed:      make(chan struct{}),
+	}
+}
+
+// ServeSSE opens an event stream for a new session and relays responses to it
+// until the client disconnects or the handler is closed.
+func (h *SSEHandler) ServeSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		http.Error(w, "failed to create session", http.StatusInternalServerError)
+		return
+	}
+	session := &sseSession{messages: make(chan []byte, sseBacklog), done: make(chan struct{})}
+
+	h.mu.Lock()
+	h.sessions[id] = session
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.sessions, id)
+		h.mu.Unlock()
+		close(session.done)
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeEvent(w, "endpoint", []byte(h.messagePath+"?sessionId="+id)); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.closed:
+			return
+		case msg := <-session.messages:
+			if err := writeEvent(w, "message", msg); err != nil {
+				slog.DebugContext(r.Context(), "MCP SSE write failed", "session", id, "error", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// ServeMessage handles a JSON-RPC message POSTed for an open session. The
+// response is delivered on the session's event stream.
+func (h *SSEHandler) ServeMessage(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	session, ok := h.sessions[r.URL.Query().Get("sessionId")]
+	h.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, "failed to read message", http.StatusBadRequest)
+		return
+	}
+
+	resp := h.server.HandleMessage(r.Context(), body)
+	if resp != nil {
+		select {
+		case session.messages <- resp:
+		case <-session.done:
+			http.Error(w, "session closed", http.StatusGone)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Close ends every open event stream. It is safe to call more than once.
+func (h *SSEHandler) Close() {
+	h.once.Do(func() { close(h.closed) })
+}
+
+func writeEvent(w io.Writer, event string, data []byte) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Tool pairs a descriptor with the handler executing tools/call requests.
+// Handlers return an *Error for invalid arguments, which is reported as a
+// JSON-RPC error; any other error is reported as a failed tool result.
+type Tool struct {
+	Descriptor ToolDescriptor
+	Handler    func(ctx context.Context, args json.RawMessage) (CallToolResult, error)
+}
+
+// ArgumentError describes a tool argument that failed validation.
+type ArgumentError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// invalidArgument returns the JSON-RPC error for a rejected tool argument.
+func invalidArgument(field, reason string) *Error {
+	return &Error{
+		Code:    CodeInvalidParams,
+		Message: fmt.Sprintf("invalid argument %q: %s", field, reason),
+		Data:    ArgumentError{Field: field, Reason: reason},
+	}
+}
+
+// jsonResult renders v both as text content and as structured content.
+func jsonResult(v any) (CallToolResult, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return CallToolResult{}, err
+	}
+	return CallToolResult{
+		Content:           []Content{{Type: "text", Text: string(data)}},
+		StructuredContent: v,
+	}, nil
+}
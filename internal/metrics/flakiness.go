@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultFlakinessTTL is how long a flakiness snapshot is served before
+	// the next scrape refreshes it.
+	DefaultFlakinessTTL = time.Minute
+	// DefaultFlakinessMaxSeries caps the fern_flaky_failure_rate series
+	// exposed per scrape.
+	DefaultFlakinessMaxSeries = 1000
+
+	// flakinessScanLimit caps how many tests are read per project for the
+	// fern_flaky_failure_rate series.
+	flakinessScanLimit = 100
+	// anyFailure is a minimum failure rate met by every test that failed at
+	// least once, as a nonzero rate is never below 1 over the run count.
+	anyFailure = math.SmallestNonzeroFloat64
+	// flakinessTimeout bounds the queries behind one refresh.
+	flakinessTimeout = 10 * time.Second
+)
+
+var (
+	flakyFailureRateDesc = prometheus.NewDesc("fern_flaky_failure_rate",
+		"Failure rate of each flaky test over the default lookback window, for the 100 most failing tests of each project.",
+		[]string{"project", "test"}, nil)
+	flakyTestCountDesc = prometheus.NewDesc("fern_flaky_test_count",
+		"Number of tests with at least one failure in the default lookback window.",
+		[]string{"project"}, nil)
+	flakySeriesDroppedDesc = prometheus.NewDesc("fern_flaky_series_dropped",
+		"Number of fern_flaky_failure_rate series left out by the max-series cap.",
+		nil, nil)
+)
+
+// FlakinessConfig tunes the flakiness collector.
+type FlakinessConfig struct {
+	// TTL is how long results are reused across scrapes.
+	TTL time.Duration
+	// MaxSeries caps the fern_flaky_failure_rate series per scrape. Tests
+	// past the cap are still counted in fern_flaky_test_count.
+	MaxSeries int
+}
+
+// CollectFlakiness registers a collector exposing the flaky tests of every
+// project as gauges. Each scrape reads projects, flaky tests and their count
+// through the given providers unless a snapshot younger than cfg.TTL is
+// available.
+func (m *Metrics) CollectFlakiness(flaky repo.FlakyTestProvider, counter repo.FlakyTestCounter, projects repo.ProjectProvider, cfg FlakinessConfig) {
+	m.registry.MustRegister(&flakinessCollector{flaky: flaky, counter: counter, projects: projects, cfg: cfg, now: time.Now})
+}
+
+type flakinessCollector struct {
+	flaky    repo.FlakyTestProvider
+	counter  repo.FlakyTestCounter
+	projects repo.ProjectProvider
+	cfg      FlakinessConfig
+	now      func() time.Time
+
+	// mu is held across refreshes so concurrent scrapes share one.
+	mu       sync.Mutex
+	snapshot []prometheus.Metric
+	expires  time.Time
+}
+
+func (c *flakinessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- flakyFailureRateDesc
+	ch <- flakyTestCountDesc
+	ch <- flakySeriesDroppedDesc
+}
+
+func (c *flakinessCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.snapshot == nil || !c.now().Before(c.expires) {
+		snapshot, err := c.refresh()
+		switch {
+		case err == nil:
+			c.snapshot, c.expires = snapshot, c.now().Add(c.cfg.TTL)
+		case c.snapshot == nil:
+			ch <- prometheus.NewInvalidMetric(flakyTestCountDesc, err)
+			return
+		default:
+			// Keep serving the last good snapshot rather than failing the scrape.
+			slog.Warn("⚠️ Failed to refresh flakiness metrics", "error", err)
+		}
+	}
+	for _, metric := range c.snapshot {
+		ch <- metric
+	}
+}
+
+func (c *flakinessCollector) refresh() ([]prometheus.Metric, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), flakinessTimeout)
+	defer cancel()
+
+	projects, err := c.projects.ListProjects(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := []prometheus.Metric{}
+	series, dropped := 0, 0
+	for _, project := range projects {
+		count, err := c.counter.CountFlakyTests(ctx, project.Name, repo.FlakyTestOptions{MinFailureRate: anyFailure})
+		if err != nil {
+			return nil, err
+		}
+		snapshot = append(snapshot, prometheus.MustNewConstMetric(flakyTestCountDesc,
+			prometheus.GaugeValue, float64(count), project.Name))
+
+		tests, err := c.flaky.GetFlakyTests(ctx, project.Name, flakinessScanLimit, repo.FlakyTestOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, test := range failing(tests) {
+			if series >= c.cfg.MaxSeries {
+				dropped++
+				continue
+			}
+			series++
+			snapshot = append(snapshot, prometheus.MustNewConstMetric(flakyFailureRateDesc,
+				prometheus.GaugeValue, test.FailureRate, project.Name, test.TestName))
+		}
+	}
+	return append(snapshot, prometheus.MustNewConstMetric(flakySeriesDroppedDesc,
+		prometheus.GaugeValue, float64(dropped))), nil
+}
+
+// failing returns the tests that failed at least once.
+func failing(tests []*gql.FlakyTest) []*gql.FlakyTest {
+	var out []*gql.FlakyTest
+	for _, test := range tests {
+		if test.FailureRate > 0 {
+			out = append(out, test)
+		}
+	}
+	return out
+}
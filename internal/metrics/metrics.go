@@ -0,0 +1,113 @@
+// Package metrics exposes Prometheus instrumentation for fern-mycelium.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the collectors fern-mycelium records into.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	ResolverCalls      *prometheus.CounterVec
+	ResolverErrors     *prometheus.CounterVec
+	FlakyQueryDuration prometheus.Histogram
+}
+
+// New registers the Go and process collectors plus the fern-mycelium
+// collectors with registry.
+func New(registry *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		registry: registry,
+		ResolverCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mycelium_graphql_resolver_calls_total",
+			Help: "Number of top-level GraphQL resolver invocations.",
+		}, []string{"operation"}),
+		ResolverErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mycelium_graphql_resolver_errors_total",
+			Help: "Number of top-level GraphQL resolver invocations that returned an error.",
+		}, []string{"operation"}),
+		FlakyQueryDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "mycelium_flaky_tests_query_duration_seconds",
+			Help:    "Duration of GetFlakyTests database queries.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.ResolverCalls,
+		m.ResolverErrors,
+		m.FlakyQueryDuration,
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Extension returns a gqlgen extension counting root field resolutions.
+func (m *Metrics) Extension() graphql.HandlerExtension {
+	return resolverCounter{m}
+}
+
+type resolverCounter struct {
+	m *Metrics
+}
+
+func (resolverCounter) ExtensionName() string {
+	return "ResolverMetrics"
+}
+
+func (resolverCounter) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (c resolverCounter) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !isRootObject(fc.Object) {
+		return next(ctx)
+	}
+
+	operation := fc.Field.Name
+	c.m.ResolverCalls.WithLabelValues(operation).Inc()
+
+	res, err := next(ctx)
+	if err != nil {
+		c.m.ResolverErrors.WithLabelValues(operation).Inc()
+	}
+	return res, err
+}
+
+func isRootObject(name string) bool {
+	return name == "Query" || name == "Mutation" || name == "Subscription"
+}
+
+// InstrumentFlakyTests wraps provider so each GetFlakyTests call is observed
+// in the query duration histogram.
+func (m *Metrics) InstrumentFlakyTests(provider repo.FlakyTestProvider) repo.FlakyTestProvider {
+	return &flakyTestTimer{provider: provider, hist: m.FlakyQueryDuration}
+}
+
+type flakyTestTimer struct {
+	provider repo.FlakyTestProvider
+	hist     prometheus.Histogram
+}
+
+func (t *flakyTestTimer) GetFlakyTests(ctx context.Context, projectID string, limit int, opts repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+	start := time.Now()
+	defer func() { t.hist.Observe(time.Since(start).Seconds()) }()
+	return t.provider.GetFlakyTests(ctx, projectID, limit, opts)
+}
@@ -0,0 +1,204 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+const (
+	// HighRiskFailureRate is the failure rate from which a flaky test is
+	// treated as high risk and filed as a GitHub issue.
+	HighRiskFailureRate = 0.3
+	// GitHubIssueLabel marks the issues filed for flaky tests. Together with
+	// the issue title it identifies the issue already filed for a test.
+	GitHubIssueLabel = "flaky-test"
+	// DefaultGitHubAPIURL is the REST API of github.com.
+	DefaultGitHubAPIURL = "https://api.github.com"
+
+	githubTimeout  = 10 * time.Second
+	githubPageSize = 100
+)
+
+// GitHubIssues files one issue per flaky test in a GitHub repository,
+// updating the open issue for a test instead of opening another.
+type GitHubIssues struct {
+	apiURL     string
+	repository string
+	token      string
+	client     *http.Client
+}
+
+// GitHubOption configures a GitHubIssues.
+type GitHubOption func(*GitHubIssues)
+
+// WithGitHubAPIURL talks to the REST API at apiURL instead of github.com,
+// e.g. a GitHub Enterprise Server.
+func WithGitHubAPIURL(apiURL string) GitHubOption {
+	return func(g *GitHubIssues) {
+		if apiURL != "" {
+			g.apiURL = strings.TrimSuffix(apiURL, "/")
+		}
+	}
+}
+
+// NewGitHubIssues files issues in repository, given as owner/name, using a
+// personal access token allowed to write its issues.
+func NewGitHubIssues(repository, token string, opts ...GitHubOption) *GitHubIssues {
+	g := &GitHubIssues{
+		apiURL:     DefaultGitHubAPIURL,
+		repository: repository,
+		token:      token,
+		client:     &http.Client{Timeout: githubTimeout},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Issue identifies the GitHub issue filed for a flaky test.
+type Issue struct {
+	Number int    `json:"number"`
+	URL    string `json:"html_url"`
+	// Created is true when the issue was opened rather than updated.
+	Created bool `json:"-"`
+}
+
+// Notify files an issue for test, satisfying Notifier.
+func (g *GitHubIssues) Notify(ctx context.Context, project string, test *gql.FlakyTest) error {
+	_, err := g.FileIssue(ctx, project, test)
+	return err
+}
+
+// FileIssue opens an issue for test in project, or refreshes the body of
+// the open issue already filed for it.
+func (g *GitHubIssues) FileIssue(ctx context.Context, project string, test *gql.FlakyTest) (*Issue, error) {
+	title := IssueTitle(project, test.TestName)
+	body := issueBody(project, test)
+
+	existing, err := g.findOpenIssue(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		var issue Issue
+		path := "/repos/" + g.repository + "/issues/" + strconv.Itoa(existing.Number)
+		if err := g.do(ctx, http.MethodPatch, path, map[string]any{"body": body}, &issue); err != nil {
+			return nil, err
+		}
+		return &issue, nil
+	}
+
+	issue := Issue{Created: true}
+	payload := map[string]any{"title": title, "body": body, "labels": []string{GitHubIssueLabel}}
+	if err := g.do(ctx, http.MethodPost, "/repos/"+g.repository+"/issues", payload, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// IssueTitle is the title of the issue filed for testName in project.
+func IssueTitle(project, testName string) string {
+	return fmt.Sprintf("Flaky test: %s (%s)", testName, project)
+}
+
+// findOpenIssue returns the open, labelled issue with title, or nil.
+func (g *GitHubIssues) findOpenIssue(ctx context.Context, title string) (*Issue, error) {
+	for page := 1; ; page++ {
+		query := url.Values{
+			"labels":   {GitHubIssueLabel},
+			"state":    {"open"},
+			"per_page": {strconv.Itoa(githubPageSize)},
+			"page":     {strconv.Itoa(page)},
+		}
+		var issues []struct {
+			Issue
+			Title string `json:"title"`
+			// PullRequest is set when the entry is a pull request, which the
+			// issues API also lists.
+			PullRequest json.RawMessage `json:"pull_request"`
+		}
+		if err := g.do(ctx, http.MethodGet, "/repos/"+g.repository+"/issues?"+query.Encode(), nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.PullRequest == nil && issue.Title == title {
+				return &issue.Issue, nil
+			}
+		}
+		if len(issues) < githubPageSize {
+			return nil, nil
+		}
+	}
+}
+
+func (g *GitHubIssues) do(ctx context.Context, method, path string, payload, out any) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("github: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&apiErr)
+		return fmt.Errorf("github %s %s returned %s: %s", method, strings.SplitN(path, "?", 2)[0], resp.Status, apiErr.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func issueBody(project string, test *gql.FlakyTest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "`%s` in project **%s** is failing often enough to be considered high risk.\n\n", test.TestName, project)
+	b.WriteString("| Failure rate | Pass rate | Runs | Last failure |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	lastFailure := "never"
+	if test.LastFailure != nil {
+		lastFailure = *test.LastFailure
+	}
+	fmt.Fprintf(&b, "| %.1f%% | %.1f%% | %d | %s |\n", test.FailureRate*100, test.PassRate*100, test.RunCount, lastFailure)
+
+	if len(test.TopFailureMessages) > 0 {
+		b.WriteString("\n### Most frequent failures\n\n")
+		for _, message := range test.TopFailureMessages {
+			fmt.Fprintf(&b, "- %d× `%s`\n", message.Count, inlineCode(message.Message))
+		}
+	}
+	b.WriteString("\n_Filed by fern-mycelium. This issue is updated while the test stays flaky._\n")
+	return b.String()
+}
+
+// inlineCode flattens s onto one line that can sit inside backticks.
+func inlineCode(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "`", "'")
+}
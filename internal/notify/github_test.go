@@ -0,0 +1,141 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/notify"
+)
+
+// githubStub mocks the parts of the GitHub REST API used to file issues.
+type githubStub struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	issues  []map[string]any
+	posts   []map[string]any
+	patches map[int]map[string]any
+}
+
+func newGitHubStub() *githubStub {
+	stub := &githubStub{patches: map[int]map[string]any{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/webapp/issues", func(w http.ResponseWriter, r *http.Request) {
+		defer GinkgoRecover()
+		Expect(r.Header.Get("Authorization")).To(Equal("Bearer pat-123"))
+		Expect(r.URL.Query().Get("labels")).To(Equal(notify.GitHubIssueLabel))
+		Expect(r.URL.Query().Get("state")).To(Equal("open"))
+
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		start := min((page-1)*perPage, len(stub.issues))
+		end := min(start+perPage, len(stub.issues))
+		_ = json.NewEncoder(w).Encode(stub.issues[start:end])
+	})
+	mux.HandleFunc("POST /repos/acme/webapp/issues", func(w http.ResponseWriter, r *http.Request) {
+		defer GinkgoRecover()
+		var payload map[string]any
+		Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		stub.posts = append(stub.posts, payload)
+		number := 100 + len(stub.posts)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"number": number, "html_url": fmt.Sprintf("https://github.example/acme/webapp/issues/%d", number)})
+	})
+	mux.HandleFunc("PATCH /repos/acme/webapp/issues/{number}", func(w http.ResponseWriter, r *http.Request) {
+		defer GinkgoRecover()
+		number, err := strconv.Atoi(r.PathValue("number"))
+		Expect(err).NotTo(HaveOccurred())
+		var payload map[string]any
+		Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		stub.patches[number] = payload
+		_ = json.NewEncoder(w).Encode(map[string]any{"number": number, "html_url": fmt.Sprintf("https://github.example/acme/webapp/issues/%d", number)})
+	})
+	stub.Server = httptest.NewServer(mux)
+	return stub
+}
+
+var _ = Describe("GitHubIssues", func() {
+	var (
+		github *githubStub
+		issues *notify.GitHubIssues
+		ctx    context.Context
+		test   *gql.FlakyTest
+	)
+
+	BeforeEach(func() {
+		github = newGitHubStub()
+		DeferCleanup(github.Close)
+		issues = notify.NewGitHubIssues("acme/webapp", "pat-123", notify.WithGitHubAPIURL(github.URL+"/"))
+		ctx = context.Background()
+
+		lastFailure := "2026-10-01T12:00:00Z"
+		test = &gql.FlakyTest{
+			TestName: "login", FailureRate: 0.4, PassRate: 0.6, RunCount: 10, LastFailure: &lastFailure,
+			TopFailureMessages: []*gql.FailureMessage{{Message: "timeout\nwaiting for `token`", Count: 3}},
+		}
+	})
+
+	It("should open a labelled issue when none is filed for the test", func() {
+		issue, err := issues.FileIssue(ctx, "demo", test)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issue).To(Equal(&notify.Issue{Number: 101, URL: "https://github.example/acme/webapp/issues/101", Created: true}))
+
+		Expect(github.posts).To(HaveLen(1))
+		Expect(github.posts[0]).To(HaveKeyWithValue("title", "Flaky test: login (demo)"))
+		Expect(github.posts[0]).To(HaveKeyWithValue("labels", []any{notify.GitHubIssueLabel}))
+		Expect(github.posts[0]["body"]).To(And(
+			ContainSubstring("| 40.0% | 60.0% | 10 | 2026-10-01T12:00:00Z |"),
+			ContainSubstring("- 3× `timeout waiting for 'token'`"),
+		))
+	})
+
+	It("should update the open issue instead of filing a duplicate", func() {
+		github.issues = []map[string]any{
+			{"number": 7, "title": "Flaky test: login (demo)", "pull_request": map[string]any{}},
+			{"number": 8, "title": "Flaky test: login (billing)"},
+		}
+		for i := range 100 {
+			github.issues = append(github.issues, map[string]any{"number": 200 + i, "title": fmt.Sprintf("Flaky test: other-%d (demo)", i)})
+		}
+		github.issues = append(github.issues, map[string]any{"number": 42, "title": "Flaky test: login (demo)"})
+
+		issue, err := issues.FileIssue(ctx, "demo", test)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issue).To(Equal(&notify.Issue{Number: 42, URL: "https://github.example/acme/webapp/issues/42"}))
+
+		Expect(github.posts).To(BeEmpty())
+		Expect(github.patches).To(HaveKey(42))
+		Expect(github.patches[42]).To(HaveKey("body"))
+		Expect(github.patches[42]).NotTo(HaveKey("title"))
+	})
+
+	It("should file issues through the Notifier interface", func() {
+		var notifier notify.Notifier = issues
+		Expect(notifier.Notify(ctx, "demo", test)).To(Succeed())
+		Expect(github.posts).To(HaveLen(1))
+	})
+
+	It("should report errors returned by the API", func() {
+		issues = notify.NewGitHubIssues("acme/missing", "pat-123", notify.WithGitHubAPIURL(github.URL))
+
+		_, err := issues.FileIssue(ctx, "demo", test)
+		Expect(err).To(MatchError(ContainSubstring("github GET /repos/acme/missing/issues returned 404 Not Found")))
+	})
+})
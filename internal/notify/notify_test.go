@@ -0,0 +1,202 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/notify"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+// slackStub stands in for a Slack incoming webhook, recording each message.
+type slackStub struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages []string
+	status   int
+}
+
+func newSlackStub() *slackStub {
+	stub := &slackStub{status: http.StatusOK}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer GinkgoRecover()
+		Expect(r.Method).To(Equal(http.MethodPost))
+		Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+
+		var payload struct {
+			Text string `json:"text"`
+		}
+		Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+		if stub.status != http.StatusOK {
+			http.Error(w, "invalid_token", stub.status)
+			return
+		}
+		stub.messages = append(stub.messages, payload.Text)
+	}))
+	return stub
+}
+
+func (s *slackStub) Messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+func (s *slackStub) SetStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+var _ = Describe("SlackNotifier", func() {
+	var slack *slackStub
+
+	BeforeEach(func() {
+		slack = newSlackStub()
+		DeferCleanup(slack.Close)
+	})
+
+	It("should post the test name, failure rate and project", func() {
+		notifier := notify.NewSlackNotifier(slack.URL)
+
+		err := notifier.Notify(context.Background(), "demo", &gql.FlakyTest{TestName: "login <admin>", FailureRate: 0.375, RunCount: 8})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(slack.Messages()).To(Equal([]string{
+			":warning: Flaky test detected in *demo*\n`login &lt;admin&gt;` failed 38% of its last 8 runs",
+		}))
+	})
+
+	It("should return an error when Slack rejects the message", func() {
+		slack.SetStatus(http.StatusForbidden)
+		notifier := notify.NewSlackNotifier(slack.URL)
+
+		err := notifier.Notify(context.Background(), "demo", &gql.FlakyTest{TestName: "login"})
+		Expect(err).To(MatchError("slack webhook returned 403 Forbidden: invalid_token"))
+	})
+})
+
+var _ = Describe("Watcher", func() {
+	var (
+		slack     *slackStub
+		fakeFlaky *fakes.FakeFlakyTestProvider
+		projects  *fakes.FakeProjectProvider
+		mu        sync.Mutex
+		polls     [][]*gql.FlakyTest
+	)
+
+	// setPolls makes each scan return the next batch of tests, repeating the
+	// last one once they run out.
+	setPolls := func(batches ...[]*gql.FlakyTest) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls = batches
+	}
+
+	BeforeEach(func() {
+		slack = newSlackStub()
+		DeferCleanup(slack.Close)
+
+		projects = &fakes.FakeProjectProvider{}
+		projects.ListProjectsReturns([]*gql.Project{{ID: "1", Name: "demo"}}, nil)
+
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		fakeFlaky.GetFlakyTestsStub = func(context.Context, string, int, repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			next := polls[0]
+			if len(polls) > 1 {
+				polls = polls[1:]
+			}
+			return next, nil
+		}
+	})
+
+	run := func(opts ...notify.WatcherOption) {
+		watcher := notify.NewWatcher(fakeFlaky, projects, notify.NewSlackNotifier(slack.URL), 5*time.Millisecond, 0.5, opts...)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			watcher.Run(ctx)
+		}()
+		DeferCleanup(func() {
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+	}
+
+	It("should notify once about each test that newly crosses the threshold", func() {
+		login := &gql.FlakyTest{TestName: "login", FailureRate: 0.6, RunCount: 10}
+		logout := &gql.FlakyTest{TestName: "logout", FailureRate: 0.5, RunCount: 4}
+		setPolls([]*gql.FlakyTest{login}, []*gql.FlakyTest{login, logout})
+
+		run()
+
+		Eventually(fakeFlaky.GetFlakyTestsCallCount).Should(BeNumerically(">=", 5))
+		Expect(slack.Messages()).To(Equal([]string{
+			":warning: Flaky test detected in *demo*\n`logout` failed 50% of its last 4 runs",
+		}))
+
+		_, project, limit, opts := fakeFlaky.GetFlakyTestsArgsForCall(0)
+		Expect(project).To(Equal("demo"))
+		Expect(limit).To(BeNumerically(">", 0))
+		Expect(opts).To(Equal(repo.FlakyTestOptions{MinFailureRate: 0.5}))
+	})
+
+	It("should notify again after a test recovers and crosses the threshold again", func() {
+		login := &gql.FlakyTest{TestName: "login", FailureRate: 0.6, RunCount: 10}
+		setPolls(nil, []*gql.FlakyTest{login}, nil, []*gql.FlakyTest{login})
+
+		run()
+
+		Eventually(slack.Messages).Should(HaveLen(2))
+		Consistently(slack.Messages, 50*time.Millisecond).Should(HaveLen(2))
+	})
+
+	It("should retry a notification that Slack rejected", func() {
+		slack.SetStatus(http.StatusInternalServerError)
+		setPolls(nil, []*gql.FlakyTest{{TestName: "login", FailureRate: 0.6, RunCount: 10}})
+
+		run()
+
+		Eventually(fakeFlaky.GetFlakyTestsCallCount).Should(BeNumerically(">=", 3))
+		Expect(slack.Messages()).To(BeEmpty())
+
+		slack.SetStatus(http.StatusOK)
+		Eventually(slack.Messages).Should(HaveLen(1))
+		Consistently(slack.Messages, 50*time.Millisecond).Should(HaveLen(1))
+	})
+
+	It("should notify about every test on every scan, the first included, with WithEveryScan", func() {
+		login := &gql.FlakyTest{TestName: "login", FailureRate: 0.6, RunCount: 10}
+		setPolls([]*gql.FlakyTest{login})
+
+		run(notify.WithEveryScan())
+
+		Eventually(func() int { return len(slack.Messages()) }).Should(BeNumerically(">=", 3))
+		Expect(slack.Messages()).To(HaveEach(ContainSubstring("`login` failed 60%")))
+	})
+
+	It("should keep running when a scan fails", func() {
+		projects.ListProjectsReturnsOnCall(0, nil, errors.New("db down"))
+		setPolls(nil, []*gql.FlakyTest{{TestName: "login", FailureRate: 0.6, RunCount: 10}})
+
+		run()
+
+		Eventually(slack.Messages).Should(HaveLen(1))
+	})
+})
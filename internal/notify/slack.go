@@ -0,0 +1,67 @@
+// Package notify tells people outside fern-mycelium when tests become flaky.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// slackTimeout bounds each webhook delivery.
+const slackTimeout = 10 * time.Second
+
+// Notifier delivers an alert that test has become flaky in project.
+type Notifier interface {
+	Notify(ctx context.Context, project string, test *gql.FlakyTest) error
+}
+
+// SlackNotifier posts alerts to a Slack incoming webhook.
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier returns a notifier posting to webhookURL.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{webhookURL: webhookURL, client: &http.Client{Timeout: slackTimeout}}
+}
+
+// Notify posts a message naming the test, its project and its failure rate.
+func (n *SlackNotifier) Notify(ctx context.Context, project string, test *gql.FlakyTest) error {
+	body, err := json.Marshal(map[string]string{"text": slackMessage(project, test)})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// slackEscaper escapes the characters Slack treats as control sequences in
+// message text.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackMessage(project string, test *gql.FlakyTest) string {
+	return fmt.Sprintf(":warning: Flaky test detected in *%s*\n`%s` failed %.0f%% of its last %d runs",
+		slackEscaper.Replace(project), slackEscaper.Replace(test.TestName), test.FailureRate*100, test.RunCount)
+}
@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+const (
+	// DefaultInterval is how often a Watcher scans for flaky tests.
+	DefaultInterval = 5 * time.Minute
+	// scanLimit caps how many tests are inspected per project each scan.
+	scanLimit = 100
+)
+
+// Watcher scans every project for flaky tests on an interval and notifies
+// about each test that newly reaches a failure-rate threshold.
+type Watcher struct {
+	flaky     repo.FlakyTestProvider
+	projects  repo.ProjectProvider
+	notifier  Notifier
+	interval  time.Duration
+	threshold float64
+
+	// alerted holds, per project, the tests over the threshold at the last
+	// scan. A test is notified about again only after it drops below.
+	alerted map[string]map[string]bool
+	// everyScan notifies about every test over the threshold on each scan.
+	everyScan bool
+}
+
+// WatcherOption configures a Watcher.
+type WatcherOption func(*Watcher)
+
+// WithEveryScan notifies about every test over the threshold on every scan,
+// the first included, instead of only about tests newly crossing it. It
+// suits notifiers that update what they sent before, such as GitHubIssues.
+func WithEveryScan() WatcherOption {
+	return func(w *Watcher) {
+		w.everyScan = true
+	}
+}
+
+// NewWatcher returns a watcher notifying through notifier. A non-positive
+// interval uses DefaultInterval.
+func NewWatcher(flaky repo.FlakyTestProvider, projects repo.ProjectProvider, notifier Notifier, interval time.Duration, threshold float64, opts ...WatcherOption) *Watcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	w := &Watcher{
+		flaky:     flaky,
+		projects:  projects,
+		notifier:  notifier,
+		interval:  interval,
+		threshold: threshold,
+		alerted:   map[string]map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Run scans immediately and then every interval until ctx is done. Unless
+// WithEveryScan is set, the first scan of each project records the tests
+// already over the threshold without notifying, so a restart does not
+// repeat earlier alerts.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.scan(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) scan(ctx context.Context) {
+	projects, err := w.projects.ListProjects(ctx, "")
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.WarnContext(ctx, "⚠️ Flaky test notifier could not list projects", "error", err)
+		}
+		return
+	}
+	for _, project := range projects {
+		if ctx.Err() != nil {
+			return
+		}
+		w.scanProject(ctx, project.Name)
+	}
+}
+
+func (w *Watcher) scanProject(ctx context.Context, project string) {
+	tests, err := w.flaky.GetFlakyTests(ctx, project, scanLimit, repo.FlakyTestOptions{MinFailureRate: w.threshold})
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.WarnContext(ctx, "⚠️ Flaky test notifier scan failed", "project", project, "error", err)
+		}
+		return
+	}
+
+	previous, seen := w.alerted[project]
+	current := make(map[string]bool, len(tests))
+	for _, test := range tests {
+		if !w.everyScan && (!seen || previous[test.TestName]) {
+			current[test.TestName] = true
+			continue
+		}
+		if err := w.notifier.Notify(ctx, project, test); err != nil {
+			// Leave the test out of current so the next scan retries it.
+			slog.WarnContext(ctx, "⚠️ Flaky test notification failed", "project", project, "test", test.TestName, "error", err)
+			continue
+		}
+		current[test.TestName] = true
+	}
+	w.alerted[project] = current
+}
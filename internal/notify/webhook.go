@@ -0,0 +1,168 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+const (
+	// SignatureHeader carries the hex HMAC-SHA256 of a webhook body, prefixed
+	// with "sha256=", when a signing secret is configured.
+	SignatureHeader = "X-Mycelium-Signature-256"
+
+	// DefaultWebhookTimeout bounds each delivery attempt.
+	DefaultWebhookTimeout = 10 * time.Second
+	// DefaultWebhookAttempts is how many times a delivery is tried.
+	DefaultWebhookAttempts = 3
+	// defaultWebhookBackoff is the wait before the first retry; it doubles
+	// for each retry after that.
+	defaultWebhookBackoff = time.Second
+)
+
+// WebhookPayload is the JSON body posted for each alert.
+type WebhookPayload struct {
+	Project     string  `json:"project"`
+	Test        string  `json:"test"`
+	FailureRate float64 `json:"failureRate"`
+	RunCount    int     `json:"runCount"`
+}
+
+// WebhookNotifier posts a WebhookPayload to each of a list of URLs, retrying
+// network errors and 5xx or 429 responses.
+type WebhookNotifier struct {
+	urls     []string
+	secret   []byte
+	attempts int
+	backoff  time.Duration
+	client   *http.Client
+}
+
+// WebhookOption configures a WebhookNotifier.
+type WebhookOption func(*WebhookNotifier)
+
+// WithWebhookSecret signs each body with secret in SignatureHeader.
+func WithWebhookSecret(secret string) WebhookOption {
+	return func(n *WebhookNotifier) {
+		if secret != "" {
+			n.secret = []byte(secret)
+		}
+	}
+}
+
+// WithWebhookTimeout bounds each delivery attempt by d. Zero keeps the
+// default.
+func WithWebhookTimeout(d time.Duration) WebhookOption {
+	return func(n *WebhookNotifier) {
+		if d > 0 {
+			n.client.Timeout = d
+		}
+	}
+}
+
+// WithWebhookRetries tries each delivery up to attempts times, waiting
+// backoff before the first retry and twice as long before each one after.
+func WithWebhookRetries(attempts int, backoff time.Duration) WebhookOption {
+	return func(n *WebhookNotifier) {
+		n.attempts = max(attempts, 1)
+		n.backoff = backoff
+	}
+}
+
+// NewWebhookNotifier returns a notifier posting to urls.
+func NewWebhookNotifier(urls []string, opts ...WebhookOption) *WebhookNotifier {
+	n := &WebhookNotifier{
+		urls:     urls,
+		attempts: DefaultWebhookAttempts,
+		backoff:  defaultWebhookBackoff,
+		client:   &http.Client{Timeout: DefaultWebhookTimeout},
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// Notify delivers the alert to every URL. It returns the failures joined
+// together; URLs that accepted the alert may receive it again if the caller
+// retries.
+func (n *WebhookNotifier) Notify(ctx context.Context, project string, test *gql.FlakyTest) error {
+	body, err := json.Marshal(WebhookPayload{
+		Project:     project,
+		Test:        test.TestName,
+		FailureRate: test.FailureRate,
+		RunCount:    test.RunCount,
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, target := range n.urls {
+		if err := n.deliver(ctx, target, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Sign returns the SignatureHeader value for body signed with secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (n *WebhookNotifier) deliver(ctx context.Context, url string, body []byte) error {
+	wait := n.backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		if retry, err = n.post(ctx, url, body); err == nil || !retry || attempt == n.attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", url, err)
+	}
+	return nil
+}
+
+// post makes one delivery attempt, reporting whether a failure is worth
+// retrying.
+func (n *WebhookNotifier) post(ctx context.Context, url string, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != nil {
+		req.Header.Set(SignatureHeader, Sign(n.secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("returned %s", resp.Status)
+}
@@ -0,0 +1,127 @@
+package notify_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/notify"
+)
+
+type webhookRequest struct {
+	body      []byte
+	signature string
+}
+
+var _ = Describe("WebhookNotifier", func() {
+	var (
+		mu        sync.Mutex
+		requests  []webhookRequest
+		responses []int
+		receiver  *httptest.Server
+		test      *gql.FlakyTest
+	)
+
+	BeforeEach(func() {
+		requests, responses = nil, nil
+		receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			body, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, webhookRequest{body: body, signature: r.Header.Get(notify.SignatureHeader)})
+			status := http.StatusNoContent
+			if len(responses) > 0 {
+				status, responses = responses[0], responses[1:]
+			}
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(receiver.Close)
+
+		test = &gql.FlakyTest{TestName: "login", FailureRate: 0.6, PassRate: 0.4, RunCount: 10}
+	})
+
+	It("should post the alert payload to every URL", func() {
+		notifier := notify.NewWebhookNotifier([]string{receiver.URL + "/pagerduty", receiver.URL + "/custom"})
+
+		Expect(notifier.Notify(context.Background(), "demo", test)).To(Succeed())
+		Expect(requests).To(HaveLen(2))
+		for _, req := range requests {
+			Expect(req.body).To(MatchJSON(`{"project":"demo","test":"login","failureRate":0.6,"runCount":10}`))
+			Expect(req.signature).To(BeEmpty())
+		}
+	})
+
+	It("should sign the body when a secret is configured", func() {
+		notifier := notify.NewWebhookNotifier([]string{receiver.URL}, notify.WithWebhookSecret("shh"))
+
+		Expect(notifier.Notify(context.Background(), "demo", test)).To(Succeed())
+		Expect(requests).To(HaveLen(1))
+
+		mac := hmac.New(sha256.New, []byte("shh"))
+		mac.Write(requests[0].body)
+		Expect(requests[0].signature).To(Equal("sha256=" + hex.EncodeToString(mac.Sum(nil))))
+		Expect(notify.Sign([]byte("shh"), requests[0].body)).To(Equal(requests[0].signature))
+	})
+
+	It("should retry server errors until the delivery succeeds", func() {
+		responses = []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK}
+		notifier := notify.NewWebhookNotifier([]string{receiver.URL}, notify.WithWebhookRetries(3, time.Millisecond))
+
+		Expect(notifier.Notify(context.Background(), "demo", test)).To(Succeed())
+		Expect(requests).To(HaveLen(3))
+		Expect(requests[2].body).To(Equal(requests[0].body))
+	})
+
+	It("should give up after the last attempt", func() {
+		responses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+		notifier := notify.NewWebhookNotifier([]string{receiver.URL}, notify.WithWebhookRetries(2, time.Millisecond))
+
+		err := notifier.Notify(context.Background(), "demo", test)
+		Expect(err).To(MatchError("webhook " + receiver.URL + ": returned 503 Service Unavailable"))
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("should not retry client errors", func() {
+		responses = []int{http.StatusBadRequest}
+		notifier := notify.NewWebhookNotifier([]string{receiver.URL}, notify.WithWebhookRetries(3, time.Millisecond))
+
+		Expect(notifier.Notify(context.Background(), "demo", test)).To(MatchError(ContainSubstring("400 Bad Request")))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("should time out slow receivers", func() {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		DeferCleanup(slow.Close)
+		notifier := notify.NewWebhookNotifier([]string{slow.URL},
+			notify.WithWebhookTimeout(20*time.Millisecond), notify.WithWebhookRetries(1, 0))
+
+		err := notifier.Notify(context.Background(), "demo", test)
+		Expect(err).To(MatchError(ContainSubstring("Client.Timeout exceeded")))
+	})
+
+	It("should still deliver to the other URLs when one fails", func() {
+		notifier := notify.NewWebhookNotifier([]string{"http://127.0.0.1:1/unreachable", receiver.URL},
+			notify.WithWebhookRetries(1, 0))
+
+		err := notifier.Notify(context.Background(), "demo", test)
+		Expect(err).To(MatchError(ContainSubstring("webhook http://127.0.0.1:1/unreachable")))
+		Expect(requests).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// FlakyDiff is the change in a project's flaky set between two scans.
+type FlakyDiff struct {
+	// Flaky holds the tests in the current scan that were not in the
+	// previous one, with their current statistics.
+	Flaky []*gql.FlakyTest
+	// Recovered holds the tests in the previous scan that are gone from the
+	// current one, with their last known statistics.
+	Recovered []*gql.FlakyTest
+}
+
+// Empty reports whether the flaky set did not change.
+func (d FlakyDiff) Empty() bool {
+	return len(d.Flaky) == 0 && len(d.Recovered) == 0
+}
+
+// DiffFlakyTests compares two successive scans by test name. Both lists keep
+// the order of the scan they come from.
+func DiffFlakyTests(previous, current []*gql.FlakyTest) FlakyDiff {
+	before := make(map[string]bool, len(previous))
+	for _, test := range previous {
+		before[test.TestName] = true
+	}
+	after := make(map[string]bool, len(current))
+	for _, test := range current {
+		after[test.TestName] = true
+	}
+
+	var diff FlakyDiff
+	for _, test := range current {
+		if !before[test.TestName] {
+			diff.Flaky = append(diff.Flaky, test)
+		}
+	}
+	for _, test := range previous {
+		if !after[test.TestName] {
+			diff.Recovered = append(diff.Recovered, test)
+		}
+	}
+	return diff
+}
+
+// WriteFlakyDiff writes one line per change in diff, stamped with at:
+// "+" for a newly flaky test and "-" for a recovered one.
+func WriteFlakyDiff(w io.Writer, diff FlakyDiff, at time.Time) error {
+	stamp := at.Format(time.TimeOnly)
+	for _, test := range diff.Flaky {
+		if _, err := fmt.Fprintf(w, "%s + %s flaky: %.1f%% of %d runs failed\n",
+			stamp, test.TestName, test.FailureRate*100, test.RunCount); err != nil {
+			return err
+		}
+	}
+	for _, test := range diff.Recovered {
+		if _, err := fmt.Fprintf(w, "%s - %s recovered\n", stamp, test.TestName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package report_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/report"
+)
+
+var _ = Describe("DiffFlakyTests", func() {
+	login := &gql.FlakyTest{TestName: "login", FailureRate: 0.3, RunCount: 10}
+	logout := &gql.FlakyTest{TestName: "logout", FailureRate: 0.2, RunCount: 5}
+	checkout := &gql.FlakyTest{TestName: "checkout", FailureRate: 0.5, RunCount: 4}
+
+	It("should report newly flaky and recovered tests between two scans", func() {
+		loginNow := &gql.FlakyTest{TestName: "login", FailureRate: 0.4, RunCount: 11}
+
+		diff := report.DiffFlakyTests([]*gql.FlakyTest{login, logout}, []*gql.FlakyTest{checkout, loginNow})
+		Expect(diff.Flaky).To(Equal([]*gql.FlakyTest{checkout}))
+		Expect(diff.Recovered).To(Equal([]*gql.FlakyTest{logout}))
+		Expect(diff.Empty()).To(BeFalse())
+	})
+
+	It("should be empty when the flaky set is unchanged", func() {
+		diff := report.DiffFlakyTests([]*gql.FlakyTest{login, logout}, []*gql.FlakyTest{logout, login})
+		Expect(diff.Empty()).To(BeTrue())
+	})
+
+	It("should treat every test as newly flaky after an empty scan", func() {
+		diff := report.DiffFlakyTests(nil, []*gql.FlakyTest{login})
+		Expect(diff.Flaky).To(Equal([]*gql.FlakyTest{login}))
+		Expect(diff.Recovered).To(BeEmpty())
+	})
+
+	It("should write one stamped line per change", func() {
+		var buf bytes.Buffer
+		at := time.Date(2025, 4, 1, 10, 30, 0, 0, time.UTC)
+
+		diff := report.FlakyDiff{Flaky: []*gql.FlakyTest{checkout}, Recovered: []*gql.FlakyTest{logout}}
+		Expect(report.WriteFlakyDiff(&buf, diff, at)).To(Succeed())
+		Expect(buf.String()).To(Equal("" +
+			"10:30:00 + checkout flaky: 50.0% of 4 runs failed\n" +
+			"10:30:00 - logout recovered\n"))
+	})
+})
@@ -0,0 +1,61 @@
+// Package report renders query results for the mycel CLI.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// Output formats supported by the CLI.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// ValidateFormat reports whether format is one the CLI can render.
+func ValidateFormat(format string) error {
+	if format != FormatTable && format != FormatJSON {
+		return fmt.Errorf("unknown format %q: must be %s or %s", format, FormatTable, FormatJSON)
+	}
+	return nil
+}
+
+// WriteFlakyTests writes tests to w in the given format.
+func WriteFlakyTests(w io.Writer, tests []*gql.FlakyTest, format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	if format == FormatJSON {
+		return FlakyTestsJSON(w, tests)
+	}
+	return FlakyTestsTable(w, tests)
+}
+
+// FlakyTestsJSON writes tests as an indented JSON array.
+func FlakyTestsJSON(w io.Writer, tests []*gql.FlakyTest) error {
+	if tests == nil {
+		tests = []*gql.FlakyTest{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(tests)
+}
+
+// FlakyTestsTable writes tests as aligned columns with a header row.
+func FlakyTestsTable(w io.Writer, tests []*gql.FlakyTest) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TEST\tRUNS\tFAILURE RATE\tPASS RATE\tLAST FAILURE")
+	for _, test := range tests {
+		lastFailure := "-"
+		if test.LastFailure != nil {
+			lastFailure = *test.LastFailure
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.1f%%\t%.1f%%\t%s\n",
+			test.TestName, test.RunCount, test.FailureRate*100, test.PassRate*100, lastFailure)
+	}
+	return tw.Flush()
+}
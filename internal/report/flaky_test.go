@@ -0,0 +1,48 @@
+package report_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/report"
+)
+
+var _ = Describe("WriteFlakyTests", func() {
+	lastFailure := "2025-03-30T18:44:10Z"
+	tests := []*gql.FlakyTest{
+		{TestID: "login", TestName: "login", PassRate: 0.7, FailureRate: 0.3, LastFailure: &lastFailure, RunCount: 10},
+		{TestID: "logout", TestName: "logout", PassRate: 1, RunCount: 4},
+	}
+
+	It("should write JSON", func() {
+		var buf bytes.Buffer
+		Expect(report.WriteFlakyTests(&buf, tests, report.FormatJSON)).To(Succeed())
+		Expect(buf.String()).To(MatchJSON(`[
+			{"testID":"login","testName":"login","passRate":0.7,"failureRate":0.3,"flakinessScore":0,"lastFailure":"2025-03-30T18:44:10Z","runCount":10,"isStale":false},
+			{"testID":"logout","testName":"logout","passRate":1,"failureRate":0,"flakinessScore":0,"runCount":4,"isStale":false}
+		]`))
+	})
+
+	It("should write an empty JSON array when there are no results", func() {
+		var buf bytes.Buffer
+		Expect(report.WriteFlakyTests(&buf, nil, report.FormatJSON)).To(Succeed())
+		Expect(buf.String()).To(MatchJSON(`[]`))
+	})
+
+	It("should write a table", func() {
+		var buf bytes.Buffer
+		Expect(report.WriteFlakyTests(&buf, tests, report.FormatTable)).To(Succeed())
+		Expect(buf.String()).To(Equal("" +
+			"TEST    RUNS  FAILURE RATE  PASS RATE  LAST FAILURE\n" +
+			"login   10    30.0%         70.0%      2025-03-30T18:44:10Z\n" +
+			"logout  4     0.0%          100.0%     -\n"))
+	})
+
+	It("should reject unknown formats", func() {
+		var buf bytes.Buffer
+		Expect(report.WriteFlakyTests(&buf, tests, "yaml")).To(MatchError(ContainSubstring(`unknown format "yaml"`)))
+	})
+})
@@ -0,0 +1,58 @@
+// Package score condenses a project's test statistics into a single health
+// score for dashboards.
+package score
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultSlowTestThreshold is the average duration above which a spec counts
+// as slow.
+const DefaultSlowTestThreshold = 10 * time.Second
+
+// Inputs are the statistics of a project's specs over a window.
+type Inputs struct {
+	// TestCount is how many specs ran.
+	TestCount int
+	// FlakyTestCount is how many specs both passed and failed.
+	FlakyTestCount int
+	// AverageFailureRate is the mean of the specs' failure rates, in [0, 1].
+	AverageFailureRate float64
+	// SlowTestCount is how many specs took longer than the slow threshold
+	// on average.
+	SlowTestCount int
+}
+
+// Weights are the most points each signal can take off a perfect score of
+// 100. They should add up to 100, so the worst project scores zero.
+type Weights struct {
+	Flakiness   float64
+	FailureRate float64
+	Slowness    float64
+}
+
+// DefaultWeights favour failures and flakiness, which block merges, over
+// slowness, which only delays them.
+var DefaultWeights = Weights{Flakiness: 40, FailureRate: 45, Slowness: 15}
+
+// Compute returns a score from 0 (worst) to 100 (healthiest), rounded to
+// one decimal. Flakiness and slowness are penalised by the share of specs
+// they affect, failures by the average failure rate. A project without
+// specs has nothing wrong with it and scores 100.
+func Compute(in Inputs, w Weights) float64 {
+	if in.TestCount <= 0 {
+		return 100
+	}
+	total := float64(in.TestCount)
+	penalty := w.Flakiness*share(float64(in.FlakyTestCount)/total) +
+		w.FailureRate*share(in.AverageFailureRate) +
+		w.Slowness*share(float64(in.SlowTestCount)/total)
+	return math.Round(math.Max(0, 100-penalty)*10) / 10
+}
+
+// share clamps a ratio to [0, 1] so inconsistent inputs cannot push the
+// score out of range.
+func share(ratio float64) float64 {
+	return math.Min(1, math.Max(0, ratio))
+}
@@ -0,0 +1,13 @@
+package score_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+)
+
+func TestScore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Score Suite")
+}
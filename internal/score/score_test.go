@@ -0,0 +1,43 @@
+package score_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/score"
+)
+
+var _ = Describe("Compute", func() {
+	It("should score a healthy project near 100", func() {
+		in := score.Inputs{TestCount: 200, FlakyTestCount: 2, AverageFailureRate: 0.01, SlowTestCount: 4}
+
+		// 100 - 40*0.01 - 45*0.01 - 15*0.02
+		Expect(score.Compute(in, score.DefaultWeights)).To(Equal(98.9))
+	})
+
+	It("should score a degraded project low", func() {
+		in := score.Inputs{TestCount: 10, FlakyTestCount: 6, AverageFailureRate: 0.5, SlowTestCount: 5}
+
+		// 100 - 40*0.6 - 45*0.5 - 15*0.5
+		Expect(score.Compute(in, score.DefaultWeights)).To(Equal(46.0))
+	})
+
+	It("should take each weight off in full at the worst", func() {
+		in := score.Inputs{TestCount: 4, FlakyTestCount: 4, AverageFailureRate: 1, SlowTestCount: 4}
+
+		Expect(score.Compute(in, score.DefaultWeights)).To(BeZero())
+		Expect(score.Compute(in, score.Weights{Flakiness: 10})).To(Equal(90.0))
+		Expect(score.Compute(in, score.Weights{FailureRate: 30})).To(Equal(70.0))
+		Expect(score.Compute(in, score.Weights{Slowness: 5})).To(Equal(95.0))
+	})
+
+	It("should keep the score within range for inconsistent inputs", func() {
+		in := score.Inputs{TestCount: 1, FlakyTestCount: 3, AverageFailureRate: 2, SlowTestCount: 3}
+		Expect(score.Compute(in, score.DefaultWeights)).To(BeZero())
+		Expect(score.Compute(in, score.Weights{Flakiness: 150})).To(BeZero())
+	})
+
+	It("should score a project without specs 100", func() {
+		Expect(score.Compute(score.Inputs{}, score.DefaultWeights)).To(Equal(100.0))
+	})
+})
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// redactedHeaders are logged as "[REDACTED]" so credentials never reach the
+// access log.
+var redactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization"}
+
+// AccessLog logs one line per request with its GraphQL operation name,
+// status, duration and client. Request headers are added when logger is
+// enabled for debug, with credentials redacted. With logDBTime the number
+// and total duration of the repository queries it issued are included too.
+// It must run before APIKeyAuth so rejected requests are logged as well.
+func AccessLog(logger *slog.Logger, logDBTime bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		ctx := c.Request.Context()
+		entry := &accessLogEntry{}
+		ctx = context.WithValue(ctx, accessLogKey{}, entry)
+		var stats *repo.QueryStats
+		if logDBTime {
+			ctx, stats = repo.WithQueryStats(ctx)
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		operation, errorCount := entry.result()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("operation", operation),
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("client", clientLabel(c)),
+			slog.Int("errors", errorCount),
+		}
+		if stats != nil {
+			count, total := stats.Totals()
+			attrs = append(attrs, slog.Int("dbQueries", count), slog.Duration("dbDuration", total))
+		}
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			attrs = append(attrs, headerAttrs(c.Request.Header))
+		}
+		logger.LogAttrs(ctx, slog.LevelInfo, "graphql request", attrs...)
+	}
+}
+
+// clientLabel identifies the caller without logging its API key: clients
+// that authenticated are shown by a short hash of their key, others by IP.
+func clientLabel(c *gin.Context) string {
+	if key := c.GetString(apiKeyContextKey); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:4])
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// headerAttrs groups the request headers, redacting credentials.
+func headerAttrs(header http.Header) slog.Attr {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	attrs := make([]any, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(header.Values(name), ", ")
+		if slices.Contains(redactedHeaders, http.CanonicalHeaderKey(name)) {
+			value = "[REDACTED]"
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.Group("headers", attrs...)
+}
+
+type accessLogKey struct{}
+
+// accessLogEntry collects what the GraphQL handler learns about a request
+// for AccessLog to report once it completes.
+type accessLogEntry struct {
+	mu         sync.Mutex
+	operation  string
+	errorCount int
+}
+
+func (e *accessLogEntry) record(operation string, errorCount int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if operation != "" {
+		e.operation = operation
+	}
+	e.errorCount += errorCount
+}
+
+func (e *accessLogEntry) result() (string, int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.operation, e.errorCount
+}
+
+// accessLogExtension feeds the operation name and error count of each
+// response into the request's access log entry.
+type accessLogExtension struct{}
+
+func (accessLogExtension) ExtensionName() string {
+	return "AccessLog"
+}
+
+func (accessLogExtension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (accessLogExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	entry, ok := ctx.Value(accessLogKey{}).(*accessLogEntry)
+	if !ok {
+		return resp
+	}
+
+	var operation string
+	if graphql.HasOperationContext(ctx) {
+		operation = graphql.GetOperationContext(ctx).OperationName
+	}
+	var errorCount int
+	if resp != nil {
+		errorCount = len(resp.Errors)
+	}
+	entry.record(operation, errorCount)
+	return resp
+}
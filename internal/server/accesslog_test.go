@@ -0,0 +1,134 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("AccessLog", func() {
+	var (
+		logs      *bytes.Buffer
+		fakeFlaky *fakes.FakeFlakyTestProvider
+	)
+
+	// useLogger routes the default logger, which NewRouter logs to, into logs.
+	useLogger := func(level slog.Level) {
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: level})))
+		DeferCleanup(func() { slog.SetDefault(previous) })
+	}
+
+	query := func(cfg server.Config, authorization string) map[string]any {
+		router := mustNewRouter(cfg, &resolvers.Resolver{FlakyRepo: fakeFlaky},
+			metrics.New(prometheus.NewRegistry()), nil)
+
+		body := `{"operationName":"Flaky","query":"query Flaky { flakyTests(limit: 5, projectID: \"demo\") { testName } }"}`
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "10.0.0.7:4321"
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		var entry map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var record map[string]any
+			Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			if record["msg"] == "graphql request" {
+				entry = record
+			}
+		}
+		Expect(entry).ToNot(BeNil(), "no access log line in:\n%s", logs.String())
+		return entry
+	}
+
+	BeforeEach(func() {
+		logs = &bytes.Buffer{}
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{{TestName: "login"}}, nil)
+	})
+
+	It("should log the operation, status, duration and client of a request", func() {
+		useLogger(slog.LevelInfo)
+
+		entry := query(server.Config{}, "")
+		Expect(entry).To(HaveKeyWithValue("level", "INFO"))
+		Expect(entry).To(HaveKeyWithValue("method", "POST"))
+		Expect(entry).To(HaveKeyWithValue("path", "/query"))
+		Expect(entry).To(HaveKeyWithValue("operation", "Flaky"))
+		Expect(entry).To(HaveKeyWithValue("status", BeNumerically("==", http.StatusOK)))
+		Expect(entry).To(HaveKeyWithValue("client", "ip:10.0.0.7"))
+		Expect(entry).To(HaveKeyWithValue("errors", BeNumerically("==", 0)))
+		Expect(entry).To(HaveKey("duration"))
+		Expect(entry).ToNot(HaveKey("headers"))
+		Expect(entry).ToNot(HaveKey("dbQueries"))
+	})
+
+	It("should include headers at debug level without leaking the API key", func() {
+		useLogger(slog.LevelDebug)
+
+		entry := query(server.Config{APIKeys: []string{"s3cret-key"}}, "Bearer s3cret-key")
+		Expect(entry).To(HaveKeyWithValue("headers", HaveKeyWithValue("Authorization", "[REDACTED]")))
+		Expect(entry).To(HaveKeyWithValue("headers", HaveKeyWithValue("Content-Type", "application/json")))
+		Expect(entry["client"]).To(HavePrefix("key:"))
+		Expect(logs.String()).ToNot(ContainSubstring("s3cret-key"))
+	})
+
+	It("should log requests rejected by authentication", func() {
+		useLogger(slog.LevelInfo)
+
+		entry := query(server.Config{APIKeys: []string{"s3cret-key"}}, "Bearer wrong")
+		Expect(entry).To(HaveKeyWithValue("status", BeNumerically("==", http.StatusUnauthorized)))
+		Expect(entry).To(HaveKeyWithValue("client", "ip:10.0.0.7"))
+	})
+
+	It("should leave /query out of gin's request log, which covers the other routes", func() {
+		useLogger(slog.LevelInfo)
+		requests := &bytes.Buffer{}
+		previous := gin.DefaultWriter
+		gin.DefaultWriter = requests
+		DeferCleanup(func() { gin.DefaultWriter = previous })
+
+		query(server.Config{}, "")
+		Expect(requests.String()).To(BeEmpty())
+
+		router := mustNewRouter(server.Config{}, &resolvers.Resolver{}, metrics.New(prometheus.NewRegistry()), nil)
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/livez", nil))
+		Expect(requests.String()).To(ContainSubstring(`"/livez"`))
+	})
+
+	It("should report the database time recorded by the repository when enabled", func() {
+		useLogger(slog.LevelInfo)
+		fakeDB := &fakes.FakePgxQuerier{}
+		fakeDB.QueryReturns(nil, errors.New("boom"))
+		router := gin.New()
+		router.POST("/query", server.AccessLog(slog.Default(), true), func(c *gin.Context) {
+			_, _ = repo.NewFlakyTestRepo(fakeDB).GetFlakyTests(c.Request.Context(), "demo", 5, repo.FlakyTestOptions{})
+			c.Status(http.StatusOK)
+		})
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/query", nil))
+
+		var entry map[string]any
+		Expect(json.Unmarshal(logs.Bytes(), &entry)).To(Succeed())
+		Expect(entry).To(HaveKeyWithValue("dbQueries", BeNumerically("==", 1)))
+		Expect(entry).To(HaveKey("dbDuration"))
+	})
+})
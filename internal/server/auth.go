@@ -0,0 +1,69 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// apiKeyContextKey is the gin context key under which APIKeyAuth stores the
+// accepted key, letting later middleware tell clients apart.
+const apiKeyContextKey = "mycelium.apiKey"
+
+// APIKeyAuth rejects requests whose Authorization header does not carry one
+// of keys as a bearer token. With no keys configured every request passes.
+// Accepted requests act as the key's keyActor, which quarantines record.
+func APIKeyAuth(keys []string) gin.HandlerFunc {
+	if len(keys) == 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+		if !validKey(keys, token) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
+			return
+		}
+		c.Set(apiKeyContextKey, token)
+		c.Request = c.Request.WithContext(repo.WithActor(c.Request.Context(), keyActor(token)))
+		c.Next()
+	}
+}
+
+// keyActor names the client holding key by a fingerprint of it, so the key
+// itself is never stored.
+func keyActor(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "api-key:" + hex.EncodeToString(sum[:4])
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header.
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// validKey compares token against every key in constant time.
+func validKey(keys []string, token string) bool {
+	valid := false
+	for _, key := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
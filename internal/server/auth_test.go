@@ -0,0 +1,63 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+var _ = Describe("APIKeyAuth", func() {
+	request := func(keys []string, authorization string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.POST("/query", server.APIKeyAuth(keys), func(c *gin.Context) {
+			c.Header("X-Actor", repo.ActorFromContext(c.Request.Context()))
+			c.String(http.StatusOK, "ok")
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should allow every request when no keys are configured", func() {
+		Expect(request(nil, "").Code).To(Equal(http.StatusOK))
+	})
+
+	It("should accept a configured key", func() {
+		rec := request([]string{"alpha", "beta"}, "Bearer beta")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal("ok"))
+	})
+
+	It("should act as a fingerprint of the accepted key", func() {
+		alpha := request([]string{"alpha", "beta"}, "Bearer alpha").Header().Get("X-Actor")
+		Expect(alpha).To(MatchRegexp(`^api-key:[0-9a-f]{8}$`))
+		Expect(alpha).NotTo(ContainSubstring("alpha"))
+		Expect(request([]string{"alpha", "beta"}, "Bearer beta").Header().Get("X-Actor")).NotTo(Equal(alpha))
+		Expect(request(nil, "").Header().Get("X-Actor")).To(BeEmpty())
+	})
+
+	It("should reject an unknown key", func() {
+		rec := request([]string{"alpha"}, "Bearer gamma")
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		Expect(rec.Body.String()).To(MatchJSON(`{"error":"invalid API key"}`))
+	})
+
+	It("should reject a request without a bearer token", func() {
+		rec := request([]string{"alpha"}, "")
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		Expect(rec.Body.String()).To(MatchJSON(`{"error":"missing bearer token"}`))
+
+		Expect(request([]string{"alpha"}, "Basic alpha").Code).To(Equal(http.StatusUnauthorized))
+	})
+})
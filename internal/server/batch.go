@@ -0,0 +1,126 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// MaxBatchOperations caps how many operations a single batched request may
+// carry, so one request cannot queue unbounded work.
+const MaxBatchOperations = 20
+
+// MaxBatchBytes caps the body of a batched request.
+const MaxBatchBytes = 1 << 20
+
+// batchPeekBytes bounds the leading whitespace Supports skips looking for
+// the '[' opening a batch.
+const batchPeekBytes = 4 << 10
+
+// batchTransport serves a POST whose JSON body is an array of operations,
+// answering with an array of results in the same order. Each operation runs
+// on its own, so one failing operation does not affect the others. It must
+// be added before transport.POST, which would reject the array.
+type batchTransport struct{}
+
+var _ graphql.Transport = batchTransport{}
+
+// Supports reports whether r is a JSON POST whose body is an array. Only
+// the start of the body is read to find out, and it stays readable for the
+// transport serving r.
+func (batchTransport) Supports(r *http.Request) bool {
+	if r.Method != http.MethodPost || r.Header.Get("Upgrade") != "" || r.Body == nil {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return false
+	}
+
+	body := bufio.NewReaderSize(r.Body, batchPeekBytes)
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{body, r.Body}
+	for n := 1; n <= batchPeekBytes; n++ {
+		peeked, err := body.Peek(n)
+		if err != nil {
+			return false
+		}
+		switch peeked[n-1] {
+		case ' ', '\t', '\r', '\n':
+		default:
+			return peeked[n-1] == '['
+		}
+	}
+	return false
+}
+
+func (batchTransport) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "application/json")
+
+	var batch []*graphql.RawParams
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBatchBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&batch); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		writeBatch(w, exec.DispatchError(ctx, gqlerror.List{gqlerror.Errorf("json request body could not be decoded: %v", err)}))
+		return
+	}
+	if len(batch) == 0 || len(batch) > MaxBatchOperations {
+		w.WriteHeader(http.StatusBadRequest)
+		writeBatch(w, exec.DispatchError(ctx, gqlerror.List{
+			gqlerror.Errorf("a batch must hold between 1 and %d operations, got %d", MaxBatchOperations, len(batch)),
+		}))
+		return
+	}
+	// RateLimit charged the request one token; each further operation costs
+	// another, so a batch does not multiply a client's rate.
+	if delay := chargeRateLimit(r, len(batch)-1); delay > 0 {
+		writeRateLimited(w, delay)
+		return
+	}
+
+	results := make([]*graphql.Response, len(batch))
+	for i, params := range batch {
+		results[i] = runOperation(r, exec, params)
+	}
+	writeBatch(w, results)
+}
+
+// runOperation executes one operation of a batch and returns its response,
+// which carries the operation's errors rather than failing the batch.
+func runOperation(r *http.Request, exec graphql.GraphExecutor, params *graphql.RawParams) *graphql.Response {
+	ctx := r.Context()
+	if params == nil {
+		return exec.DispatchError(ctx, gqlerror.List{gqlerror.Errorf("batch entry must be an operation object")})
+	}
+	params.Headers = r.Header
+	now := graphql.Now()
+	params.ReadTime = graphql.TraceTiming{Start: now, End: now}
+
+	rc, errs := exec.CreateOperationContext(ctx, params)
+	if errs != nil {
+		return exec.DispatchError(graphql.WithOperationContext(ctx, rc), errs)
+	}
+	responses, ctx := exec.DispatchOperation(ctx, rc)
+	return responses(ctx)
+}
+
+// writeBatch encodes v as the response body. An encoding error means the
+// client went away, so there is no one left to tell.
+func writeBatch(w io.Writer, v any) {
+	_ = json.NewEncoder(w).Encode(v)
+}
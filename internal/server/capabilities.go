@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/capabilities"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// newRegistry lists the GraphQL queries and the MCP tools served for flaky.
+func newRegistry(flaky repo.FlakyTestProvider) *capabilities.Registry {
+	schema := gql.NewExecutableSchema(gql.Config{}).Schema()
+	return capabilities.NewRegistry(schema, mcp.NewServer(flaky).Tools())
+}
+
+// capabilitiesHandler serves GET /capabilities, the same list as the
+// capabilities GraphQL query, for agents that do not speak GraphQL.
+func capabilitiesHandler(registry *capabilities.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"capabilities": registry.List()})
+	}
+}
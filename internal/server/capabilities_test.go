@@ -0,0 +1,84 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/capabilities"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("GET /capabilities", func() {
+	var resolver *resolvers.Resolver
+
+	BeforeEach(func() {
+		fakeFlaky := &fakes.FakeFlakyTestProvider{}
+		resolver = &resolvers.Resolver{
+			FlakyRepo: fakeFlaky,
+			Registry:  capabilities.NewRegistry(gql.NewExecutableSchema(gql.Config{}).Schema(), mcp.NewServer(fakeFlaky).Tools()),
+		}
+	})
+
+	get := func(cfg server.Config, header string) *httptest.ResponseRecorder {
+		router := mustNewRouter(cfg, resolver, metrics.New(prometheus.NewRegistry()), nil)
+		req := httptest.NewRequest(http.MethodGet, "/capabilities", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should list the flaky test query and tool with their arguments", func() {
+		rec := get(server.Config{}, "")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		var body struct {
+			Capabilities []struct {
+				Name      string `json:"name"`
+				Kind      string `json:"kind"`
+				Arguments []struct {
+					Name     string `json:"name"`
+					Required bool   `json:"required"`
+				} `json:"arguments"`
+			} `json:"capabilities"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.Capabilities).To(ContainElement(SatisfyAll(
+			HaveField("Name", "flakyTests"),
+			HaveField("Kind", "QUERY"),
+			HaveField("Arguments", ContainElement(HaveField("Name", "projectID"))),
+		)))
+		Expect(body.Capabilities).To(ContainElement(SatisfyAll(
+			HaveField("Name", "detect_flaky_tests"),
+			HaveField("Kind", "TOOL"),
+			HaveField("Arguments", ContainElement(SatisfyAll(HaveField("Name", "projectID"), HaveField("Required", true)))),
+		)))
+	})
+
+	It("should match the capabilities GraphQL query", func() {
+		list, err := resolver.Query().Capabilities(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		want, err := json.Marshal(map[string]any{"capabilities": list})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(get(server.Config{}, "").Body.String()).To(MatchJSON(want))
+	})
+
+	It("should require an API key when keys are configured", func() {
+		cfg := server.Config{APIKeys: []string{"secret"}}
+		Expect(get(cfg, "").Code).To(Equal(http.StatusUnauthorized))
+		Expect(get(cfg, "Bearer secret").Code).To(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,549 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/notify"
+	"github.com/guidewire-oss/fern-mycelium/internal/storage"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+const (
+	// defaultAddr is used when neither LISTEN_ADDR nor PORT is set.
+	defaultAddr = ":8080"
+	// defaultComplexityLimit caps the GraphQL complexity of a single operation.
+	defaultComplexityLimit = 200
+	// defaultOperationTimeout bounds a single GraphQL query or mutation. It
+	// leaves room for the several database queries an operation can issue.
+	defaultOperationTimeout = 60 * time.Second
+	// defaultQueryCacheSize is the number of parsed queries kept in memory.
+	defaultQueryCacheSize = 1000
+	// defaultPlaygroundPath is where the playground is served unless
+	// GRAPHQL_PLAYGROUND_PATH moves it.
+	defaultPlaygroundPath = "/graphql"
+)
+
+var (
+	defaultCORSMethods = []string{"GET", "POST", "OPTIONS"}
+	defaultCORSHeaders = []string{"Authorization", "Content-Type"}
+)
+
+// Config holds the server settings resolved from the environment and CLI flags.
+type Config struct {
+	// Addr is the host:port the HTTP server listens on.
+	Addr string
+	// Migrate applies pending schema migrations before serving. It is only
+	// set by the serve command's --migrate flag.
+	Migrate bool
+	// MockData serves sample flaky tests without connecting to a database,
+	// for offline demos. Queries needing the database fail with
+	// UNIMPLEMENTED.
+	MockData bool
+	// RequireDB refuses to start unless DB_URL answers a ping, checked once
+	// before anything else and even with MockData, instead of retrying.
+	RequireDB bool
+	// APIKeys are the bearer tokens accepted on /query. Authentication is
+	// disabled when empty.
+	APIKeys []string
+	// CORSOrigins are the origins allowed to make cross-origin requests.
+	// Cross-origin requests are not allowed when empty.
+	CORSOrigins []string
+	// CORSMethods and CORSHeaders are sent in preflight responses.
+	CORSMethods []string
+	CORSHeaders []string
+	// ComplexityLimit rejects GraphQL operations whose complexity exceeds it.
+	// Zero disables the limit.
+	ComplexityLimit int
+	// OperationTimeout bounds each GraphQL query and mutation; resolvers
+	// still running when it passes fail with DEADLINE_EXCEEDED. Zero
+	// disables it.
+	OperationTimeout time.Duration
+	// MaxLimit is the largest flakyTests limit accepted, at most
+	// repo.MaxLimit.
+	MaxLimit int
+	// DefaultLimit is the flakyTests limit used when a query omits it, at
+	// most MaxLimit.
+	DefaultLimit int
+	// QueryCacheSize is the capacity of the parsed and persisted query LRU
+	// caches. Zero disables caching.
+	QueryCacheSize int
+	// Introspection allows __schema and __type queries. Disable it in
+	// production to avoid exposing the schema.
+	Introspection bool
+	// PlaygroundPath is where the GraphQL playground is served. The
+	// playground is disabled when empty.
+	PlaygroundPath string
+	// PlaygroundAuth requires one of APIKeys to open the playground, as on
+	// /query.
+	PlaygroundAuth bool
+	// GETQueries serves query operations sent as GET /query?query=...
+	// alongside POST. Responses to GET may be cached by browsers and proxies.
+	GETQueries bool
+	// StorageBackend is where flaky test statistics are read from:
+	// storage.Postgres (the default) or storage.SQLite. The SQLite backend
+	// never connects to Postgres, so it only serves flaky test queries and
+	// codes the others UNIMPLEMENTED.
+	StorageBackend string
+	// SQLitePath is the SQLite database file or DSN for storage.SQLite.
+	SQLitePath string
+	// HideInternalErrors replaces the message of GraphQL errors that are not
+	// the caller's fault with a generic one. Enable it in production so
+	// database errors are not shown to clients.
+	HideInternalErrors bool
+	// RecoverFunc handles panics raised by GraphQL resolvers. It is not read
+	// from the environment; nil uses RecoverPanic.
+	RecoverFunc graphql.RecoverFunc
+	// TLSCertFile and TLSKeyFile, when both set, serve HTTPS with that
+	// certificate instead of plain HTTP.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSMinVersion is the lowest TLS version accepted, as a tls.Version*
+	// constant.
+	TLSMinVersion uint16
+	// QueryTimeout bounds each flaky-test database query. Zero disables it.
+	QueryTimeout time.Duration
+	// QueryLogging logs every flaky-test database query at debug level, and
+	// those slower than SlowQueryThreshold as warnings.
+	QueryLogging       bool
+	SlowQueryThreshold time.Duration
+	// FlakyCacheTTL reuses flakyTests results for identical requests for
+	// this long. Zero disables the cache.
+	FlakyCacheTTL time.Duration
+	// FlakyStaleWindow keeps serving the last flakyTests results of a
+	// request for this long past their TTL when the database fails, marked
+	// isStale. Zero disables it.
+	FlakyStaleWindow time.Duration
+	// FlakyTestNamePattern is removed from spec names when a flakyTests
+	// query asks for normalizeNames.
+	FlakyTestNamePattern string
+	// OwnersFile is a CODEOWNERS-style file assigning specs and suites to
+	// teams ahead of their project's team; see repo.Owners. Empty uses the
+	// project's team only.
+	OwnersFile string
+	// FlakyAlertInterval is how often each flakyTestAlerts subscriber's
+	// project is polled.
+	FlakyAlertInterval time.Duration
+	// FlakyAlertThreshold is the failure rate at which a test raises an alert.
+	FlakyAlertThreshold float64
+	// RateLimitRPS is the average number of /query requests per second
+	// allowed for each client. Zero disables rate limiting.
+	RateLimitRPS float64
+	// RateLimitBurst is how many requests a client may make at once. Zero
+	// defaults to RateLimitRPS rounded up.
+	RateLimitBurst int
+	// TrustedProxies are the IPs and CIDRs of the reverse proxies whose
+	// X-Forwarded-For header gives the client IP used for rate limiting and
+	// access logs. No proxy is trusted when empty, so the peer address is
+	// used and clients cannot pick their own rate limit bucket.
+	TrustedProxies []string
+	// AccessLogDBTime adds the number and total duration of the database
+	// queries behind each /query request to the access log.
+	AccessLogDBTime bool
+	// FlakyMetricsTTL is how long the flakiness gauges on /metrics are
+	// reused across scrapes.
+	FlakyMetricsTTL time.Duration
+	// FlakyMetricsMaxSeries caps the per-test flakiness gauges on /metrics.
+	// Zero disables the flakiness gauges.
+	FlakyMetricsMaxSeries int
+	// SlackWebhookURL is the Slack incoming webhook that is told about newly
+	// flaky tests. No notifications are sent when empty.
+	SlackWebhookURL string
+	// GitHubIssues files an issue in GitHubRepository for each test that
+	// becomes high risk.
+	GitHubIssues bool
+	// GitHubRepository is the owner/name repository issues are filed in.
+	GitHubRepository string
+	// GitHubToken is a personal access token allowed to write its issues.
+	GitHubToken string
+	// GitHubAPIURL overrides the GitHub REST API, e.g. for GitHub Enterprise.
+	GitHubAPIURL string
+	// WebhookURLs receive a JSON POST for each test that reaches
+	// WebhookThreshold. No webhooks are sent when empty.
+	WebhookURLs []string
+	// WebhookSecret, when set, signs each webhook body with HMAC-SHA256.
+	WebhookSecret string
+	// WebhookThreshold is the failure rate at which webhooks are sent.
+	WebhookThreshold float64
+	// WebhookTimeout bounds each webhook delivery attempt.
+	WebhookTimeout time.Duration
+	// NotifyInterval is how often projects are scanned for tests to notify
+	// Slack, GitHub or webhooks about.
+	NotifyInterval time.Duration
+}
+
+// LoadConfig reads the server settings from the environment.
+func LoadConfig() (Config, error) {
+	complexityLimit, err := envInt("GRAPHQL_COMPLEXITY_LIMIT", defaultComplexityLimit)
+	if err != nil {
+		return Config{}, err
+	}
+	operationTimeout, err := envDuration("GRAPHQL_OPERATION_TIMEOUT", defaultOperationTimeout)
+	if err != nil {
+		return Config{}, err
+	}
+	maxLimit, err := envInt("GRAPHQL_MAX_LIMIT", resolvers.DefaultMaxLimit)
+	if err != nil {
+		return Config{}, err
+	}
+	if maxLimit < 1 || maxLimit > repo.MaxLimit {
+		return Config{}, fmt.Errorf("invalid GRAPHQL_MAX_LIMIT %d: must be between 1 and %d", maxLimit, repo.MaxLimit)
+	}
+	defaultLimit, err := envInt("GRAPHQL_DEFAULT_LIMIT", min(resolvers.DefaultLimit, maxLimit))
+	if err != nil {
+		return Config{}, err
+	}
+	if defaultLimit < 1 || defaultLimit > maxLimit {
+		return Config{}, fmt.Errorf("invalid GRAPHQL_DEFAULT_LIMIT %d: must be between 1 and GRAPHQL_MAX_LIMIT (%d)", defaultLimit, maxLimit)
+	}
+	queryCacheSize, err := envInt("GRAPHQL_QUERY_CACHE_SIZE", defaultQueryCacheSize)
+	if err != nil {
+		return Config{}, err
+	}
+	introspection, err := envBool("GRAPHQL_INTROSPECTION", true)
+	if err != nil {
+		return Config{}, err
+	}
+	playgroundEnabled, err := envBool("GRAPHQL_PLAYGROUND_ENABLED", true)
+	if err != nil {
+		return Config{}, err
+	}
+	playgroundPath := ""
+	if playgroundEnabled {
+		playgroundPath = os.Getenv("GRAPHQL_PLAYGROUND_PATH")
+		if playgroundPath == "" {
+			playgroundPath = defaultPlaygroundPath
+		}
+		if !strings.HasPrefix(playgroundPath, "/") {
+			return Config{}, fmt.Errorf("invalid GRAPHQL_PLAYGROUND_PATH %q: must start with /", playgroundPath)
+		}
+	}
+	playgroundAuth, err := envBool("GRAPHQL_PLAYGROUND_AUTH", false)
+	if err != nil {
+		return Config{}, err
+	}
+	getQueries, err := envBool("GRAPHQL_GET_ENABLED", false)
+	if err != nil {
+		return Config{}, err
+	}
+	hideInternalErrors, err := envBool("GRAPHQL_HIDE_INTERNAL_ERRORS", false)
+	if err != nil {
+		return Config{}, err
+	}
+	storageBackend, err := storage.ParseBackend(os.Getenv("STORAGE_BACKEND"))
+	if err != nil {
+		return Config{}, err
+	}
+	sqlitePath := os.Getenv("SQLITE_PATH")
+	if storageBackend == storage.SQLite && sqlitePath == "" {
+		return Config{}, errors.New("STORAGE_BACKEND=sqlite requires SQLITE_PATH")
+	}
+	queryTimeout, err := envDuration("DB_QUERY_TIMEOUT", repo.DefaultQueryTimeout)
+	if err != nil {
+		return Config{}, err
+	}
+	tlsCertFile, tlsKeyFile := os.Getenv("TLS_CERT_FILE"), os.Getenv("TLS_KEY_FILE")
+	if (tlsCertFile == "") != (tlsKeyFile == "") {
+		return Config{}, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	tlsMinVersion, err := envTLSVersion("TLS_MIN_VERSION", tls.VersionTLS12)
+	if err != nil {
+		return Config{}, err
+	}
+	mockData, err := envBool("MOCK_DATA", false)
+	if err != nil {
+		return Config{}, err
+	}
+	requireDB, err := envBool("REQUIRE_DB", false)
+	if err != nil {
+		return Config{}, err
+	}
+	queryLogging, err := envBool("DB_QUERY_LOGGING", false)
+	if err != nil {
+		return Config{}, err
+	}
+	slowQueryThreshold, err := envDuration("DB_SLOW_QUERY_THRESHOLD", repo.DefaultSlowQueryThreshold)
+	if err != nil {
+		return Config{}, err
+	}
+	flakyCacheTTL, err := envDuration("FLAKY_CACHE_TTL", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	flakyStaleWindow, err := envDuration("FLAKY_CACHE_STALE_WINDOW", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	flakyTestNamePattern := repo.DefaultNamePattern
+	if raw := os.Getenv("FLAKY_TEST_NAME_PATTERN"); raw != "" {
+		if _, err := regexp.Compile(raw); err != nil {
+			return Config{}, fmt.Errorf("invalid FLAKY_TEST_NAME_PATTERN %q: %w", raw, err)
+		}
+		flakyTestNamePattern = raw
+	}
+	flakyAlertInterval, err := envDuration("FLAKY_ALERT_INTERVAL", resolvers.DefaultAlertInterval)
+	if err != nil {
+		return Config{}, err
+	}
+	flakyAlertThreshold, err := envRatio("FLAKY_ALERT_THRESHOLD", resolvers.DefaultAlertThreshold)
+	if err != nil {
+		return Config{}, err
+	}
+	rateLimitRPS, err := envFloat("RATE_LIMIT_RPS", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	rateLimitBurst, err := envInt("RATE_LIMIT_BURST", 0)
+	if err != nil {
+		return Config{}, err
+	}
+	trustedProxies := splitList(os.Getenv("TRUSTED_PROXIES"))
+	for _, proxy := range trustedProxies {
+		if !isIPOrCIDR(proxy) {
+			return Config{}, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: must be an IP address or CIDR", proxy)
+		}
+	}
+	accessLogDBTime, err := envBool("ACCESS_LOG_DB_TIME", false)
+	if err != nil {
+		return Config{}, err
+	}
+	flakyMetricsTTL, err := envDuration("FLAKY_METRICS_TTL", metrics.DefaultFlakinessTTL)
+	if err != nil {
+		return Config{}, err
+	}
+	flakyMetricsMaxSeries, err := envInt("FLAKY_METRICS_MAX_SERIES", metrics.DefaultFlakinessMaxSeries)
+	if err != nil {
+		return Config{}, err
+	}
+	slackWebhookURL, err := envURL("SLACK_WEBHOOK_URL")
+	if err != nil {
+		return Config{}, err
+	}
+	githubIssues, err := envBool("GITHUB_ISSUES_ENABLED", false)
+	if err != nil {
+		return Config{}, err
+	}
+	githubRepository, githubToken := os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_TOKEN")
+	if githubIssues && (githubRepository == "" || githubToken == "") {
+		return Config{}, errors.New("GITHUB_ISSUES_ENABLED requires GITHUB_REPOSITORY and GITHUB_TOKEN")
+	}
+	githubAPIURL, err := envURL("GITHUB_API_URL")
+	if err != nil {
+		return Config{}, err
+	}
+	webhookURLs := splitList(os.Getenv("WEBHOOK_URLS"))
+	for _, raw := range webhookURLs {
+		if !isHTTPURL(raw) {
+			return Config{}, errors.New("invalid WEBHOOK_URLS: each must be an http or https URL")
+		}
+	}
+	webhookThreshold, err := envRatio("WEBHOOK_THRESHOLD", resolvers.DefaultAlertThreshold)
+	if err != nil {
+		return Config{}, err
+	}
+	webhookTimeout, err := envDuration("WEBHOOK_TIMEOUT", notify.DefaultWebhookTimeout)
+	if err != nil {
+		return Config{}, err
+	}
+	notifyInterval, err := envDuration("NOTIFY_INTERVAL", notify.DefaultInterval)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{
+		Addr:                  resolveAddr(),
+		APIKeys:               splitList(os.Getenv("API_KEYS")),
+		CORSOrigins:           splitList(os.Getenv("CORS_ALLOWED_ORIGINS")),
+		CORSMethods:           listOrDefault(os.Getenv("CORS_ALLOWED_METHODS"), defaultCORSMethods),
+		CORSHeaders:           listOrDefault(os.Getenv("CORS_ALLOWED_HEADERS"), defaultCORSHeaders),
+		ComplexityLimit:       complexityLimit,
+		OperationTimeout:      operationTimeout,
+		MaxLimit:              maxLimit,
+		DefaultLimit:          defaultLimit,
+		QueryCacheSize:        queryCacheSize,
+		Introspection:         introspection,
+		PlaygroundPath:        playgroundPath,
+		PlaygroundAuth:        playgroundAuth,
+		GETQueries:            getQueries,
+		HideInternalErrors:    hideInternalErrors,
+		StorageBackend:        storageBackend,
+		SQLitePath:            sqlitePath,
+		QueryTimeout:          queryTimeout,
+		MockData:              mockData,
+		RequireDB:             requireDB,
+		TLSCertFile:           tlsCertFile,
+		TLSKeyFile:            tlsKeyFile,
+		TLSMinVersion:         tlsMinVersion,
+		QueryLogging:          queryLogging,
+		SlowQueryThreshold:    slowQueryThreshold,
+		FlakyCacheTTL:         flakyCacheTTL,
+		FlakyStaleWindow:      flakyStaleWindow,
+		FlakyTestNamePattern:  flakyTestNamePattern,
+		OwnersFile:            os.Getenv("TEST_OWNERS_FILE"),
+		FlakyAlertInterval:    flakyAlertInterval,
+		FlakyAlertThreshold:   flakyAlertThreshold,
+		RateLimitRPS:          rateLimitRPS,
+		RateLimitBurst:        rateLimitBurst,
+		TrustedProxies:        trustedProxies,
+		AccessLogDBTime:       accessLogDBTime,
+		FlakyMetricsTTL:       flakyMetricsTTL,
+		FlakyMetricsMaxSeries: flakyMetricsMaxSeries,
+		SlackWebhookURL:       slackWebhookURL,
+		GitHubIssues:          githubIssues,
+		GitHubRepository:      githubRepository,
+		GitHubToken:           githubToken,
+		GitHubAPIURL:          githubAPIURL,
+		WebhookURLs:           webhookURLs,
+		WebhookSecret:         os.Getenv("WEBHOOK_SECRET"),
+		WebhookThreshold:      webhookThreshold,
+		WebhookTimeout:        webhookTimeout,
+		NotifyInterval:        notifyInterval,
+	}, nil
+}
+
+// resolveAddr prefers LISTEN_ADDR, then PORT, then defaultAddr.
+func resolveAddr() string {
+	if addr := os.Getenv("LISTEN_ADDR"); addr != "" {
+		return addr
+	}
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+	return defaultAddr
+}
+
+// splitList splits a comma-separated value, dropping blank entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// listOrDefault splits value like splitList, falling back to def when empty.
+func listOrDefault(value string, def []string) []string {
+	if items := splitList(value); len(items) > 0 {
+		return items
+	}
+	return def
+}
+
+func envInt(key string, fallback int) (int, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative integer", key, raw)
+	}
+	return v, nil
+}
+
+func envBool(key string, fallback bool) (bool, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be a boolean", key, raw)
+	}
+	return v, nil
+}
+
+// tlsVersions maps TLS_MIN_VERSION values to tls.Version* constants.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+func envTLSVersion(key string, fallback uint16) (uint16, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, ok := tlsVersions[raw]
+	if !ok {
+		return 0, fmt.Errorf("invalid %s %q: must be 1.2 or 1.3", key, raw)
+	}
+	return v, nil
+}
+
+func envFloat(key string, fallback float64) (float64, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative number", key, raw)
+	}
+	return v, nil
+}
+
+func envRatio(key string, fallback float64) (float64, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(v) || v < 0 || v > 1 {
+		return 0, fmt.Errorf("invalid %s %q: must be a number between 0 and 1", key, raw)
+	}
+	return v, nil
+}
+
+func envURL(key string) (string, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return "", nil
+	}
+	if !isHTTPURL(raw) {
+		return "", fmt.Errorf("invalid %s: must be an http or https URL", key)
+	}
+	return raw, nil
+}
+
+// isIPOrCIDR reports whether raw is an IP address or a CIDR range.
+func isIPOrCIDR(raw string) bool {
+	if _, err := netip.ParsePrefix(raw); err == nil {
+		return true
+	}
+	_, err := netip.ParseAddr(raw)
+	return err == nil
+}
+
+// isHTTPURL reports whether raw is an absolute http or https URL.
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func envDuration(key string, fallback time.Duration) (time.Duration, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := time.ParseDuration(raw)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative duration", key, raw)
+	}
+	return v, nil
+}
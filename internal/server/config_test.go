@@ -0,0 +1,451 @@
+package server_test
+
+import (
+	"crypto/tls"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/notify"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/internal/storage"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+var _ = Describe("LoadConfig", func() {
+	loadConfig := func() server.Config {
+		cfg, err := server.LoadConfig()
+		Expect(err).ToNot(HaveOccurred())
+		return cfg
+	}
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("LISTEN_ADDR", "")
+		GinkgoT().Setenv("PORT", "")
+		GinkgoT().Setenv("API_KEYS", "")
+		GinkgoT().Setenv("CORS_ALLOWED_ORIGINS", "")
+		GinkgoT().Setenv("CORS_ALLOWED_METHODS", "")
+		GinkgoT().Setenv("CORS_ALLOWED_HEADERS", "")
+		GinkgoT().Setenv("GRAPHQL_COMPLEXITY_LIMIT", "")
+		GinkgoT().Setenv("GRAPHQL_OPERATION_TIMEOUT", "")
+		GinkgoT().Setenv("GRAPHQL_QUERY_CACHE_SIZE", "")
+		GinkgoT().Setenv("GRAPHQL_MAX_LIMIT", "")
+		GinkgoT().Setenv("GRAPHQL_DEFAULT_LIMIT", "")
+		GinkgoT().Setenv("GRAPHQL_INTROSPECTION", "")
+		GinkgoT().Setenv("GRAPHQL_GET_ENABLED", "")
+		GinkgoT().Setenv("GRAPHQL_PLAYGROUND_ENABLED", "")
+		GinkgoT().Setenv("GRAPHQL_PLAYGROUND_PATH", "")
+		GinkgoT().Setenv("GRAPHQL_PLAYGROUND_AUTH", "")
+		GinkgoT().Setenv("DB_QUERY_TIMEOUT", "")
+		GinkgoT().Setenv("DB_QUERY_LOGGING", "")
+		GinkgoT().Setenv("MOCK_DATA", "")
+		GinkgoT().Setenv("REQUIRE_DB", "")
+		GinkgoT().Setenv("TLS_CERT_FILE", "")
+		GinkgoT().Setenv("TLS_KEY_FILE", "")
+		GinkgoT().Setenv("TLS_MIN_VERSION", "")
+		GinkgoT().Setenv("DB_SLOW_QUERY_THRESHOLD", "")
+		GinkgoT().Setenv("FLAKY_CACHE_TTL", "")
+		GinkgoT().Setenv("FLAKY_CACHE_STALE_WINDOW", "")
+		GinkgoT().Setenv("FLAKY_TEST_NAME_PATTERN", "")
+		GinkgoT().Setenv("TEST_OWNERS_FILE", "")
+		GinkgoT().Setenv("FLAKY_ALERT_INTERVAL", "")
+		GinkgoT().Setenv("FLAKY_ALERT_THRESHOLD", "")
+		GinkgoT().Setenv("RATE_LIMIT_RPS", "")
+		GinkgoT().Setenv("RATE_LIMIT_BURST", "")
+		GinkgoT().Setenv("TRUSTED_PROXIES", "")
+		GinkgoT().Setenv("ACCESS_LOG_DB_TIME", "")
+		GinkgoT().Setenv("GRAPHQL_HIDE_INTERNAL_ERRORS", "")
+		GinkgoT().Setenv("STORAGE_BACKEND", "")
+		GinkgoT().Setenv("SQLITE_PATH", "")
+		GinkgoT().Setenv("FLAKY_METRICS_TTL", "")
+		GinkgoT().Setenv("FLAKY_METRICS_MAX_SERIES", "")
+		GinkgoT().Setenv("SLACK_WEBHOOK_URL", "")
+		GinkgoT().Setenv("NOTIFY_INTERVAL", "")
+		GinkgoT().Setenv("GITHUB_ISSUES_ENABLED", "")
+		GinkgoT().Setenv("GITHUB_REPOSITORY", "")
+		GinkgoT().Setenv("GITHUB_TOKEN", "")
+		GinkgoT().Setenv("GITHUB_API_URL", "")
+		GinkgoT().Setenv("WEBHOOK_URLS", "")
+		GinkgoT().Setenv("WEBHOOK_SECRET", "")
+		GinkgoT().Setenv("WEBHOOK_THRESHOLD", "")
+		GinkgoT().Setenv("WEBHOOK_TIMEOUT", "")
+	})
+
+	It("should default to :8080", func() {
+		Expect(loadConfig().Addr).To(Equal(":8080"))
+	})
+
+	It("should build the address from PORT", func() {
+		GinkgoT().Setenv("PORT", "9090")
+		Expect(loadConfig().Addr).To(Equal(":9090"))
+	})
+
+	It("should prefer LISTEN_ADDR over PORT", func() {
+		GinkgoT().Setenv("PORT", "9090")
+		GinkgoT().Setenv("LISTEN_ADDR", "127.0.0.1:7070")
+		Expect(loadConfig().Addr).To(Equal("127.0.0.1:7070"))
+	})
+	It("should read API keys as a comma-separated list", func() {
+		GinkgoT().Setenv("API_KEYS", "alpha, beta,,")
+		Expect(loadConfig().APIKeys).To(Equal([]string{"alpha", "beta"}))
+	})
+	It("should default CORS to no origins with standard methods and headers", func() {
+		cfg := loadConfig()
+		Expect(cfg.CORSOrigins).To(BeEmpty())
+		Expect(cfg.CORSMethods).To(Equal([]string{"GET", "POST", "OPTIONS"}))
+		Expect(cfg.CORSHeaders).To(Equal([]string{"Authorization", "Content-Type"}))
+	})
+
+	It("should read CORS settings from the environment", func() {
+		GinkgoT().Setenv("CORS_ALLOWED_ORIGINS", "https://a.example.com,https://b.example.com")
+		GinkgoT().Setenv("CORS_ALLOWED_METHODS", "POST")
+		GinkgoT().Setenv("CORS_ALLOWED_HEADERS", "Content-Type")
+
+		cfg := loadConfig()
+		Expect(cfg.CORSOrigins).To(Equal([]string{"https://a.example.com", "https://b.example.com"}))
+		Expect(cfg.CORSMethods).To(Equal([]string{"POST"}))
+		Expect(cfg.CORSHeaders).To(Equal([]string{"Content-Type"}))
+	})
+	It("should default the complexity limit and read overrides", func() {
+		Expect(loadConfig().ComplexityLimit).To(Equal(200))
+
+		GinkgoT().Setenv("GRAPHQL_COMPLEXITY_LIMIT", "50")
+		Expect(loadConfig().ComplexityLimit).To(Equal(50))
+	})
+
+	It("should reject an invalid complexity limit", func() {
+		GinkgoT().Setenv("GRAPHQL_COMPLEXITY_LIMIT", "lots")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid GRAPHQL_COMPLEXITY_LIMIT")))
+	})
+	It("should default the query cache size and read overrides", func() {
+		Expect(loadConfig().QueryCacheSize).To(Equal(1000))
+
+		GinkgoT().Setenv("GRAPHQL_QUERY_CACHE_SIZE", "0")
+		Expect(loadConfig().QueryCacheSize).To(BeZero())
+	})
+	It("should enable introspection unless GRAPHQL_INTROSPECTION disables it", func() {
+		Expect(loadConfig().Introspection).To(BeTrue())
+
+		GinkgoT().Setenv("GRAPHQL_INTROSPECTION", "false")
+		Expect(loadConfig().Introspection).To(BeFalse())
+
+		GinkgoT().Setenv("GRAPHQL_INTROSPECTION", "sometimes")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid GRAPHQL_INTROSPECTION")))
+	})
+	It("should only serve GET queries when GRAPHQL_GET_ENABLED is set", func() {
+		Expect(loadConfig().GETQueries).To(BeFalse())
+
+		GinkgoT().Setenv("GRAPHQL_GET_ENABLED", "true")
+		Expect(loadConfig().GETQueries).To(BeTrue())
+	})
+	It("should serve the playground at /graphql unless moved or disabled", func() {
+		cfg := loadConfig()
+		Expect(cfg.PlaygroundPath).To(Equal("/graphql"))
+		Expect(cfg.PlaygroundAuth).To(BeFalse())
+
+		GinkgoT().Setenv("GRAPHQL_PLAYGROUND_PATH", "/playground")
+		GinkgoT().Setenv("GRAPHQL_PLAYGROUND_AUTH", "true")
+		cfg = loadConfig()
+		Expect(cfg.PlaygroundPath).To(Equal("/playground"))
+		Expect(cfg.PlaygroundAuth).To(BeTrue())
+
+		GinkgoT().Setenv("GRAPHQL_PLAYGROUND_ENABLED", "false")
+		Expect(loadConfig().PlaygroundPath).To(BeEmpty())
+	})
+	It("should reject a playground path that is not absolute", func() {
+		GinkgoT().Setenv("GRAPHQL_PLAYGROUND_PATH", "playground")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid GRAPHQL_PLAYGROUND_PATH")))
+	})
+	It("should only serve mock data when MOCK_DATA is set", func() {
+		Expect(loadConfig().MockData).To(BeFalse())
+
+		GinkgoT().Setenv("MOCK_DATA", "true")
+		Expect(loadConfig().MockData).To(BeTrue())
+
+		GinkgoT().Setenv("MOCK_DATA", "sometimes")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("MOCK_DATA")))
+	})
+	It("should only require the database when REQUIRE_DB is set", func() {
+		Expect(loadConfig().RequireDB).To(BeFalse())
+
+		GinkgoT().Setenv("REQUIRE_DB", "true")
+		Expect(loadConfig().RequireDB).To(BeTrue())
+
+		GinkgoT().Setenv("REQUIRE_DB", "maybe")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("REQUIRE_DB")))
+	})
+	It("should serve plain HTTP unless a TLS certificate and key are both set", func() {
+		cfg := loadConfig()
+		Expect(cfg.TLSCertFile).To(BeEmpty())
+		Expect(cfg.TLSMinVersion).To(Equal(uint16(tls.VersionTLS12)))
+
+		GinkgoT().Setenv("TLS_CERT_FILE", "/etc/mycelium/tls.crt")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+
+		GinkgoT().Setenv("TLS_KEY_FILE", "/etc/mycelium/tls.key")
+		GinkgoT().Setenv("TLS_MIN_VERSION", "1.3")
+		cfg = loadConfig()
+		Expect(cfg.TLSCertFile).To(Equal("/etc/mycelium/tls.crt"))
+		Expect(cfg.TLSKeyFile).To(Equal("/etc/mycelium/tls.key"))
+		Expect(cfg.TLSMinVersion).To(Equal(uint16(tls.VersionTLS13)))
+
+		GinkgoT().Setenv("TLS_MIN_VERSION", "1.0")
+		_, err = server.LoadConfig()
+		Expect(err).To(MatchError(`invalid TLS_MIN_VERSION "1.0": must be 1.2 or 1.3`))
+	})
+	It("should leave query logging off and default the slow query threshold", func() {
+		cfg := loadConfig()
+		Expect(cfg.QueryLogging).To(BeFalse())
+		Expect(cfg.SlowQueryThreshold).To(Equal(repo.DefaultSlowQueryThreshold))
+
+		GinkgoT().Setenv("DB_QUERY_LOGGING", "true")
+		GinkgoT().Setenv("DB_SLOW_QUERY_THRESHOLD", "250ms")
+		cfg = loadConfig()
+		Expect(cfg.QueryLogging).To(BeTrue())
+		Expect(cfg.SlowQueryThreshold).To(Equal(250 * time.Millisecond))
+	})
+	It("should default the operation timeout and read overrides", func() {
+		Expect(loadConfig().OperationTimeout).To(Equal(time.Minute))
+
+		GinkgoT().Setenv("GRAPHQL_OPERATION_TIMEOUT", "10s")
+		Expect(loadConfig().OperationTimeout).To(Equal(10 * time.Second))
+
+		GinkgoT().Setenv("GRAPHQL_OPERATION_TIMEOUT", "0")
+		Expect(loadConfig().OperationTimeout).To(BeZero())
+
+		GinkgoT().Setenv("GRAPHQL_OPERATION_TIMEOUT", "-1s")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid GRAPHQL_OPERATION_TIMEOUT")))
+	})
+	It("should default the query timeout and read overrides", func() {
+		Expect(loadConfig().QueryTimeout).To(Equal(repo.DefaultQueryTimeout))
+
+		GinkgoT().Setenv("DB_QUERY_TIMEOUT", "5s")
+		Expect(loadConfig().QueryTimeout).To(Equal(5 * time.Second))
+
+		GinkgoT().Setenv("DB_QUERY_TIMEOUT", "soon")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid DB_QUERY_TIMEOUT")))
+	})
+
+	It("should disable the flaky test cache unless a TTL is set", func() {
+		Expect(loadConfig().FlakyCacheTTL).To(BeZero())
+
+		GinkgoT().Setenv("FLAKY_CACHE_TTL", "1m")
+		Expect(loadConfig().FlakyCacheTTL).To(Equal(time.Minute))
+
+		GinkgoT().Setenv("FLAKY_CACHE_TTL", "-1s")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid FLAKY_CACHE_TTL")))
+	})
+
+	It("should read the stale window of the flaky test cache", func() {
+		Expect(loadConfig().FlakyStaleWindow).To(BeZero())
+
+		GinkgoT().Setenv("FLAKY_CACHE_STALE_WINDOW", "10m")
+		Expect(loadConfig().FlakyStaleWindow).To(Equal(10 * time.Minute))
+
+		GinkgoT().Setenv("FLAKY_CACHE_STALE_WINDOW", "soon")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid FLAKY_CACHE_STALE_WINDOW")))
+	})
+
+	It("should read the flaky test name pattern", func() {
+		Expect(loadConfig().FlakyTestNamePattern).To(Equal(repo.DefaultNamePattern))
+
+		GinkgoT().Setenv("FLAKY_TEST_NAME_PATTERN", `\s*\(.*\)$`)
+		Expect(loadConfig().FlakyTestNamePattern).To(Equal(`\s*\(.*\)$`))
+
+		GinkgoT().Setenv("FLAKY_TEST_NAME_PATTERN", "[case")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid FLAKY_TEST_NAME_PATTERN")))
+	})
+
+	It("should read the test owners file path", func() {
+		Expect(loadConfig().OwnersFile).To(BeEmpty())
+
+		GinkgoT().Setenv("TEST_OWNERS_FILE", "/etc/fern/OWNERS")
+		Expect(loadConfig().OwnersFile).To(Equal("/etc/fern/OWNERS"))
+	})
+
+	It("should read the flaky alert interval and threshold", func() {
+		cfg := loadConfig()
+		Expect(cfg.FlakyAlertInterval).To(Equal(resolvers.DefaultAlertInterval))
+		Expect(cfg.FlakyAlertThreshold).To(Equal(resolvers.DefaultAlertThreshold))
+
+		GinkgoT().Setenv("FLAKY_ALERT_INTERVAL", "5s")
+		GinkgoT().Setenv("FLAKY_ALERT_THRESHOLD", "0.25")
+		cfg = loadConfig()
+		Expect(cfg.FlakyAlertInterval).To(Equal(5 * time.Second))
+		Expect(cfg.FlakyAlertThreshold).To(Equal(0.25))
+	})
+
+	It("should reject a flaky alert threshold outside [0, 1]", func() {
+		GinkgoT().Setenv("FLAKY_ALERT_THRESHOLD", "1.5")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid FLAKY_ALERT_THRESHOLD")))
+	})
+
+	It("should disable rate limiting unless a rate is set", func() {
+		cfg := loadConfig()
+		Expect(cfg.RateLimitRPS).To(BeZero())
+		Expect(cfg.RateLimitBurst).To(BeZero())
+
+		GinkgoT().Setenv("RATE_LIMIT_RPS", "2.5")
+		GinkgoT().Setenv("RATE_LIMIT_BURST", "10")
+		cfg = loadConfig()
+		Expect(cfg.RateLimitRPS).To(Equal(2.5))
+		Expect(cfg.RateLimitBurst).To(Equal(10))
+
+		GinkgoT().Setenv("RATE_LIMIT_RPS", "-1")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid RATE_LIMIT_RPS")))
+	})
+
+	It("should trust no proxy unless TRUSTED_PROXIES lists some", func() {
+		Expect(loadConfig().TrustedProxies).To(BeNil())
+
+		GinkgoT().Setenv("TRUSTED_PROXIES", "10.0.0.1, 192.168.0.0/16")
+		Expect(loadConfig().TrustedProxies).To(Equal([]string{"10.0.0.1", "192.168.0.0/16"}))
+
+		GinkgoT().Setenv("TRUSTED_PROXIES", "10.0.0.1,proxy.internal")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring(`invalid TRUSTED_PROXIES entry "proxy.internal"`)))
+	})
+
+	It("should only log database time when enabled", func() {
+		Expect(loadConfig().AccessLogDBTime).To(BeFalse())
+
+		GinkgoT().Setenv("ACCESS_LOG_DB_TIME", "true")
+		Expect(loadConfig().AccessLogDBTime).To(BeTrue())
+	})
+
+	It("should bound the flakyTests limit", func() {
+		Expect(loadConfig().MaxLimit).To(Equal(resolvers.DefaultMaxLimit))
+
+		GinkgoT().Setenv("GRAPHQL_MAX_LIMIT", "500")
+		Expect(loadConfig().MaxLimit).To(Equal(500))
+
+		GinkgoT().Setenv("GRAPHQL_MAX_LIMIT", "5000")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError("invalid GRAPHQL_MAX_LIMIT 5000: must be between 1 and 1000"))
+	})
+
+	It("should default the flakyTests limit within the maximum", func() {
+		Expect(loadConfig().DefaultLimit).To(Equal(resolvers.DefaultLimit))
+
+		GinkgoT().Setenv("GRAPHQL_DEFAULT_LIMIT", "50")
+		Expect(loadConfig().DefaultLimit).To(Equal(50))
+
+		GinkgoT().Setenv("GRAPHQL_MAX_LIMIT", "10")
+		GinkgoT().Setenv("GRAPHQL_DEFAULT_LIMIT", "")
+		Expect(loadConfig().DefaultLimit).To(Equal(10))
+
+		GinkgoT().Setenv("GRAPHQL_DEFAULT_LIMIT", "11")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError("invalid GRAPHQL_DEFAULT_LIMIT 11: must be between 1 and GRAPHQL_MAX_LIMIT (10)"))
+
+		GinkgoT().Setenv("GRAPHQL_DEFAULT_LIMIT", "0")
+		_, err = server.LoadConfig()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should only hide internal errors when enabled", func() {
+		Expect(loadConfig().HideInternalErrors).To(BeFalse())
+
+		GinkgoT().Setenv("GRAPHQL_HIDE_INTERNAL_ERRORS", "true")
+		Expect(loadConfig().HideInternalErrors).To(BeTrue())
+	})
+
+	It("should select the storage backend", func() {
+		Expect(loadConfig().StorageBackend).To(Equal(storage.Postgres))
+
+		GinkgoT().Setenv("STORAGE_BACKEND", "sqlite")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError("STORAGE_BACKEND=sqlite requires SQLITE_PATH"))
+
+		GinkgoT().Setenv("SQLITE_PATH", "/var/lib/fern/results.db")
+		cfg := loadConfig()
+		Expect(cfg.StorageBackend).To(Equal(storage.SQLite))
+		Expect(cfg.SQLitePath).To(Equal("/var/lib/fern/results.db"))
+
+		GinkgoT().Setenv("STORAGE_BACKEND", "bigquery")
+		_, err = server.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring(`unknown storage backend "bigquery"`)))
+	})
+
+	It("should read the flakiness metrics settings", func() {
+		cfg := loadConfig()
+		Expect(cfg.FlakyMetricsTTL).To(Equal(metrics.DefaultFlakinessTTL))
+		Expect(cfg.FlakyMetricsMaxSeries).To(Equal(metrics.DefaultFlakinessMaxSeries))
+
+		GinkgoT().Setenv("FLAKY_METRICS_TTL", "15s")
+		GinkgoT().Setenv("FLAKY_METRICS_MAX_SERIES", "0")
+		cfg = loadConfig()
+		Expect(cfg.FlakyMetricsTTL).To(Equal(15 * time.Second))
+		Expect(cfg.FlakyMetricsMaxSeries).To(BeZero())
+	})
+
+	It("should read the Slack notifier settings", func() {
+		cfg := loadConfig()
+		Expect(cfg.SlackWebhookURL).To(BeEmpty())
+		Expect(cfg.NotifyInterval).To(Equal(notify.DefaultInterval))
+
+		GinkgoT().Setenv("SLACK_WEBHOOK_URL", "https://hooks.slack.com/services/T/B/x")
+		GinkgoT().Setenv("NOTIFY_INTERVAL", "1m")
+		cfg = loadConfig()
+		Expect(cfg.SlackWebhookURL).To(Equal("https://hooks.slack.com/services/T/B/x"))
+		Expect(cfg.NotifyInterval).To(Equal(time.Minute))
+
+		GinkgoT().Setenv("SLACK_WEBHOOK_URL", "hooks.slack.com/services/T/B/x")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError("invalid SLACK_WEBHOOK_URL: must be an http or https URL"))
+	})
+
+	It("should require a repository and token for GitHub issues", func() {
+		Expect(loadConfig().GitHubIssues).To(BeFalse())
+
+		GinkgoT().Setenv("GITHUB_ISSUES_ENABLED", "true")
+		GinkgoT().Setenv("GITHUB_REPOSITORY", "acme/webapp")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError("GITHUB_ISSUES_ENABLED requires GITHUB_REPOSITORY and GITHUB_TOKEN"))
+
+		GinkgoT().Setenv("GITHUB_TOKEN", "pat-123")
+		GinkgoT().Setenv("GITHUB_API_URL", "https://github.example/api/v3")
+		cfg := loadConfig()
+		Expect(cfg.GitHubIssues).To(BeTrue())
+		Expect(cfg.GitHubRepository).To(Equal("acme/webapp"))
+		Expect(cfg.GitHubToken).To(Equal("pat-123"))
+		Expect(cfg.GitHubAPIURL).To(Equal("https://github.example/api/v3"))
+	})
+
+	It("should read the webhook settings", func() {
+		cfg := loadConfig()
+		Expect(cfg.WebhookURLs).To(BeEmpty())
+		Expect(cfg.WebhookThreshold).To(Equal(resolvers.DefaultAlertThreshold))
+		Expect(cfg.WebhookTimeout).To(Equal(notify.DefaultWebhookTimeout))
+
+		GinkgoT().Setenv("WEBHOOK_URLS", "https://events.example.com/hook, http://localhost:9000/alerts")
+		GinkgoT().Setenv("WEBHOOK_SECRET", "shh")
+		GinkgoT().Setenv("WEBHOOK_THRESHOLD", "0.25")
+		GinkgoT().Setenv("WEBHOOK_TIMEOUT", "3s")
+		cfg = loadConfig()
+		Expect(cfg.WebhookURLs).To(Equal([]string{"https://events.example.com/hook", "http://localhost:9000/alerts"}))
+		Expect(cfg.WebhookSecret).To(Equal("shh"))
+		Expect(cfg.WebhookThreshold).To(Equal(0.25))
+		Expect(cfg.WebhookTimeout).To(Equal(3 * time.Second))
+
+		GinkgoT().Setenv("WEBHOOK_URLS", "https://events.example.com/hook,ftp://files.example.com")
+		_, err := server.LoadConfig()
+		Expect(err).To(MatchError("invalid WEBHOOK_URLS: each must be an http or https URL"))
+	})
+})
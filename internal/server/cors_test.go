@@ -0,0 +1,64 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+)
+
+var _ = Describe("CORS", func() {
+	var router http.Handler
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/query", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	newRouter := func(origins ...string) http.Handler {
+		cfg := server.Config{
+			CORSOrigins: origins,
+			CORSMethods: []string{"GET", "POST", "OPTIONS"},
+			CORSHeaders: []string{"Authorization", "Content-Type"},
+		}
+		return mustNewRouter(cfg, &resolvers.Resolver{}, metrics.New(prometheus.NewRegistry()), nil)
+	}
+
+	It("should answer preflight requests from an allowed origin", func() {
+		router = newRouter("https://dashboard.example.com")
+
+		rec := preflight("https://dashboard.example.com")
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+		Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(Equal("https://dashboard.example.com"))
+		Expect(rec.Header().Get("Access-Control-Allow-Methods")).To(ContainSubstring("POST"))
+	})
+
+	It("should not allow a disallowed origin", func() {
+		router = newRouter("https://dashboard.example.com")
+
+		rec := preflight("https://evil.example.com")
+		Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(BeEmpty())
+	})
+
+	It("should not send CORS headers when no origins are configured", func() {
+		router = newRouter()
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		req.Header.Set("Origin", "https://dashboard.example.com")
+		router.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(BeEmpty())
+	})
+})
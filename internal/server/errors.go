@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/logging"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// Error codes set in extensions.code of resolver errors.
+const (
+	ErrorCodeInvalidArgument  = "INVALID_ARGUMENT"
+	ErrorCodeNotFound         = "NOT_FOUND"
+	ErrorCodeAlreadyExists    = "ALREADY_EXISTS"
+	ErrorCodeDBUnavailable    = "DB_UNAVAILABLE"
+	ErrorCodeDeadlineExceeded = "DEADLINE_EXCEEDED"
+	ErrorCodeUnimplemented    = "UNIMPLEMENTED"
+	ErrorCodeInternal         = "INTERNAL"
+)
+
+// internalErrorMessage replaces the message of unclassified errors when
+// internal errors are hidden.
+const internalErrorMessage = "internal server error"
+
+// errorCodes maps the typed errors of the repo and resolver layers to codes,
+// checked in order.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{repo.ErrInvalidArgument, ErrorCodeInvalidArgument},
+	{repo.ErrNotFound, ErrorCodeNotFound},
+	{resolvers.ErrAlreadyQuarantined, ErrorCodeAlreadyExists},
+	{repo.ErrUnavailable, ErrorCodeDBUnavailable},
+	{errNotInMockMode, ErrorCodeUnimplemented},
+	{errNotInSQLiteMode, ErrorCodeUnimplemented},
+}
+
+// errorPresenter adds extensions.code to resolver errors. Errors gqlgen has
+// already coded, such as validation failures, pass through unchanged. With
+// hideInternal, unclassified errors are logged and sent with a generic
+// message so database details do not reach clients. Errors of resolvers cut
+// short by the operation timeout are coded DEADLINE_EXCEEDED whatever their
+// cause, which is usually a cancelled database query.
+func errorPresenter(hideInternal bool) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+		if _, coded := gqlErr.Extensions["code"]; coded || gqlErr.Err == nil {
+			return withRequestID(ctx, gqlErr)
+		}
+
+		if timeoutErr, exceeded := exceededOperationTimeout(ctx); exceeded {
+			gqlErr = &gqlerror.Error{Message: timeoutErr.Error(), Path: gqlErr.Path, Locations: gqlErr.Locations, Err: err}
+			gqlErr.Extensions = map[string]any{"code": ErrorCodeDeadlineExceeded}
+			return withRequestID(ctx, gqlErr)
+		}
+
+		code := ErrorCodeInternal
+		for _, candidate := range errorCodes {
+			if errors.Is(err, candidate.err) {
+				code = candidate.code
+				break
+			}
+		}
+		if hideInternal && (code == ErrorCodeInternal || code == ErrorCodeDBUnavailable) {
+			slog.ErrorContext(ctx, "❌ GraphQL resolver failed", "path", gqlErr.Path.String(), "code", code, "error", err)
+			gqlErr = &gqlerror.Error{Message: internalErrorMessage, Path: gqlErr.Path, Locations: gqlErr.Locations, Err: err}
+			if code == ErrorCodeDBUnavailable {
+				gqlErr.Message = "database unavailable"
+			}
+		}
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]any{}
+		}
+		gqlErr.Extensions["code"] = code
+		return withRequestID(ctx, gqlErr)
+	}
+}
+
+// withRequestID adds extensions.requestId, so users can quote it when
+// reporting a failure.
+func withRequestID(ctx context.Context, gqlErr *gqlerror.Error) *gqlerror.Error {
+	id := logging.RequestID(ctx)
+	if id == "" {
+		return gqlErr
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]any{}
+	}
+	gqlErr.Extensions[logging.RequestIDKey] = id
+	return gqlErr
+}
@@ -0,0 +1,112 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("GraphQL error codes", func() {
+	type graphQLError struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	}
+
+	var (
+		cfg            server.Config
+		fakeFlaky      *fakes.FakeFlakyTestProvider
+		fakeQuarantine *fakes.FakeQuarantineProvider
+	)
+
+	BeforeEach(func() {
+		cfg = server.Config{}
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		fakeQuarantine = &fakes.FakeQuarantineProvider{}
+	})
+
+	query := func(body string) graphQLError {
+		router := mustNewRouter(cfg, &resolvers.Resolver{FlakyRepo: fakeFlaky, QuarantineRepo: fakeQuarantine},
+			metrics.New(prometheus.NewRegistry()), nil)
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		var resp struct {
+			Errors []graphQLError `json:"errors"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp.Errors).To(HaveLen(1))
+		return resp.Errors[0]
+	}
+	flakyQuery := `{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"}`
+	quarantine := `{"query":"mutation { quarantineTest(projectID: \"demo\", testName: \"login\") { id } }"}`
+
+	DescribeTable("should code flakyTests failures by their type",
+		func(err error, code string) {
+			fakeFlaky.GetFlakyTestsReturns(nil, err)
+
+			gqlErr := query(flakyQuery)
+			Expect(gqlErr.Extensions).To(HaveKeyWithValue("code", code))
+			Expect(gqlErr.Message).To(Equal(err.Error()))
+		},
+		Entry("invalid arguments", repo.InvalidArgumentf("minRuns must be non-negative, got -1"), server.ErrorCodeInvalidArgument),
+		Entry("an unreachable database", fmt.Errorf("flaky tests: %w", repo.ErrUnavailable), server.ErrorCodeDBUnavailable),
+		Entry("anything else", errors.New("relation \"spec_runs\" does not exist"), server.ErrorCodeInternal),
+	)
+
+	It("should code mutation failures", func() {
+		fakeQuarantine.QuarantineTestReturns(nil, fmt.Errorf("%w: %q", repo.ErrAlreadyQuarantined, "login"))
+		Expect(query(quarantine).Extensions).To(HaveKeyWithValue("code", server.ErrorCodeAlreadyExists))
+
+		fakeQuarantine.QuarantineTestReturns(nil, repo.ErrProjectNotFound)
+		Expect(query(quarantine).Extensions).To(HaveKeyWithValue("code", server.ErrorCodeNotFound))
+	})
+
+	It("should code resolver validation errors", func() {
+		gqlErr := query(`{"query":"mutation { quarantineTest(projectID: \" \", testName: \"login\") { id } }"}`)
+		Expect(gqlErr.Message).To(Equal("projectID must not be empty"))
+		Expect(gqlErr.Extensions).To(HaveKeyWithValue("code", server.ErrorCodeInvalidArgument))
+	})
+
+	It("should keep the codes gqlgen assigns to invalid documents", func() {
+		gqlErr := query(`{"query":"{ noSuchField }"}`)
+		Expect(gqlErr.Extensions).To(HaveKeyWithValue("code", "GRAPHQL_VALIDATION_FAILED"))
+	})
+
+	Context("with internal errors hidden", func() {
+		BeforeEach(func() {
+			cfg.HideInternalErrors = true
+		})
+
+		It("should not reveal database errors", func() {
+			fakeFlaky.GetFlakyTestsReturns(nil, errors.New(`relation "spec_runs" does not exist`))
+			gqlErr := query(flakyQuery)
+			Expect(gqlErr.Message).To(Equal("internal server error"))
+			Expect(gqlErr.Extensions).To(HaveKeyWithValue("code", server.ErrorCodeInternal))
+
+			fakeFlaky.GetFlakyTestsReturns(nil, fmt.Errorf("dial tcp 10.0.0.5:5432: %w", repo.ErrUnavailable))
+			gqlErr = query(flakyQuery)
+			Expect(gqlErr.Message).To(Equal("database unavailable"))
+			Expect(gqlErr.Extensions).To(HaveKeyWithValue("code", server.ErrorCodeDBUnavailable))
+		})
+
+		It("should still explain invalid arguments", func() {
+			fakeFlaky.GetFlakyTestsReturns(nil, repo.InvalidArgumentf("minRuns must be non-negative, got -1"))
+			Expect(query(flakyQuery).Message).To(Equal("minRuns must be non-negative, got -1"))
+		})
+	})
+})
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// Export row limits: defaultExportLimit applies when ?limit is omitted and
+// maxExportLimit caps what a single download may request.
+const (
+	defaultExportLimit = 100
+	maxExportLimit     = repo.MaxLimit
+)
+
+// loadExport reads the project and limit query parameters and fetches the
+// matching flaky tests. It writes the error response itself and returns
+// false when the request cannot be served.
+func loadExport(c *gin.Context, flaky repo.FlakyTestProvider) ([]*gql.FlakyTest, string, bool) {
+	project := c.Query("project")
+	if project == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project is required"})
+		return nil, "", false
+	}
+
+	limit := defaultExportLimit
+	if raw := c.Query("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > maxExportLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer between 1 and " + strconv.Itoa(maxExportLimit)})
+			return nil, "", false
+		}
+		limit = v
+	}
+
+	tests, err := flaky.GetFlakyTests(c.Request.Context(), project, limit, repo.FlakyTestOptions{})
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "⚠️ Flaky test export failed", "project", project, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load flaky tests"})
+		return nil, "", false
+	}
+	return tests, project, true
+}
+
+type junitTestCase struct {
+	XMLName    xml.Name        `xml:"testcase"`
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitExportHandler serves GET /export/flaky.xml, writing a project's flaky
+// tests as a JUnit XML suite. Each test is a testcase whose properties carry
+// its failure statistics. Test cases are encoded straight to the response
+// rather than assembled in memory first.
+func junitExportHandler(flaky repo.FlakyTestProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tests, project, ok := loadExport(c, flaky)
+		if !ok {
+			return
+		}
+
+		c.Header("Content-Type", "application/xml; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := writeJUnit(c.Writer, project, tests); err != nil {
+			// The client went away mid-stream; there is no one left to tell.
+			slog.DebugContext(c.Request.Context(), "flaky test export interrupted", "error", err)
+		}
+	}
+}
+
+func writeJUnit(w io.Writer, project string, tests []*gql.FlakyTest) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	count := strconv.Itoa(len(tests))
+	suites := xml.StartElement{Name: xml.Name{Local: "testsuites"}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: "name"}, Value: "fern-mycelium flaky tests"},
+		{Name: xml.Name{Local: "tests"}, Value: count},
+	}}
+	suite := xml.StartElement{Name: xml.Name{Local: "testsuite"}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: "name"}, Value: project},
+		{Name: xml.Name{Local: "tests"}, Value: count},
+	}}
+	if err := enc.EncodeToken(suites); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(suite); err != nil {
+		return err
+	}
+
+	for _, test := range tests {
+		if err := enc.Encode(junitCase(project, test)); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.EncodeToken(suite.End()); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(suites.End()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func junitCase(project string, test *gql.FlakyTest) junitTestCase {
+	properties := []junitProperty{
+		{Name: "failureRate", Value: formatFloat(test.FailureRate)},
+		{Name: "passRate", Value: formatFloat(test.PassRate)},
+		{Name: "flakinessScore", Value: formatFloat(test.FlakinessScore)},
+		{Name: "runCount", Value: strconv.Itoa(test.RunCount)},
+	}
+	if test.LastFailure != nil {
+		properties = append(properties, junitProperty{Name: "lastFailure", Value: *test.LastFailure})
+	}
+	return junitTestCase{Name: test.TestName, ClassName: project, Properties: properties}
+}
+
+// csvExportHeader is the first row of every CSV export.
+var csvExportHeader = []string{"testName", "passRate", "failureRate", "runCount", "lastFailure"}
+
+// csvExportHandler serves GET /export/flaky.csv, writing a project's flaky
+// tests as a downloadable spreadsheet with one row per test. A test that has
+// never failed leaves the lastFailure cell empty.
+func csvExportHandler(flaky repo.FlakyTestProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tests, project, ok := loadExport(c, flaky)
+		if !ok {
+			return
+		}
+
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		disposition := mime.FormatMediaType("attachment", map[string]string{"filename": "flaky-" + project + ".csv"})
+		if disposition == "" {
+			// The project name cannot be expressed as a filename parameter.
+			disposition = "attachment"
+		}
+		c.Header("Content-Disposition", disposition)
+		c.Status(http.StatusOK)
+		if err := writeCSV(c.Writer, tests); err != nil {
+			slog.DebugContext(c.Request.Context(), "flaky test export interrupted", "error", err)
+		}
+	}
+}
+
+func writeCSV(w io.Writer, tests []*gql.FlakyTest) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvExportHeader); err != nil {
+		return err
+	}
+	for _, test := range tests {
+		lastFailure := ""
+		if test.LastFailure != nil {
+			lastFailure = *test.LastFailure
+		}
+		row := []string{
+			csvText(test.TestName),
+			formatFloat(test.PassRate),
+			formatFloat(test.FailureRate),
+			strconv.Itoa(test.RunCount),
+			csvText(lastFailure),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvText prefixes a cell that spreadsheets would evaluate as a formula with
+// a quote, so spec names cannot inject formulas into an export.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,174 @@
+package server_test
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("Flaky test export", func() {
+	var (
+		fakeFlaky *fakes.FakeFlakyTestProvider
+		router    *gin.Engine
+	)
+
+	BeforeEach(func() {
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		router = mustNewRouter(server.Config{}, &resolvers.Resolver{FlakyRepo: fakeFlaky},
+			metrics.New(prometheus.NewRegistry()), nil)
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	Describe("GET /export/flaky.xml", func() {
+		type property struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:"value,attr"`
+		}
+		type testCase struct {
+			Name       string     `xml:"name,attr"`
+			ClassName  string     `xml:"classname,attr"`
+			Properties []property `xml:"properties>property"`
+		}
+		type report struct {
+			XMLName xml.Name `xml:"testsuites"`
+			Suites  []struct {
+				Name  string     `xml:"name,attr"`
+				Tests int        `xml:"tests,attr"`
+				Cases []testCase `xml:"testcase"`
+			} `xml:"testsuite"`
+		}
+
+		It("should write each flaky test as a JUnit testcase", func() {
+			lastFailure := "2026-10-01T12:00:00Z"
+			fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{
+				{TestName: "login", PassRate: 0.75, FailureRate: 0.25, FlakinessScore: 0.5, RunCount: 8, LastFailure: &lastFailure},
+				{TestName: `checkout <"cart">`, PassRate: 1, RunCount: 3},
+			}, nil)
+
+			rec := get("/export/flaky.xml?project=demo&limit=2")
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Type")).To(Equal("application/xml; charset=utf-8"))
+
+			var doc report
+			Expect(xml.Unmarshal(rec.Body.Bytes(), &doc)).To(Succeed())
+			Expect(doc.Suites).To(HaveLen(1))
+			Expect(doc.Suites[0].Name).To(Equal("demo"))
+			Expect(doc.Suites[0].Tests).To(Equal(2))
+			Expect(doc.Suites[0].Cases).To(Equal([]testCase{
+				{Name: "login", ClassName: "demo", Properties: []property{
+					{Name: "failureRate", Value: "0.25"},
+					{Name: "passRate", Value: "0.75"},
+					{Name: "flakinessScore", Value: "0.5"},
+					{Name: "runCount", Value: "8"},
+					{Name: "lastFailure", Value: lastFailure},
+				}},
+				{Name: `checkout <"cart">`, ClassName: "demo", Properties: []property{
+					{Name: "failureRate", Value: "0"},
+					{Name: "passRate", Value: "1"},
+					{Name: "flakinessScore", Value: "0"},
+					{Name: "runCount", Value: "3"},
+				}},
+			}))
+
+			_, project, limit, opts := fakeFlaky.GetFlakyTestsArgsForCall(0)
+			Expect(project).To(Equal("demo"))
+			Expect(limit).To(Equal(2))
+			Expect(opts).To(Equal(repo.FlakyTestOptions{}))
+		})
+
+		It("should write a well-formed empty suite when nothing is flaky", func() {
+			rec := get("/export/flaky.xml?project=demo")
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var doc report
+			Expect(xml.Unmarshal(rec.Body.Bytes(), &doc)).To(Succeed())
+			Expect(doc.Suites).To(HaveLen(1))
+			Expect(doc.Suites[0].Cases).To(BeEmpty())
+
+			_, _, limit, _ := fakeFlaky.GetFlakyTestsArgsForCall(0)
+			Expect(limit).To(Equal(100))
+		})
+
+		DescribeTable("should reject invalid parameters",
+			func(path, message string) {
+				rec := get(path)
+
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(MatchJSON(`{"error":"` + message + `"}`))
+				Expect(fakeFlaky.GetFlakyTestsCallCount()).To(BeZero())
+			},
+			Entry("missing project", "/export/flaky.xml", "project is required"),
+			Entry("non-numeric limit", "/export/flaky.xml?project=demo&limit=ten", "limit must be an integer between 1 and 1000"),
+			Entry("zero limit", "/export/flaky.xml?project=demo&limit=0", "limit must be an integer between 1 and 1000"),
+			Entry("limit over the cap", "/export/flaky.xml?project=demo&limit=1001", "limit must be an integer between 1 and 1000"),
+		)
+
+		It("should return 500 without a partial document when the lookup fails", func() {
+			fakeFlaky.GetFlakyTestsReturns(nil, errors.New("db down"))
+
+			rec := get("/export/flaky.xml?project=demo")
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+			Expect(rec.Body.String()).To(MatchJSON(`{"error":"failed to load flaky tests"}`))
+		})
+	})
+
+	Describe("GET /export/flaky.csv", func() {
+		It("should write a header and one row per flaky test", func() {
+			lastFailure := "2026-10-01T12:00:00Z"
+			fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{
+				{TestName: "login, with comma", PassRate: 0.75, FailureRate: 0.25, RunCount: 8, LastFailure: &lastFailure},
+				{TestName: "logout", PassRate: 1, RunCount: 3},
+				{TestName: `=HYPERLINK("https://evil.example","click")`, RunCount: 1},
+				{TestName: "-1+1", PassRate: 1, RunCount: 1},
+			}, nil)
+
+			rec := get("/export/flaky.csv?project=demo&limit=2")
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Type")).To(Equal("text/csv; charset=utf-8"))
+			Expect(rec.Header().Get("Content-Disposition")).To(Equal("attachment; filename=flaky-demo.csv"))
+
+			rows, err := csv.NewReader(rec.Body).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rows).To(Equal([][]string{
+				{"testName", "passRate", "failureRate", "runCount", "lastFailure"},
+				{"login, with comma", "0.75", "0.25", "8", lastFailure},
+				{"logout", "1", "0", "3", ""},
+				{`'=HYPERLINK("https://evil.example","click")`, "0", "0", "1", ""},
+				{"'-1+1", "1", "0", "1", ""},
+			}))
+
+			_, project, limit, _ := fakeFlaky.GetFlakyTestsArgsForCall(0)
+			Expect(project).To(Equal("demo"))
+			Expect(limit).To(Equal(2))
+		})
+
+		It("should reject a request without a project", func() {
+			rec := get("/export/flaky.csv")
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			Expect(rec.Header().Get("Content-Disposition")).To(BeEmpty())
+		})
+	})
+})
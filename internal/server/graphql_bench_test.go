@@ -0,0 +1,52 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+const benchmarkQuery = `{"query":"query Dashboard($project: ID!) {` +
+	` flakyTests(limit: 10, projectID: $project) { testID testName passRate failureRate lastFailure runCount }` +
+	` slowestTests(limit: 10, projectID: $project) { testName avgDurationMs maxDurationMs runCount }` +
+	` passRateTrend(projectID: $project, testName: \"login\") { periodStart passRate runCount }` +
+	` }","variables":{"project":"demo"}}`
+
+// BenchmarkGraphQLServer compares repeated identical queries with and
+// without the parsed query cache.
+func BenchmarkGraphQLServer(b *testing.B) {
+	for _, bc := range []struct {
+		name      string
+		cacheSize int
+	}{
+		{"uncached", 0},
+		{"cached", 1000},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			schema := gql.NewExecutableSchema(gql.Config{Resolvers: &resolvers.Resolver{
+				FlakyRepo: &fakes.FakeFlakyTestProvider{},
+				SlowRepo:  &fakes.FakeSlowTestProvider{},
+				TrendRepo: &fakes.FakeTrendProvider{},
+			}})
+			srv := server.NewGraphQLServer(server.Config{QueryCacheSize: bc.cacheSize}, schema)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(benchmarkQuery))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+				srv.ServeHTTP(rec, req)
+				if rec.Code != http.StatusOK {
+					b.Fatalf("unexpected status %d: %s", rec.Code, rec.Body.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package server_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("NewGraphQLServer", func() {
+	var fakeFlaky *fakes.FakeFlakyTestProvider
+
+	post := func(cfg server.Config, body string) *httptest.ResponseRecorder {
+		schema := gql.NewExecutableSchema(gql.Config{Resolvers: &resolvers.Resolver{FlakyRepo: fakeFlaky}})
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		server.NewGraphQLServer(cfg, schema).ServeHTTP(rec, req)
+		return rec
+	}
+
+	get := func(cfg server.Config, query string) *httptest.ResponseRecorder {
+		schema := gql.NewExecutableSchema(gql.Config{Resolvers: &resolvers.Resolver{FlakyRepo: fakeFlaky}})
+		req := httptest.NewRequest(http.MethodGet, "/query?query="+url.QueryEscape(query), nil)
+		rec := httptest.NewRecorder()
+		server.NewGraphQLServer(cfg, schema).ServeHTTP(rec, req)
+		return rec
+	}
+
+	const flakyQuery = `{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName passRate failureRate runCount } }"}`
+
+	BeforeEach(func() {
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+	})
+
+	It("should reject operations exceeding the complexity limit", func() {
+		rec := post(server.Config{ComplexityLimit: 3}, flakyQuery)
+
+		Expect(rec.Body.String()).To(ContainSubstring("operation has complexity 5, which exceeds the limit of 3"))
+		Expect(rec.Body.String()).To(ContainSubstring("COMPLEXITY_LIMIT_EXCEEDED"))
+		Expect(fakeFlaky.GetFlakyTestsCallCount()).To(Equal(0))
+	})
+
+	It("should run operations within the complexity limit", func() {
+		rec := post(server.Config{ComplexityLimit: 5}, flakyQuery)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(MatchJSON(`{"data":{"flakyTests":[]}}`))
+		Expect(fakeFlaky.GetFlakyTestsCallCount()).To(Equal(1))
+	})
+	It("should answer introspection queries with the query cache enabled", func() {
+		cfg := server.Config{QueryCacheSize: 10, Introspection: true}
+		body := `{"query":"{ __schema { queryType { name } } }"}`
+
+		for range 2 {
+			rec := post(cfg, body)
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(MatchJSON(`{"data":{"__schema":{"queryType":{"name":"Query"}}}}`))
+		}
+	})
+
+	It("should serve automatic persisted queries once registered", func() {
+		schema := gql.NewExecutableSchema(gql.Config{Resolvers: &resolvers.Resolver{FlakyRepo: fakeFlaky}})
+		srv := server.NewGraphQLServer(server.Config{QueryCacheSize: 10}, schema)
+
+		query := "{ health { status } }"
+		hash := sha256.Sum256([]byte(query))
+		extensions := fmt.Sprintf(`"extensions":{"persistedQuery":{"version":1,"sha256Hash":"%s"}}`, hex.EncodeToString(hash[:]))
+
+		send := func(body string) string {
+			req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			return rec.Body.String()
+		}
+
+		Expect(send(`{` + extensions + `}`)).To(ContainSubstring("PersistedQueryNotFound"))
+		Expect(send(`{"query":"` + query + `",` + extensions + `}`)).To(MatchJSON(`{"data":{"health":{"status":"ok"}}}`))
+		Expect(send(`{` + extensions + `}`)).To(MatchJSON(`{"data":{"health":{"status":"ok"}}}`))
+	})
+	It("should reject introspection queries when introspection is disabled", func() {
+		rec := post(server.Config{}, `{"query":"{ __schema { queryType { name } } }"}`)
+
+		Expect(rec.Body.String()).To(ContainSubstring("introspection disabled"))
+		Expect(rec.Body.String()).ToNot(ContainSubstring(`"queryType"`))
+	})
+
+	It("should still run regular queries when introspection is disabled", func() {
+		rec := post(server.Config{}, `{"query":"{ health { status } }"}`)
+		Expect(rec.Body.String()).To(MatchJSON(`{"data":{"health":{"status":"ok"}}}`))
+	})
+
+	It("should answer GET queries when enabled", func() {
+		rec := get(server.Config{GETQueries: true}, `{ flakyTests(limit: 5, projectID: "demo") { testName } }`)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(MatchJSON(`{"data":{"flakyTests":[]}}`))
+		_, projectID, limit, _ := fakeFlaky.GetFlakyTestsArgsForCall(0)
+		Expect(projectID).To(Equal("demo"))
+		Expect(limit).To(Equal(5))
+	})
+
+	It("should keep serving POST and introspection with GET enabled", func() {
+		cfg := server.Config{GETQueries: true, Introspection: true}
+
+		Expect(post(cfg, flakyQuery).Code).To(Equal(http.StatusOK))
+		rec := get(cfg, "{ __schema { queryType { name } } }")
+		Expect(rec.Body.String()).To(MatchJSON(`{"data":{"__schema":{"queryType":{"name":"Query"}}}}`))
+	})
+
+	It("should reject mutations sent over GET", func() {
+		rec := get(server.Config{GETQueries: true}, `mutation { quarantineTest(projectID: "demo", testName: "login") { id } }`)
+
+		Expect(rec.Code).To(Equal(http.StatusNotAcceptable))
+		Expect(rec.Body.String()).To(ContainSubstring("GET requests only allow query operations"))
+	})
+
+	It("should not serve GET queries unless enabled", func() {
+		rec := get(server.Config{}, "{ health { status } }")
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(rec.Body.String()).To(ContainSubstring("transport not supported"))
+	})
+	It("should answer a batch of operations with a result for each", func() {
+		fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{{TestName: "login"}}, nil)
+
+		rec := post(server.Config{}, `[
+			{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"},
+			{"query":"query Broken { noSuchField }"}
+		]`)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var results []struct {
+			Data   map[string]any `json:"data"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &results)).To(Succeed())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Errors).To(BeEmpty())
+		Expect(results[0].Data).To(HaveKeyWithValue("flakyTests", []any{map[string]any{"testName": "login"}}))
+		Expect(results[1].Data).To(BeEmpty())
+		Expect(results[1].Errors).To(HaveLen(1))
+		Expect(results[1].Errors[0].Message).To(ContainSubstring("noSuchField"))
+	})
+
+	It("should keep a resolver error to its own operation in a batch", func() {
+		fakeFlaky.GetFlakyTestsReturnsOnCall(0, nil, errors.New("db down"))
+		fakeFlaky.GetFlakyTestsReturnsOnCall(1, []*gql.FlakyTest{}, nil)
+
+		rec := post(server.Config{}, `[
+			{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"},
+			{"query":"{ flakyTests(limit: 5, projectID: \"billing\") { testName } }"}
+		]`)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var results []map[string]any
+		Expect(json.Unmarshal(rec.Body.Bytes(), &results)).To(Succeed())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0]).To(HaveKey("errors"))
+		Expect(results[1]).ToNot(HaveKey("errors"))
+		Expect(results[1]).To(HaveKeyWithValue("data", map[string]any{"flakyTests": []any{}}))
+		Expect(fakeFlaky.GetFlakyTestsCallCount()).To(Equal(2))
+	})
+
+	It("should reject an empty or oversized batch", func() {
+		rec := post(server.Config{}, `[]`)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(rec.Body.String()).To(ContainSubstring("a batch must hold between 1 and 20 operations, got 0"))
+
+		ops := make([]string, server.MaxBatchOperations+1)
+		for i := range ops {
+			ops[i] = `{"query":"{ health { status } }"}`
+		}
+		rec = post(server.Config{}, "["+strings.Join(ops, ",")+"]")
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(fakeFlaky.GetFlakyTestsCallCount()).To(Equal(0))
+	})
+
+	It("should reject a batch body over MaxBatchBytes", func() {
+		padding := strings.Repeat("x", server.MaxBatchBytes)
+		rec := post(server.Config{}, `[{"query":"{ health { status } }","variables":{"padding":"`+padding+`"}}]`)
+
+		Expect(rec.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(rec.Body.String()).To(ContainSubstring("request body too large"))
+	})
+})
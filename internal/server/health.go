@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// readinessTimeout bounds the database ping performed by /readyz.
+const readinessTimeout = 2 * time.Second
+
+// livenessHandler reports that the process is up and serving HTTP.
+func livenessHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readinessHandler reports whether db can be pinged within readinessTimeout,
+// responding 503 when it cannot. A nil db is always ready.
+func readinessHandler(db repo.Pinger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if db != nil {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+			defer cancel()
+
+			if err := db.Ping(ctx); err != nil {
+				slog.WarnContext(ctx, "⚠️ Readiness check failed", "error", err)
+				c.JSON(http.StatusServiceUnavailable, gin.H{
+					"status": "degraded",
+					"error":  "database unreachable",
+				})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "ok",
+			"message": "fern-mycelium is healthy 🍄",
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package server_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("Health endpoints", func() {
+	var (
+		fakeDB *fakes.FakePinger
+		router http.Handler
+	)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	BeforeEach(func() {
+		fakeDB = &fakes.FakePinger{}
+		router = mustNewRouter(server.Config{}, &resolvers.Resolver{DB: fakeDB},
+			metrics.New(prometheus.NewRegistry()), nil)
+	})
+
+	It("should report liveness without touching the database", func() {
+		fakeDB.PingReturns(errors.New("connection refused"))
+
+		rec := get("/livez")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(MatchJSON(`{"status":"ok"}`))
+		Expect(fakeDB.PingCallCount()).To(Equal(0))
+	})
+
+	DescribeTable("readiness",
+		func(path string) {
+			rec := get(path)
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(MatchJSON(`{"status":"ok","message":"fern-mycelium is healthy 🍄"}`))
+			Expect(fakeDB.PingCallCount()).To(Equal(1))
+
+			ctx := fakeDB.PingArgsForCall(0)
+			_, hasDeadline := ctx.Deadline()
+			Expect(hasDeadline).To(BeTrue())
+
+			fakeDB.PingReturns(errors.New("connection refused"))
+			rec = get(path)
+			Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(rec.Body.String()).To(MatchJSON(`{"status":"degraded","error":"database unreachable"}`))
+		},
+		Entry("on /readyz", "/readyz"),
+		Entry("on /healthz", "/healthz"),
+	)
+})
+
+var _ = Describe("Probe authentication", func() {
+	var router http.Handler
+
+	BeforeEach(func() {
+		cfg := server.Config{APIKeys: []string{"secret"}, RateLimitRPS: 1, RateLimitBurst: 1}
+		router = mustNewRouter(cfg, &resolvers.Resolver{DB: &fakes.FakePinger{}},
+			metrics.New(prometheus.NewRegistry()), nil)
+	})
+
+	DescribeTable("should serve probes without an API key or rate limit",
+		func(path string) {
+			for range 3 {
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+				Expect(rec.Code).To(Equal(http.StatusOK))
+			}
+		},
+		Entry("on /livez", "/livez"),
+		Entry("on /readyz", "/readyz"),
+		Entry("on /healthz", "/healthz"),
+		Entry("on /metrics", "/metrics"),
+	)
+
+	It("should still require an API key on /query", func() {
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(`{"query":"{ health { status } }"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+})
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/guidewire-oss/fern-mycelium/internal/loader"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// FlakyTestLoader gives each request its own flaky test loader, so lookups
+// made while resolving one GraphQL document are batched together but never
+// shared with another request.
+func FlakyTestLoader(batcher repo.FlakyTestBatcher, wait time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		l := loader.NewFlakyTests(batcher, wait)
+		c.Request = c.Request.WithContext(loader.WithFlakyTests(c.Request.Context(), l))
+		c.Next()
+	}
+}
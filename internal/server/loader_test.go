@@ -0,0 +1,48 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/loader"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestLoader", func() {
+	It("should resolve flakyTests for several projects in one document with one batch", func() {
+		fakeFlaky := &fakes.FakeFlakyTestProvider{}
+		batcher := &fakes.FakeFlakyTestBatcher{}
+		batcher.GetFlakyTestsBatchReturns(map[string][]*gql.FlakyTest{
+			"demo":    {{TestName: "login"}},
+			"billing": {{TestName: "invoice"}},
+		}, nil)
+		router := mustNewRouter(server.Config{}, &resolvers.Resolver{
+			FlakyRepo:    loader.NewProvider(fakeFlaky),
+			FlakyBatcher: batcher,
+		}, metrics.New(prometheus.NewRegistry()), nil)
+
+		body := `{"query":"{ demo: flakyTests(limit: 5, projectID: \"demo\") { testName } ` +
+			`billing: flakyTests(limit: 5, projectID: \"billing\") { testName } }"}`
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(MatchJSON(`{"data":{` +
+			`"demo":[{"testName":"login"}],"billing":[{"testName":"invoice"}]}}`))
+		Expect(batcher.GetFlakyTestsBatchCallCount()).To(Equal(1))
+		_, projectIDs, _, _ := batcher.GetFlakyTestsBatchArgsForCall(0)
+		Expect(projectIDs).To(ConsistOf("demo", "billing"))
+		Expect(fakeFlaky.GetFlakyTestsCallCount()).To(BeZero())
+	})
+})
@@ -0,0 +1,169 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+var _ = Describe("MCP SSE transport", func() {
+	var (
+		baseURL   string
+		cancel    context.CancelFunc
+		done      chan error
+		fakeFlaky *fakes.FakeFlakyTestProvider
+	)
+
+	// openStream connects to the SSE endpoint and returns the parsed events.
+	openStream := func(ctx context.Context) (<-chan sseEvent, *http.Response) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/mcp/sse", nil)
+		Expect(err).ToNot(HaveOccurred())
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+		events := make(chan sseEvent, 8)
+		go func() {
+			defer close(events)
+			scanner := bufio.NewScanner(resp.Body)
+			var ev sseEvent
+			for scanner.Scan() {
+				line := scanner.Text()
+				switch {
+				case strings.HasPrefix(line, "event: "):
+					ev.name = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					ev.data = strings.TrimPrefix(line, "data: ")
+				case line == "":
+					events <- ev
+					ev = sseEvent{}
+				}
+			}
+		}()
+		return events, resp
+	}
+
+	BeforeEach(func() {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		baseURL = "http://" + ln.Addr().String()
+
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		sse := mcp.NewSSEHandler(mcp.NewServer(fakeFlaky), "/mcp/message")
+		router := mustNewRouter(server.Config{}, &resolvers.Resolver{FlakyRepo: fakeFlaky},
+			metrics.New(prometheus.NewRegistry()), sse)
+
+		ctx, stop := context.WithCancel(context.Background())
+		DeferCleanup(stop)
+		served := make(chan error, 1)
+		go func() {
+			served <- server.Serve(ctx, ln, router, sse.Close)
+		}()
+		cancel, done = stop, served
+	})
+
+	It("should stream the response to a tool call", func() {
+		fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{{TestID: "login", TestName: "login", RunCount: 2}}, nil)
+
+		events, resp := openStream(context.Background())
+		defer resp.Body.Close() //nolint:all
+
+		var endpoint sseEvent
+		Eventually(events).Should(Receive(&endpoint))
+		Expect(endpoint.name).To(Equal("endpoint"))
+		Expect(endpoint.data).To(HavePrefix("/mcp/message?sessionId="))
+
+		post, err := http.Post(baseURL+endpoint.data, "application/json", strings.NewReader(
+			`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"detect_flaky_tests","arguments":{"projectID":"demo"}}}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(post.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(post.Body.Close()).To(Succeed())
+
+		var message sseEvent
+		Eventually(events).Should(Receive(&message))
+		Expect(message.name).To(Equal("message"))
+
+		var rpc struct {
+			ID     int                `json:"id"`
+			Result mcp.CallToolResult `json:"result"`
+		}
+		Expect(json.Unmarshal([]byte(message.data), &rpc)).To(Succeed())
+		Expect(rpc.ID).To(Equal(7))
+		Expect(rpc.Result.Content[0].Text).To(MatchJSON(`{"tests":[{"testID":"login","testName":"login","passRate":0,"failureRate":0,"flakinessScore":0,"runCount":2,"isStale":false}]}`))
+	})
+
+	It("should forget the session once the client disconnects", func() {
+		streamCtx, closeStream := context.WithCancel(context.Background())
+		events, resp := openStream(streamCtx)
+
+		var endpoint sseEvent
+		Eventually(events).Should(Receive(&endpoint))
+		closeStream()
+		Expect(resp.Body.Close()).To(Succeed())
+
+		Eventually(func() int {
+			post, err := http.Post(baseURL+endpoint.data, "application/json",
+				strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(post.Body.Close()).To(Succeed())
+			return post.StatusCode
+		}).Should(Equal(http.StatusNotFound))
+	})
+
+	It("should end open streams when the server shuts down", func() {
+		events, resp := openStream(context.Background())
+		defer resp.Body.Close() //nolint:all
+		Eventually(events).Should(Receive())
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		Eventually(events).Should(BeClosed())
+	})
+})
+
+var _ = Describe("MCP SSE routes", func() {
+	It("should share the API key auth and rate limit of /query", func() {
+		fakeFlaky := &fakes.FakeFlakyTestProvider{}
+		sse := mcp.NewSSEHandler(mcp.NewServer(fakeFlaky), "/mcp/message")
+		DeferCleanup(sse.Close)
+		cfg := server.Config{APIKeys: []string{"secret"}, RateLimitRPS: 1, RateLimitBurst: 1}
+		router := mustNewRouter(cfg, &resolvers.Resolver{FlakyRepo: fakeFlaky},
+			metrics.New(prometheus.NewRegistry()), sse)
+
+		post := func(authorization string) int {
+			req := httptest.NewRequest(http.MethodPost, "/mcp/message?sessionId=unknown",
+				strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		Expect(post("")).To(Equal(http.StatusUnauthorized))
+		Expect(post("Bearer secret")).To(Equal(http.StatusNotFound))
+		Expect(post("Bearer secret")).To(Equal(http.StatusTooManyRequests))
+	})
+})
@@ -0,0 +1,183 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("Metrics", func() {
+	var (
+		m         *metrics.Metrics
+		fakeFlaky *fakes.FakeFlakyTestProvider
+		fakeDB    *fakes.FakePinger
+		router    http.Handler
+	)
+
+	query := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	BeforeEach(func() {
+		m = metrics.New(prometheus.NewRegistry())
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		fakeDB = &fakes.FakePinger{}
+		router = mustNewRouter(server.Config{}, &resolvers.Resolver{
+			FlakyRepo: m.InstrumentFlakyTests(fakeFlaky),
+			DB:        fakeDB,
+		}, m, nil)
+	})
+
+	It("should count resolver invocations and time flaky test queries", func() {
+		fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{{TestID: "a", TestName: "a"}}, nil)
+
+		rec := query(`{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"}`)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		Expect(testutil.ToFloat64(m.ResolverCalls.WithLabelValues("flakyTests"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(m.ResolverErrors.WithLabelValues("flakyTests"))).To(BeZero())
+		Expect(testutil.CollectAndCount(m.FlakyQueryDuration)).To(Equal(1))
+	})
+
+	It("should count resolver errors per operation", func() {
+		fakeFlaky.GetFlakyTestsReturns(nil, errors.New("connection refused"))
+
+		rec := query(`{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"}`)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		Expect(testutil.ToFloat64(m.ResolverCalls.WithLabelValues("flakyTests"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(m.ResolverErrors.WithLabelValues("flakyTests"))).To(Equal(1.0))
+	})
+
+	It("should expose Go and GraphQL metrics on /metrics", func() {
+		query(`{"query":"{ health { status } }"}`)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		body, err := io.ReadAll(rec.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("go_goroutines"))
+		Expect(string(body)).To(ContainSubstring(`mycelium_graphql_resolver_calls_total{operation="health"} 1`))
+	})
+})
+
+var _ = Describe("Flakiness metrics", func() {
+	var (
+		m            *metrics.Metrics
+		fakeFlaky    *fakes.FakeFlakyTestProvider
+		fakeCounter  *fakes.FakeFlakyTestCounter
+		fakeProjects *fakes.FakeProjectProvider
+		router       http.Handler
+	)
+
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		body, err := io.ReadAll(rec.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return string(body)
+	}
+
+	setup := func(cfg metrics.FlakinessConfig) {
+		m = metrics.New(prometheus.NewRegistry())
+		m.CollectFlakiness(fakeFlaky, fakeCounter, fakeProjects, cfg)
+		router = mustNewRouter(server.Config{}, &resolvers.Resolver{}, m, nil)
+	}
+
+	BeforeEach(func() {
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		fakeProjects = &fakes.FakeProjectProvider{}
+		fakeProjects.ListProjectsReturns([]*gql.Project{{ID: "1", Name: "demo"}, {ID: "2", Name: "billing"}}, nil)
+		fakeFlaky.GetFlakyTestsStub = func(_ context.Context, project string, _ int, _ repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+			if project == "demo" {
+				return []*gql.FlakyTest{
+					{TestName: "login", FailureRate: 0.5},
+					{TestName: "logout", FailureRate: 0.25},
+					{TestName: "signup", FailureRate: 0},
+				}, nil
+			}
+			return []*gql.FlakyTest{{TestName: "invoice", FailureRate: 0.1}}, nil
+		}
+		fakeCounter = &fakes.FakeFlakyTestCounter{}
+		fakeCounter.CountFlakyTestsStub = func(_ context.Context, project string, _ repo.FlakyTestOptions) (int, error) {
+			if project == "demo" {
+				return 2, nil
+			}
+			return 1, nil
+		}
+	})
+
+	It("should expose per-test failure rates and per-project counts", func() {
+		setup(metrics.FlakinessConfig{TTL: time.Minute, MaxSeries: 10})
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("# TYPE fern_flaky_failure_rate gauge"))
+		Expect(body).To(ContainSubstring(`fern_flaky_failure_rate{project="demo",test="login"} 0.5`))
+		Expect(body).To(ContainSubstring(`fern_flaky_failure_rate{project="demo",test="logout"} 0.25`))
+		Expect(body).To(ContainSubstring(`fern_flaky_failure_rate{project="billing",test="invoice"} 0.1`))
+		Expect(body).NotTo(ContainSubstring(`test="signup"`))
+		Expect(body).To(ContainSubstring("# TYPE fern_flaky_test_count gauge"))
+		Expect(body).To(ContainSubstring(`fern_flaky_test_count{project="demo"} 2`))
+		Expect(body).To(ContainSubstring(`fern_flaky_test_count{project="billing"} 1`))
+		Expect(body).To(ContainSubstring("fern_flaky_series_dropped 0"))
+	})
+
+	It("should stop adding failure-rate series at the cap", func() {
+		setup(metrics.FlakinessConfig{TTL: time.Minute, MaxSeries: 2})
+
+		body := scrape()
+		Expect(strings.Count(body, "fern_flaky_failure_rate{")).To(Equal(2))
+		Expect(body).To(ContainSubstring(`fern_flaky_test_count{project="billing"} 1`))
+		Expect(body).To(ContainSubstring("fern_flaky_series_dropped 1"))
+	})
+
+	It("should count every failing test, not only those read for the failure-rate series", func() {
+		fakeCounter.CountFlakyTestsReturns(250, nil)
+		setup(metrics.FlakinessConfig{TTL: time.Minute, MaxSeries: 10})
+
+		Expect(scrape()).To(ContainSubstring(`fern_flaky_test_count{project="demo"} 250`))
+		_, project, opts := fakeCounter.CountFlakyTestsArgsForCall(0)
+		Expect(project).To(Equal("demo"))
+		Expect(opts.MinFailureRate).To(BeNumerically(">", 0))
+	})
+
+	It("should reuse results across scrapes within the TTL", func() {
+		setup(metrics.FlakinessConfig{TTL: time.Hour, MaxSeries: 10})
+
+		scrape()
+		scrape()
+		Expect(fakeProjects.ListProjectsCallCount()).To(Equal(1))
+		Expect(fakeFlaky.GetFlakyTestsCallCount()).To(Equal(2))
+	})
+
+	It("should keep serving the last snapshot when a refresh fails", func() {
+		setup(metrics.FlakinessConfig{MaxSeries: 10})
+		Expect(scrape()).To(ContainSubstring(`fern_flaky_test_count{project="demo"} 2`))
+
+		fakeProjects.ListProjectsReturns(nil, errors.New("db down"))
+		Expect(scrape()).To(ContainSubstring(`fern_flaky_test_count{project="demo"} 2`))
+		Expect(fakeProjects.ListProjectsCallCount()).To(Equal(2))
+	})
+})
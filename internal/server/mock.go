@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// errNotInMockMode is returned for root fields that MOCK_DATA does not serve.
+var errNotInMockMode = errors.New("not available in mock mode")
+
+// errNotInSQLiteMode is returned for root fields that the SQLite storage
+// backend does not serve.
+var errNotInSQLiteMode = errors.New("not available with the sqlite storage backend")
+
+// flakyTestFields are the root fields answered with MOCK_DATA or the SQLite
+// backend, which only wire flaky tests and their count into the resolver.
+var flakyTestFields = map[string]bool{
+	"flakyTests":      true,
+	"flakyTestsPage":  true,
+	"flakyTestAlerts": true,
+	"health":          true,
+	"capabilities":    true,
+}
+
+// flakyTestFieldsOnly fails every other root field with errUnserved instead
+// of letting its resolver call a repository that was never wired.
+func flakyTestFieldsOnly(errUnserved error) graphql.FieldMiddleware {
+	return func(ctx context.Context, next graphql.Resolver) (any, error) {
+		fc := graphql.GetFieldContext(ctx)
+		switch fc.Object {
+		case "Query", "Mutation", "Subscription":
+			name := fc.Field.Name
+			if !flakyTestFields[name] && !strings.HasPrefix(name, "__") {
+				return nil, fmt.Errorf("%s is %w", name, errUnserved)
+			}
+		}
+		return next(ctx)
+	}
+}
@@ -0,0 +1,74 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/internal/storage"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+var _ = Describe("Mock mode", func() {
+	type response struct {
+		Data   map[string]any `json:"data"`
+		Errors []struct {
+			Message    string         `json:"message"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"errors"`
+	}
+
+	mockMode := server.Config{MockData: true, Introspection: true}
+
+	query := func(cfg server.Config, body string) response {
+		mock := repo.NewMockFlakyTestRepo()
+		router := mustNewRouter(cfg,
+			&resolvers.Resolver{FlakyRepo: mock, FlakyCounter: mock}, metrics.New(prometheus.NewRegistry()), nil)
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		var resp response
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+
+	It("should serve the sample flaky tests and introspection", func() {
+		resp := query(mockMode, `{"query":"{ flakyTestsPage(limit: 2, projectID: \"demo\") { totalCount } health { status } __typename }"}`)
+		Expect(resp.Errors).To(BeEmpty())
+		Expect(resp.Data).To(HaveKeyWithValue("health", HaveKeyWithValue("status", "ok")))
+		Expect(resp.Data).To(HaveKeyWithValue("__typename", "Query"))
+	})
+
+	It("should code queries that need the database as unimplemented", func() {
+		resp := query(mockMode, `{"query":"{ slowestTests(limit: 5, projectID: \"demo\") { testName } }"}`)
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Message).To(Equal("slowestTests is not available in mock mode"))
+		Expect(resp.Errors[0].Extensions).To(HaveKeyWithValue("code", server.ErrorCodeUnimplemented))
+	})
+
+	It("should code mutations as unimplemented", func() {
+		resp := query(mockMode, `{"query":"mutation { quarantineTest(projectID: \"demo\", testName: \"login\") { id } }"}`)
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Extensions).To(HaveKeyWithValue("code", server.ErrorCodeUnimplemented))
+	})
+
+	It("should code queries that need Postgres as unimplemented with the SQLite backend", func() {
+		sqliteMode := server.Config{StorageBackend: storage.SQLite}
+		Expect(query(sqliteMode, `{"query":"{ flakyTests(limit: 2, projectID: \"demo\") { testName } }"}`).Errors).To(BeEmpty())
+
+		resp := query(sqliteMode, `{"query":"{ flakyTestsConnection(first: 2, projectID: \"demo\") { pageInfo { hasNextPage } } }"}`)
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Message).To(Equal("flakyTestsConnection is not available with the sqlite storage backend"))
+		Expect(resp.Errors[0].Extensions).To(HaveKeyWithValue("code", server.ErrorCodeUnimplemented))
+	})
+})
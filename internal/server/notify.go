@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/notify"
+)
+
+// startNotifiers runs a watcher for each configured notification sink until
+// the returned stop function is called or ctx is done. stop waits for the
+// watchers to return.
+func startNotifiers(ctx context.Context, cfg Config, resolver *resolvers.Resolver) (stop func()) {
+	var watchers []*notify.Watcher
+	if cfg.SlackWebhookURL != "" {
+		watchers = append(watchers, notify.NewWatcher(resolver.FlakyRepo, resolver.ProjectRepo,
+			notify.NewSlackNotifier(cfg.SlackWebhookURL), cfg.NotifyInterval, cfg.FlakyAlertThreshold))
+		slog.Info("🔔 Slack notifications enabled", "interval", cfg.NotifyInterval)
+	}
+	if cfg.GitHubIssues {
+		issues := notify.NewGitHubIssues(cfg.GitHubRepository, cfg.GitHubToken, notify.WithGitHubAPIURL(cfg.GitHubAPIURL))
+		watchers = append(watchers, notify.NewWatcher(resolver.FlakyRepo, resolver.ProjectRepo,
+			issues, cfg.NotifyInterval, notify.HighRiskFailureRate, notify.WithEveryScan()))
+		slog.Info("🐙 GitHub issues enabled for high-risk flaky tests", "repository", cfg.GitHubRepository)
+	}
+	if len(cfg.WebhookURLs) > 0 {
+		webhooks := notify.NewWebhookNotifier(cfg.WebhookURLs,
+			notify.WithWebhookSecret(cfg.WebhookSecret), notify.WithWebhookTimeout(cfg.WebhookTimeout))
+		watchers = append(watchers, notify.NewWatcher(resolver.FlakyRepo, resolver.ProjectRepo,
+			webhooks, cfg.NotifyInterval, cfg.WebhookThreshold))
+		slog.Info("📡 Webhook notifications enabled", "urls", len(cfg.WebhookURLs), "signed", cfg.WebhookSecret != "")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for _, watcher := range watchers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			watcher.Run(ctx)
+		}()
+	}
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
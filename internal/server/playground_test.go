@@ -0,0 +1,54 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+)
+
+var _ = Describe("GraphQL playground", func() {
+	get := func(cfg server.Config, path, authorization string) *httptest.ResponseRecorder {
+		router := mustNewRouter(cfg, &resolvers.Resolver{}, metrics.New(prometheus.NewRegistry()), nil)
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should serve the playground at the configured path", func() {
+		rec := get(server.Config{PlaygroundPath: "/playground"}, "/playground", "")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(ContainSubstring("Mycelium GraphQL Playground"))
+
+		Expect(get(server.Config{PlaygroundPath: "/playground"}, "/graphql", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should answer 404 when the playground is disabled", func() {
+		Expect(get(server.Config{}, "/graphql", "").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should stay open without API keys when auth is not required", func() {
+		cfg := server.Config{PlaygroundPath: "/graphql", APIKeys: []string{"alpha"}}
+		Expect(get(cfg, "/graphql", "").Code).To(Equal(http.StatusOK))
+	})
+
+	It("should require an API key when protected", func() {
+		cfg := server.Config{PlaygroundPath: "/graphql", PlaygroundAuth: true, APIKeys: []string{"alpha"}}
+
+		rec := get(cfg, "/graphql", "")
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		Expect(rec.Body.String()).To(MatchJSON(`{"error":"missing bearer token"}`))
+		Expect(get(cfg, "/graphql", "Bearer beta").Code).To(Equal(http.StatusUnauthorized))
+		Expect(get(cfg, "/graphql", "Bearer alpha").Code).To(Equal(http.StatusOK))
+	})
+})
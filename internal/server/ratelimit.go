@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitIdleTTL is how long a client's bucket is kept after its last
+// request. A bucket idle this long has refilled, so dropping it is harmless.
+const rateLimitIdleTTL = 10 * time.Minute
+
+// RateLimit allows each client rps requests per second on average, with
+// bursts of up to burst requests, answering the rest with 429 and a
+// Retry-After header. A batch counts one request per operation. Clients are
+// keyed by the API key accepted by APIKeyAuth, which must run first, or by
+// client IP when authentication is disabled. A non-positive rps disables the
+// limit; a non-positive burst defaults to rps rounded up.
+func RateLimit(rps float64, burst int) gin.HandlerFunc {
+	if rps <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(rps))
+	}
+
+	limiters := &clientLimiters{
+		limit:   rate.Limit(rps),
+		burst:   burst,
+		clients: map[string]*clientLimiter{},
+	}
+	return func(c *gin.Context) {
+		now := time.Now()
+		limiter := limiters.get(clientKey(c), now)
+		first, delay := reserve(limiter, now, 1)
+		if delay > 0 {
+			writeRateLimited(c.Writer, delay)
+			c.Abort()
+			return
+		}
+		// Further tokens are reserved together with the first one, so a
+		// request rejected for them does not count against the bucket either.
+		charge := rateLimitCharge(func(n int) time.Duration {
+			first.CancelAt(now)
+			_, delay := reserve(limiter, now, n+1)
+			return delay
+		})
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), rateLimitChargeKey{}, charge))
+		c.Next()
+	}
+}
+
+// rateLimitCharge takes n more tokens from the bucket of a request's client.
+type rateLimitCharge func(n int) time.Duration
+
+type rateLimitChargeKey struct{}
+
+// chargeRateLimit takes n more tokens from the bucket RateLimit charged r
+// to, for requests costing more than one, such as batches. It returns how
+// long the client must wait when the bucket cannot cover them, and zero when
+// r is not rate limited.
+func chargeRateLimit(r *http.Request, n int) time.Duration {
+	charge, ok := r.Context().Value(rateLimitChargeKey{}).(rateLimitCharge)
+	if !ok || n <= 0 {
+		return 0
+	}
+	return charge(n)
+}
+
+// reserve takes n tokens from limiter at now, or none when they are not
+// available, returning how long until they would be. The delay is
+// rate.InfDuration when n exceeds the burst, as the tokens never will be.
+func reserve(limiter *rate.Limiter, now time.Time, n int) (*rate.Reservation, time.Duration) {
+	reservation := limiter.ReserveN(now, n)
+	if !reservation.OK() {
+		return reservation, rate.InfDuration
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return reservation, delay
+	}
+	return reservation, 0
+}
+
+// writeRateLimited answers 429, with a Retry-After header when waiting delay
+// lets the request through.
+func writeRateLimited(w http.ResponseWriter, delay time.Duration) {
+	if delay != rate.InfDuration {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write([]byte(`{"error":"rate limit exceeded"}`))
+}
+
+// clientKey identifies the caller by API key when one was accepted, so
+// clients behind a shared proxy get separate buckets, and by IP otherwise.
+// X-Forwarded-For only counts when sent by one of the router's trusted
+// proxies, so clients cannot spoof their way into a fresh bucket.
+func clientKey(c *gin.Context) string {
+	if key := c.GetString(apiKeyContextKey); key != "" {
+		return "key:" + key
+	}
+	return "ip:" + c.ClientIP()
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// clientLimiters holds one token bucket per client, dropping idle ones.
+type clientLimiters struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+func (l *clientLimiters) get(key string, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > rateLimitIdleTTL {
+		for k, client := range l.clients {
+			if now.Sub(client.lastSeen) > rateLimitIdleTTL {
+				delete(l.clients, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	client, ok := l.clients[key]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[key] = client
+	}
+	client.lastSeen = now
+	return client.limiter
+}
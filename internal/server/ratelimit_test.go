@@ -0,0 +1,165 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+)
+
+var _ = Describe("RateLimit", func() {
+	newRouter := func(keys []string, rps float64, burst int) *gin.Engine {
+		router := gin.New()
+		router.POST("/query", server.APIKeyAuth(keys), server.RateLimit(rps, burst), func(c *gin.Context) {
+			c.String(http.StatusOK, "ok")
+		})
+		return router
+	}
+
+	send := func(router *gin.Engine, remoteAddr, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		req.RemoteAddr = remoteAddr
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should answer 429 with Retry-After once a burst drains the bucket", func() {
+		router := newRouter(nil, 0.5, 3)
+
+		for range 3 {
+			Expect(send(router, "10.0.0.1:1234", "").Code).To(Equal(http.StatusOK))
+		}
+		rec := send(router, "10.0.0.1:1234", "")
+		Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rec.Header().Get("Retry-After")).To(Equal("2"))
+		Expect(rec.Body.String()).To(MatchJSON(`{"error":"rate limit exceeded"}`))
+	})
+
+	It("should keep a separate bucket per client IP", func() {
+		router := newRouter(nil, 1, 1)
+
+		Expect(send(router, "10.0.0.1:1234", "").Code).To(Equal(http.StatusOK))
+		Expect(send(router, "10.0.0.1:5678", "").Code).To(Equal(http.StatusTooManyRequests))
+		Expect(send(router, "10.0.0.2:1234", "").Code).To(Equal(http.StatusOK))
+	})
+
+	It("should key authenticated clients by API key rather than IP", func() {
+		router := newRouter([]string{"alpha", "beta"}, 1, 1)
+
+		Expect(send(router, "10.0.0.1:1234", "Bearer alpha").Code).To(Equal(http.StatusOK))
+		Expect(send(router, "10.0.0.1:1234", "Bearer alpha").Code).To(Equal(http.StatusTooManyRequests))
+		Expect(send(router, "10.0.0.1:1234", "Bearer beta").Code).To(Equal(http.StatusOK))
+	})
+
+	It("should refill the bucket over time", func() {
+		router := newRouter(nil, 50, 1)
+
+		Expect(send(router, "10.0.0.1:1234", "").Code).To(Equal(http.StatusOK))
+		Expect(send(router, "10.0.0.1:1234", "").Code).To(Equal(http.StatusTooManyRequests))
+		Eventually(func() int {
+			return send(router, "10.0.0.1:1234", "").Code
+		}).WithPolling(10 * time.Millisecond).Should(Equal(http.StatusOK))
+	})
+
+	It("should not count rejected requests against the bucket", func() {
+		router := newRouter(nil, 50, 1)
+
+		Expect(send(router, "10.0.0.1:1234", "").Code).To(Equal(http.StatusOK))
+		for range 5 {
+			Expect(send(router, "10.0.0.1:1234", "").Code).To(Equal(http.StatusTooManyRequests))
+		}
+		time.Sleep(25 * time.Millisecond)
+		Expect(send(router, "10.0.0.1:1234", "").Code).To(Equal(http.StatusOK))
+	})
+
+	Context("behind a proxy", func() {
+		sendVia := func(router *gin.Engine, forwardedFor string) int {
+			req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(`{"query":"{ health { status } }"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+			req.RemoteAddr = "10.0.0.1:1234"
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		newServer := func(trustedProxies []string) *gin.Engine {
+			cfg := server.Config{RateLimitRPS: 1, RateLimitBurst: 1, TrustedProxies: trustedProxies}
+			return mustNewRouter(cfg, &resolvers.Resolver{}, metrics.New(prometheus.NewRegistry()), nil)
+		}
+
+		It("should ignore X-Forwarded-For from untrusted peers", func() {
+			router := newServer(nil)
+
+			Expect(sendVia(router, "203.0.113.1")).To(Equal(http.StatusOK))
+			Expect(sendVia(router, "203.0.113.2")).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("should key on X-Forwarded-For from trusted proxies", func() {
+			router := newServer([]string{"10.0.0.0/8"})
+
+			Expect(sendVia(router, "203.0.113.1")).To(Equal(http.StatusOK))
+			Expect(sendVia(router, "203.0.113.2")).To(Equal(http.StatusOK))
+			Expect(sendVia(router, "203.0.113.1")).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+
+	Context("with batched operations", func() {
+		sendBatch := func(router *gin.Engine, operations int) *httptest.ResponseRecorder {
+			ops := make([]string, operations)
+			for i := range ops {
+				ops[i] = `{"query":"{ health { status } }"}`
+			}
+			req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader("["+strings.Join(ops, ",")+"]"))
+			req.Header.Set("Content-Type", "application/json")
+			req.RemoteAddr = "10.0.0.1:1234"
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			return rec
+		}
+
+		newServer := func(burst int) *gin.Engine {
+			cfg := server.Config{RateLimitRPS: 0.5, RateLimitBurst: burst}
+			return mustNewRouter(cfg, &resolvers.Resolver{}, metrics.New(prometheus.NewRegistry()), nil)
+		}
+
+		It("should charge one token per operation", func() {
+			router := newServer(3)
+
+			Expect(sendBatch(router, 2).Code).To(Equal(http.StatusOK))
+			rec := sendBatch(router, 2)
+			Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(rec.Header().Get("Retry-After")).To(Equal("2"))
+			Expect(rec.Body.String()).To(MatchJSON(`{"error":"rate limit exceeded"}`))
+			Expect(sendBatch(router, 1).Code).To(Equal(http.StatusOK))
+		})
+
+		It("should reject a batch larger than the burst without Retry-After", func() {
+			rec := sendBatch(newServer(3), 5)
+
+			Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(rec.Header().Get("Retry-After")).To(BeEmpty())
+		})
+	})
+
+	It("should allow every request when disabled", func() {
+		router := newRouter(nil, 0, 0)
+
+		for range 20 {
+			Expect(send(router, "10.0.0.1:1234", "").Code).To(Equal(http.StatusOK))
+		}
+	})
+})
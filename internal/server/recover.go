@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gin-gonic/gin"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// RecoverPanic is the default graphql.RecoverFunc. It logs the panic with
+// its stack and returns a generic error, so a failing resolver does not
+// take down the process or show its internals to the client.
+func RecoverPanic(ctx context.Context, err any) error {
+	attrs := []any{"panic", fmt.Sprint(err), "stack", string(debug.Stack())}
+	if fc := graphql.GetFieldContext(ctx); fc != nil {
+		attrs = append(attrs, "path", fc.Path().String())
+	}
+	slog.ErrorContext(ctx, "❌ GraphQL resolver panicked", attrs...)
+	return &gqlerror.Error{
+		Message:    internalErrorMessage,
+		Extensions: map[string]any{"code": ErrorCodeInternal},
+	}
+}
+
+// Recovery returns middleware that turns a panic in a later handler into a
+// 500 response, logging the panic with its stack.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		slog.ErrorContext(c.Request.Context(), "❌ HTTP handler panicked",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"panic", fmt.Sprint(err),
+			"stack", string(debug.Stack()))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": internalErrorMessage})
+	})
+}
@@ -0,0 +1,116 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("Panic recovery", func() {
+	var (
+		cfg       server.Config
+		logs      *bytes.Buffer
+		fakeFlaky *fakes.FakeFlakyTestProvider
+	)
+
+	BeforeEach(func() {
+		cfg = server.Config{}
+		logs = &bytes.Buffer{}
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewJSONHandler(logs, nil)))
+		DeferCleanup(func() { slog.SetDefault(previous) })
+
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		fakeFlaky.GetFlakyTestsStub = func(context.Context, string, int, repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+			panic("resolver exploded")
+		}
+	})
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		router := mustNewRouter(cfg, &resolvers.Resolver{FlakyRepo: fakeFlaky},
+			metrics.New(prometheus.NewRegistry()), nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	// logEntry returns the logged record with the given message.
+	logEntry := func(msg string) map[string]any {
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var entry map[string]any
+			Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+			if entry["msg"] == msg {
+				return entry
+			}
+		}
+		Fail("no log entry " + msg + " in " + logs.String())
+		return nil
+	}
+	query := func() *httptest.ResponseRecorder {
+		body := `{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"}`
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return serve(req)
+	}
+
+	It("should return a generic GraphQL error and log the stack when a resolver panics", func() {
+		rec := query()
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).NotTo(ContainSubstring("resolver exploded"))
+		Expect(rec.Body.String()).NotTo(ContainSubstring("goroutine"))
+
+		var resp struct {
+			Errors []struct {
+				Message    string         `json:"message"`
+				Path       []any          `json:"path"`
+				Extensions map[string]any `json:"extensions"`
+			} `json:"errors"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Message).To(Equal("internal server error"))
+		Expect(resp.Errors[0].Path).To(Equal([]any{"flakyTests"}))
+		Expect(resp.Errors[0].Extensions).To(HaveKeyWithValue("code", server.ErrorCodeInternal))
+
+		entry := logEntry("❌ GraphQL resolver panicked")
+		Expect(entry).To(HaveKeyWithValue("panic", "resolver exploded"))
+		Expect(entry).To(HaveKeyWithValue("path", "flakyTests"))
+		Expect(entry["stack"]).To(ContainSubstring("goroutine"))
+	})
+
+	It("should use the configured recover func", func() {
+		var recovered any
+		cfg.RecoverFunc = func(_ context.Context, err any) error {
+			recovered = err
+			return errors.New("recovered in test")
+		}
+
+		Expect(query().Body.String()).To(ContainSubstring(`"message":"recovered in test"`))
+		Expect(recovered).To(Equal("resolver exploded"))
+	})
+
+	It("should answer 500 and log the stack when an HTTP handler panics", func() {
+		rec := serve(httptest.NewRequest(http.MethodGet, "/export/flaky.csv?project=demo", nil))
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		Expect(rec.Body.String()).To(MatchJSON(`{"error":"internal server error"}`))
+
+		entry := logEntry("❌ HTTP handler panicked")
+		Expect(entry).To(HaveKeyWithValue("path", "/export/flaky.csv"))
+		Expect(entry["stack"]).To(ContainSubstring("goroutine"))
+	})
+})
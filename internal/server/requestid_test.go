@@ -0,0 +1,104 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/logging"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("Request IDs", func() {
+	var logs *bytes.Buffer
+
+	BeforeEach(func() {
+		logs = &bytes.Buffer{}
+		previous := slog.Default()
+		slog.SetDefault(slog.New(logging.NewContextHandler(
+			slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+		DeferCleanup(func() { slog.SetDefault(previous) })
+	})
+
+	// query sends a flakyTests query backed by a repository whose database
+	// fails, so both the repo layer and the error presenter see the request.
+	query := func(requestID string) *httptest.ResponseRecorder {
+		db := &fakes.FakePgxQuerier{}
+		db.QueryStub = func(context.Context, string, ...any) (pgx.Rows, error) {
+			return nil, errors.New("relation does not exist")
+		}
+		flakyRepo := repo.NewFlakyTestRepo(db, repo.WithQueryLogging(repo.DefaultSlowQueryThreshold))
+		router := mustNewRouter(server.Config{}, &resolvers.Resolver{FlakyRepo: flakyRepo},
+			metrics.New(prometheus.NewRegistry()), nil)
+
+		body := `{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"}`
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if requestID != "" {
+			req.Header.Set(server.RequestIDHeader, requestID)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	// loggedIDs maps each logged message to the request ID it carried.
+	loggedIDs := func() map[string]any {
+		ids := map[string]any{}
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var entry map[string]any
+			Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+			ids[entry["msg"].(string)] = entry[logging.RequestIDKey]
+		}
+		return ids
+	}
+	errorRequestID := func(rec *httptest.ResponseRecorder) any {
+		var resp struct {
+			Errors []struct {
+				Extensions map[string]any `json:"extensions"`
+			} `json:"errors"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp.Errors).To(HaveLen(1))
+		return resp.Errors[0].Extensions[logging.RequestIDKey]
+	}
+
+	It("should propagate the caller's request ID to the response, logs and errors", func() {
+		rec := query("ci-build-42")
+		Expect(rec.Header().Get(server.RequestIDHeader)).To(Equal("ci-build-42"))
+		Expect(errorRequestID(rec)).To(Equal("ci-build-42"))
+
+		ids := loggedIDs()
+		Expect(ids).To(HaveKeyWithValue("sql statement", "ci-build-42"))
+		Expect(ids).To(HaveKeyWithValue("sql query", "ci-build-42"))
+		Expect(ids).To(HaveKeyWithValue("graphql request", "ci-build-42"))
+	})
+
+	It("should generate a request ID when the caller sends none", func() {
+		rec := query("")
+		id := rec.Header().Get(server.RequestIDHeader)
+		Expect(id).To(MatchRegexp(`^[0-9a-f]{32}$`))
+		Expect(errorRequestID(rec)).To(Equal(id))
+		Expect(loggedIDs()).To(HaveKeyWithValue("sql statement", id))
+	})
+
+	It("should replace request IDs that are too long or contain control characters", func() {
+		for _, bad := range []string{strings.Repeat("a", 129), "forged\x01line"} {
+			rec := query(bad)
+			Expect(rec.Header().Get(server.RequestIDHeader)).To(MatchRegexp(`^[0-9a-f]{32}$`))
+		}
+	})
+})
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// REST routes for clients that cannot speak GraphQL.
+const (
+	restFlakyTestsPath = "/api/v1/projects/:project/flaky-tests"
+	openAPIPath        = "/api/v1/openapi.json"
+)
+
+// restLimits returns the default and largest limit of the REST endpoint,
+// the same as the flakyTests query's.
+func restLimits(resolver *resolvers.Resolver) (defaultLimit, maxLimit int) {
+	defaultLimit, maxLimit = resolver.DefaultLimit, resolver.MaxLimit
+	if defaultLimit <= 0 {
+		defaultLimit = resolvers.DefaultLimit
+	}
+	if maxLimit <= 0 {
+		maxLimit = resolvers.DefaultMaxLimit
+	}
+	return defaultLimit, maxLimit
+}
+
+// flakyTestsRESTHandler serves GET /api/v1/projects/{project}/flaky-tests,
+// writing the project's flaky tests as a JSON array. Bad arguments are
+// answered with 400 and an unreachable database with 503, so clients can
+// tell a request worth retrying from one that never will succeed.
+func flakyTestsRESTHandler(flaky repo.FlakyTestProvider, defaultLimit, maxLimit int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		project := c.Param("project")
+
+		limit := defaultLimit
+		if raw := c.Query("limit"); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil || v < 1 || v > maxLimit {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be an integer between 1 and " + strconv.Itoa(maxLimit)})
+				return
+			}
+			limit = v
+		}
+
+		tests, err := flaky.GetFlakyTests(c.Request.Context(), project, limit, repo.FlakyTestOptions{})
+		switch {
+		case errors.Is(err, repo.ErrInvalidArgument):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, repo.ErrUnavailable):
+			slog.WarnContext(c.Request.Context(), "⚠️ Flaky test REST request failed", "project", project, "error", err)
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "database unavailable"})
+			return
+		case err != nil:
+			slog.ErrorContext(c.Request.Context(), "❌ Flaky test REST request failed", "project", project, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load flaky tests"})
+			return
+		}
+
+		if tests == nil {
+			tests = []*gql.FlakyTest{}
+		}
+		c.JSON(http.StatusOK, tests)
+	}
+}
+
+// openAPIHandler serves GET /api/v1/openapi.json with a spec encoded once.
+func openAPIHandler(spec []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+	}
+}
+
+// openAPISpec describes the REST endpoints as an OpenAPI 3 document. The
+// response schemas are generated from the GraphQL object types the
+// endpoints return, so the two cannot drift apart.
+func openAPISpec(schema *ast.Schema, defaultLimit, maxLimit int) ([]byte, error) {
+	components := map[string]any{}
+	addOpenAPISchema(schema, "FlakyTest", components)
+	components["Error"] = map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"error": map[string]any{"type": "string"}},
+		"required":   []string{"error"},
+	}
+
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/Error"},
+			}},
+		}
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "fern-mycelium REST API",
+			"version": "v1",
+		},
+		"paths": map[string]any{
+			"/api/v1/projects/{project}/flaky-tests": map[string]any{
+				"get": map[string]any{
+					"operationId": "listFlakyTests",
+					"summary":     "List a project's flaky tests, most often failing first",
+					"parameters": []any{
+						map[string]any{
+							"name": "project", "in": "path", "required": true,
+							"description": "Project name or UUID",
+							"schema":      map[string]any{"type": "string"},
+						},
+						map[string]any{
+							"name": "limit", "in": "query", "required": false,
+							"description": "Most tests to return",
+							"schema": map[string]any{
+								"type": "integer", "minimum": 1, "maximum": maxLimit, "default": defaultLimit,
+							},
+						},
+					},
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": "The flaky tests",
+							"content": map[string]any{"application/json": map[string]any{
+								"schema": map[string]any{
+									"type":  "array",
+									"items": map[string]any{"$ref": "#/components/schemas/FlakyTest"},
+								},
+							}},
+						},
+						"400": errorResponse("The limit is invalid"),
+						"401": errorResponse("The API key is missing or invalid"),
+						"429": errorResponse("Too many requests"),
+						"500": errorResponse("The flaky tests could not be loaded"),
+						"503": errorResponse("The database is unavailable"),
+					},
+				},
+			},
+		},
+		"components": map[string]any{
+			"schemas": components,
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []any{map[string]any{"apiKey": []string{}}},
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// addOpenAPISchema adds the JSON schema of the GraphQL object type name, and
+// of the object types its fields refer to, to components.
+func addOpenAPISchema(schema *ast.Schema, name string, components map[string]any) {
+	if _, done := components[name]; done {
+		return
+	}
+	def := schema.Types[name]
+	if def == nil {
+		return
+	}
+	properties := map[string]any{}
+	required := []string{}
+	// Claim the name before descending, in case the type refers to itself.
+	components[name] = nil
+	for _, field := range def.Fields {
+		if len(field.Arguments) > 0 {
+			continue
+		}
+		properties[field.Name] = openAPIType(schema, field.Type, field.Description, components)
+		if field.Type.NonNull {
+			required = append(required, field.Name)
+		}
+	}
+
+	object := map[string]any{"type": "object", "properties": properties}
+	if def.Description != "" {
+		object["description"] = def.Description
+	}
+	if len(required) > 0 {
+		object["required"] = required
+	}
+	components[name] = object
+}
+
+// openAPIType maps a GraphQL field type to a JSON schema.
+func openAPIType(schema *ast.Schema, t *ast.Type, description string, components map[string]any) map[string]any {
+	var out map[string]any
+	switch {
+	case t.Elem != nil:
+		out = map[string]any{"type": "array", "items": openAPIType(schema, t.Elem, "", components)}
+	case t.NamedType == "Int":
+		out = map[string]any{"type": "integer"}
+	case t.NamedType == "Float":
+		out = map[string]any{"type": "number"}
+	case t.NamedType == "Boolean":
+		out = map[string]any{"type": "boolean"}
+	case schema.Types[t.NamedType] != nil && schema.Types[t.NamedType].Kind == ast.Object:
+		addOpenAPISchema(schema, t.NamedType, components)
+		out = map[string]any{"$ref": "#/components/schemas/" + t.NamedType}
+	default:
+		// String, ID, enums and custom scalars are all sent as strings.
+		out = map[string]any{"type": "string"}
+	}
+	if _, ref := out["$ref"]; ref && (description != "" || !t.NonNull) {
+		// OpenAPI 3.0 ignores the siblings of $ref, so wrap it.
+		out = map[string]any{"allOf": []any{out}}
+	}
+	if description != "" {
+		out["description"] = description
+	}
+	if !t.NonNull {
+		out["nullable"] = true
+	}
+	return out
+}
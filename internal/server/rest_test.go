@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("REST API", func() {
+	var (
+		fakeFlaky *fakes.FakeFlakyTestProvider
+		router    *gin.Engine
+	)
+
+	BeforeEach(func() {
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		router = mustNewRouter(server.Config{}, &resolvers.Resolver{FlakyRepo: fakeFlaky, DefaultLimit: 10, MaxLimit: 50},
+			metrics.New(prometheus.NewRegistry()), nil)
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	errorBody := func(rec *httptest.ResponseRecorder) string {
+		var body struct {
+			Error string `json:"error"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &body)).To(Succeed())
+		return body.Error
+	}
+
+	Describe("GET /api/v1/projects/{project}/flaky-tests", func() {
+		It("should return the project's flaky tests as JSON", func() {
+			lastFailure := "2026-10-01T12:00:00Z"
+			fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{
+				{TestID: "login", TestName: "login", PassRate: 0.75, FailureRate: 0.25, RunCount: 8, LastFailure: &lastFailure},
+			}, nil)
+
+			rec := get("/api/v1/projects/demo/flaky-tests?limit=5")
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Type")).To(HavePrefix("application/json"))
+			Expect(rec.Body.String()).To(MatchJSON(`[{"testID":"login","testName":"login","passRate":0.75,"failureRate":0.25,"flakinessScore":0,"lastFailure":"2026-10-01T12:00:00Z","runCount":8,"isStale":false}]`))
+
+			_, project, limit, opts := fakeFlaky.GetFlakyTestsArgsForCall(0)
+			Expect(project).To(Equal("demo"))
+			Expect(limit).To(Equal(5))
+			Expect(opts).To(Equal(repo.FlakyTestOptions{}))
+		})
+
+		It("should use the default limit when none is given", func() {
+			rec := get("/api/v1/projects/demo/flaky-tests")
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(MatchJSON(`[]`))
+			_, _, limit, _ := fakeFlaky.GetFlakyTestsArgsForCall(0)
+			Expect(limit).To(Equal(10))
+		})
+
+		DescribeTable("should reject a bad limit with 400",
+			func(limit string) {
+				rec := get("/api/v1/projects/demo/flaky-tests?limit=" + limit)
+
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(errorBody(rec)).To(Equal("limit must be an integer between 1 and 50"))
+				Expect(fakeFlaky.GetFlakyTestsCallCount()).To(BeZero())
+			},
+			Entry("zero", "0"),
+			Entry("above the maximum", "51"),
+			Entry("not a number", "ten"),
+		)
+
+		DescribeTable("should map provider errors to status codes",
+			func(err error, status int, message string) {
+				fakeFlaky.GetFlakyTestsReturns(nil, err)
+
+				rec := get("/api/v1/projects/demo/flaky-tests")
+
+				Expect(rec.Code).To(Equal(status))
+				Expect(errorBody(rec)).To(Equal(message))
+			},
+			Entry("invalid arguments", repo.InvalidArgumentf("project is required"), http.StatusBadRequest, "project is required"),
+			Entry("an unreachable database", fmt.Errorf("dial tcp 10.0.0.5:5432: %w", repo.ErrUnavailable), http.StatusServiceUnavailable, "database unavailable"),
+			Entry("anything else", errors.New("syntax error at or near SELECT"), http.StatusInternalServerError, "failed to load flaky tests"),
+		)
+
+		It("should require an API key when keys are configured", func() {
+			router = mustNewRouter(server.Config{APIKeys: []string{"secret"}}, &resolvers.Resolver{FlakyRepo: fakeFlaky},
+				metrics.New(prometheus.NewRegistry()), nil)
+
+			Expect(get("/api/v1/projects/demo/flaky-tests").Code).To(Equal(http.StatusUnauthorized))
+			Expect(fakeFlaky.GetFlakyTestsCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("GET /api/v1/openapi.json", func() {
+		var spec map[string]any
+
+		BeforeEach(func() {
+			rec := get("/api/v1/openapi.json")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("Content-Type")).To(HavePrefix("application/json"))
+			Expect(json.Unmarshal(rec.Body.Bytes(), &spec)).To(Succeed())
+		})
+
+		It("should describe the flaky tests endpoint and its status codes", func() {
+			Expect(spec).To(HaveKeyWithValue("openapi", "3.0.3"))
+			operation := spec["paths"].(map[string]any)["/api/v1/projects/{project}/flaky-tests"].(map[string]any)["get"].(map[string]any)
+			Expect(operation["responses"]).To(HaveKey("200"))
+			Expect(operation["responses"]).To(HaveKey("400"))
+			Expect(operation["responses"]).To(HaveKey("503"))
+
+			limit := operation["parameters"].([]any)[1].(map[string]any)
+			Expect(limit["name"]).To(Equal("limit"))
+			Expect(limit["schema"]).To(SatisfyAll(
+				HaveKeyWithValue("default", BeNumerically("==", 10)),
+				HaveKeyWithValue("maximum", BeNumerically("==", 50)),
+			))
+		})
+
+		It("should generate the FlakyTest schema from the GraphQL type", func() {
+			schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+			flakyTest := schemas["FlakyTest"].(map[string]any)
+			properties := flakyTest["properties"].(map[string]any)
+
+			Expect(properties["runCount"]).To(HaveKeyWithValue("type", "integer"))
+			Expect(properties["passRate"]).To(HaveKeyWithValue("type", "number"))
+			Expect(properties["lastFailure"]).To(HaveKeyWithValue("nullable", true))
+			Expect(properties["topFailureMessages"]).To(HaveKeyWithValue("type", "array"))
+			Expect(flakyTest["required"]).To(ContainElements("testID", "testName", "runCount", "isStale"))
+			Expect(flakyTest["required"]).ToNot(ContainElement("lastFailure"))
+			Expect(schemas).To(HaveKey("FailureMessage"))
+			Expect(schemas).To(HaveKey("StatusCounts"))
+		})
+	})
+})
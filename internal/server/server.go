@@ -2,75 +2,549 @@ package server
 
 import (
 	"context"
-	"log"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/guidewire-oss/fern-mycelium/internal/db"
 	"github.com/guidewire-oss/fern-mycelium/internal/gql"
 	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/loader"
+	"github.com/guidewire-oss/fern-mycelium/internal/mcp"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/storage"
+	"github.com/guidewire-oss/fern-mycelium/internal/tracing"
 	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
-	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
-func Start() {
+// shutdownTimeout bounds how long in-flight requests may take to finish once
+// shutdown begins.
+const shutdownTimeout = 15 * time.Second
+
+// MCP HTTP+SSE transport routes.
+const (
+	mcpSSEPath     = "/mcp/sse"
+	mcpMessagePath = "/mcp/message"
+)
+
+// Start runs the server until SIGINT or SIGTERM is received.
+func Start(cfg Config) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := StartWithContext(ctx, cfg); err != nil {
+		slog.Error("❌ server exited", "error", err)
+		os.Exit(1)
+	}
+}
+
+// StartWithContext connects to the database and serves HTTP until ctx is
+// cancelled, then shuts down gracefully and closes the pool. With
+// cfg.MockData it serves sample flaky tests and never connects. With the
+// SQLite storage backend it serves flaky tests from cfg.SQLitePath and never
+// connects to Postgres.
+func StartWithContext(ctx context.Context, cfg Config) error {
+	shutdownTracing, err := tracing.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Warn("⚠️ Failed to flush traces", "error", err)
+		}
+	}()
+
+	if cfg.RequireDB {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("REQUIRE_DB is set: %w", err)
+		}
+	}
+
+	if cfg.MockData {
+		slog.Warn("⚠️ MOCK_DATA is set: serving sample flaky tests without a database")
+		mock := repo.NewMockFlakyTestRepo()
+		resolver := &resolvers.Resolver{
+			FlakyRepo:    mock,
+			FlakyCounter: mock,
+			MaxLimit:     cfg.MaxLimit,
+			DefaultLimit: cfg.DefaultLimit,
+			Registry:     newRegistry(mock),
+		}
+		return listenAndServe(ctx, cfg, resolver, metrics.New(prometheus.NewRegistry()))
+	}
+
+	if cfg.StorageBackend == storage.SQLite {
+		return serveSQLite(ctx, cfg)
+	}
+
 	// Connect to the fern-reporter DB
-	pool, err := db.Connect()
+	pool, err := db.ConnectContext(ctx)
 	if err != nil {
-		log.Fatalf("❌ Failed to get db connection: %v", err)
+		return fmt.Errorf("failed to get db connection: %w", err)
 	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			slog.Warn("⚠️ Failed to drain database pool", "pool", "primary", "error", err)
+		}
+	}()
 
-	// Inject your flaky test provider
-	flakyRepo := repo.NewFlakyTestRepo(pool)
+	if cfg.Migrate {
+		if err := applyMigrations(); err != nil {
+			return err
+		}
+	}
 
-	// Create GraphQL schema with real dependencies
+	// Analytical reads move to the replica when DB_READ_URL is set
+	replica, err := db.ConnectReplicaContext(ctx)
+	if err != nil {
+		return err
+	}
+	if replica != nil {
+		defer closePool(replica, "replica")
+	}
+
+	// Requests carrying X-Tenant query that tenant's database instead
+	tenants, err := db.ConnectTenantsContext(ctx)
+	if err != nil {
+		return err
+	}
+	for name, tenantPool := range tenants {
+		defer closePool(tenantPool, "tenant "+name)
+	}
+
+	owners, err := repo.LoadOwners(cfg.OwnersFile)
+	if err != nil {
+		return err
+	}
+
+	m := metrics.New(prometheus.NewRegistry())
+	resolver := newResolver(cfg, pool, replica, tenants, owners, m)
+	if cfg.FlakyMetricsMaxSeries > 0 {
+		m.CollectFlakiness(resolver.FlakyRepo, resolver.FlakyCounter, resolver.ProjectRepo, metrics.FlakinessConfig{
+			TTL:       cfg.FlakyMetricsTTL,
+			MaxSeries: cfg.FlakyMetricsMaxSeries,
+		})
+	}
+
+	// Stop the notifiers before the pool they query is closed.
+	stopNotifiers := startNotifiers(ctx, cfg, resolver)
+	defer stopNotifiers()
+
+	return listenAndServe(ctx, cfg, resolver, m)
+}
+
+// serveSQLite serves the flaky tests of the SQLite database at cfg.SQLitePath
+// until ctx is cancelled. Only flaky test queries are wired; the other
+// fields, the notifiers and the flakiness metrics need Postgres.
+func serveSQLite(ctx context.Context, cfg Config) error {
+	store, closeStore, err := storage.OpenFlakyTests(ctx, storage.SQLite, cfg.SQLitePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := closeStore(); err != nil {
+			slog.Warn("⚠️ Failed to close storage backend", "backend", storage.SQLite, "error", err)
+		}
+	}()
+
+	m := metrics.New(prometheus.NewRegistry())
+	flakyTests := m.InstrumentFlakyTests(store)
+	if cfg.FlakyCacheTTL > 0 || cfg.FlakyStaleWindow > 0 {
+		flakyTests = repo.NewFlakyTestCache(flakyTests, cfg.FlakyCacheTTL, repo.WithStaleOnError(cfg.FlakyStaleWindow))
+	}
 	resolver := &resolvers.Resolver{
-		FlakyRepo: flakyRepo,
+		FlakyRepo:      flakyTests,
+		FlakyCounter:   store,
+		AlertInterval:  cfg.FlakyAlertInterval,
+		AlertThreshold: cfg.FlakyAlertThreshold,
+		MaxLimit:       cfg.MaxLimit,
+		DefaultLimit:   cfg.DefaultLimit,
+		NamePattern:    cfg.FlakyTestNamePattern,
+		Registry:       newRegistry(flakyTests),
+	}
+	slog.Info("🗄️ Serving flaky tests from SQLite; queries that need Postgres are unimplemented", "path", cfg.SQLitePath)
+	return listenAndServe(ctx, cfg, resolver, m)
+}
+
+// listenAndServe serves the GraphQL, MCP and health routes for resolver on
+// cfg.Addr until ctx is cancelled.
+func listenAndServe(ctx context.Context, cfg Config, resolver *resolvers.Resolver, m *metrics.Metrics) error {
+	sse := mcp.NewSSEHandler(mcp.NewServer(resolver.FlakyRepo), mcpMessagePath)
+	router, err := NewRouter(cfg, resolver, m, sse)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.Addr, err)
+	}
+
+	tlsCfg := TLSConfig{CertFile: cfg.TLSCertFile, KeyFile: cfg.TLSKeyFile, MinVersion: cfg.TLSMinVersion}
+	baseURL := displayURL(ln.Addr(), tlsCfg.enabled())
+	if cfg.PlaygroundPath != "" {
+		slog.Info("🚀 GraphQL Playground available", "url", baseURL+cfg.PlaygroundPath)
+	}
+	slog.Info("✅ Health check available", "url", baseURL+"/healthz")
+	slog.Info("🤖 MCP SSE endpoint available", "url", baseURL+mcpSSEPath)
+
+	if tlsCfg.enabled() {
+		return ServeTLS(ctx, ln, router, tlsCfg, sse.Close)
+	}
+	return Serve(ctx, ln, router, sse.Close)
+}
+
+// applyMigrations runs the embedded schema migrations against DB_URL.
+func applyMigrations() error {
+	m, err := db.NewMigratorFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	defer m.Close() //nolint:all
+
+	changed, err := m.Up()
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	if changed {
+		slog.Info("✅ Applied pending database migrations")
+	} else {
+		slog.Info("✅ Database schema is up to date")
+	}
+	return nil
+}
+
+// closePool closes pool, waiting up to db.CloseTimeout for in-flight queries.
+func closePool(pool *pgxpool.Pool, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), db.CloseTimeout)
+	defer cancel()
+	if err := db.CloseContext(ctx, pool); err != nil {
+		slog.Warn("⚠️ Failed to drain database pool", "pool", name, "error", err)
+	}
+}
+
+// displayURL turns a listener address into a URL suitable for log output,
+// substituting localhost for wildcard hosts.
+func displayURL(addr net.Addr, https bool) string {
+	scheme := "http://"
+	if https {
+		scheme = "https://"
 	}
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return scheme + addr.String()
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return scheme + net.JoinHostPort(host, port)
+}
+
+// database is what the repositories need of a pool: *pgxpool.Pool and
+// *repo.TenantRouter both provide it.
+type database interface {
+	repo.PgxQuerier
+	repo.PgxBeginner
+}
+
+// newResolver wires the repositories backed by pool into a GraphQL resolver.
+// Flaky test queries read from replica instead when it is non-nil. Test owners are looked up in owners, which may be
+// nil, before the project.
+func newResolver(cfg Config, pool, replica *pgxpool.Pool, tenants map[string]*pgxpool.Pool, owners *repo.Owners, m *metrics.Metrics) *resolvers.Resolver {
+	// With tenant databases configured, each query goes to the database of
+	// the request's tenant, and to the default pools without one.
+	var primary database = pool
+	var reads repo.PgxQuerier
+	if replica != nil {
+		reads = replica
+	}
+	if len(tenants) > 0 {
+		queriers := make(map[string]repo.PgxQuerier, len(tenants))
+		for name, tenantPool := range tenants {
+			queriers[name] = tenantPool
+		}
+		primary = repo.NewTenantRouter(pool, queriers)
+		if replica != nil {
+			reads = repo.NewTenantRouter(replica, queriers)
+		}
+	}
+
+	// Inject your flaky test provider
+	flakyOpts := []repo.Option{repo.WithQueryTimeout(cfg.QueryTimeout)}
+	if reads != nil {
+		flakyOpts = append(flakyOpts, repo.WithReadReplica(reads))
+	}
+	if cfg.QueryLogging {
+		flakyOpts = append(flakyOpts, repo.WithQueryLogging(cfg.SlowQueryThreshold))
+	}
+	flakyRepo := repo.NewFlakyTestRepo(primary, flakyOpts...)
+
+	// Requests batch their lookups into the pgx repo
+	flakyTests := m.InstrumentFlakyTests(loader.NewProvider(flakyRepo))
+	if cfg.FlakyCacheTTL > 0 || cfg.FlakyStaleWindow > 0 {
+		flakyTests = repo.NewFlakyTestCache(flakyTests, cfg.FlakyCacheTTL, repo.WithStaleOnError(cfg.FlakyStaleWindow))
+	}
+
+	return &resolvers.Resolver{
+		FlakyRepo:      flakyTests,
+		FlakyPager:     flakyRepo,
+		FlakyCounter:   flakyRepo,
+		SlowRepo:       repo.NewSlowTestRepo(primary),
+		OutlierRepo:    repo.NewSlowTestRepo(primary),
+		TrendRepo:      repo.NewTrendRepo(primary),
+		ProjectRepo:    repo.NewProjectRepo(primary),
+		SuiteRepo:      repo.NewSuiteHealthRepo(primary),
+		TeamRepo:       repo.NewTeamHealthRepo(primary),
+		OwnerRepo:      repo.NewOwnershipRepo(primary, owners),
+		HealthScores:   flakyRepo,
+		TopFailing:     flakyRepo,
+		TestImpact:     flakyRepo,
+		NewlyFlaky:     flakyRepo,
+		ActorRepo:      flakyRepo,
+		CoFailures:     flakyRepo,
+		TestRunRepo:    repo.NewTestRunRepo(primary),
+		HistoryRepo:    repo.NewTestRunRepo(primary),
+		QuarantineRepo: repo.NewQuarantineRepo(primary),
+		IngestRepo:     repo.NewIngestRepo(primary),
+		FlakyBatcher:   flakyRepo,
+		AlertInterval:  cfg.FlakyAlertInterval,
+		AlertThreshold: cfg.FlakyAlertThreshold,
+		MaxLimit:       cfg.MaxLimit,
+		DefaultLimit:   cfg.DefaultLimit,
+		NamePattern:    cfg.FlakyTestNamePattern,
+		DB:             pool,
+		Schema:         repo.NewSchemaVersionRepo(pool),
+		Registry:       newRegistry(flakyTests),
+	}
+}
+
+// NewRouter builds the HTTP routes served by fern-mycelium, recording
+// request metrics into m. The MCP SSE transport is mounted when sse is non-nil.
+// It fails when the OpenAPI document of the REST facade cannot be built.
+func NewRouter(cfg Config, resolver *resolvers.Resolver, m *metrics.Metrics, sse *mcp.SSEHandler) (*gin.Engine, error) {
+	// Create GraphQL schema with real dependencies
 	schema := gql.NewExecutableSchema(gql.Config{Resolvers: resolver})
 
 	// Setup router
-	router := gin.Default()
+	router := gin.New()
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		// LoadConfig rejects invalid proxies, so only a hand-built Config
+		// gets here. Trust none rather than gin's default of every proxy.
+		slog.Warn("ignoring invalid trusted proxies", "error", err)
+		_ = router.SetTrustedProxies(nil)
+	}
+	// AccessLog logs /query, so gin's request log covers the other routes.
+	router.Use(RequestID(), Tenant(), gin.LoggerWithConfig(gin.LoggerConfig{SkipPaths: []string{"/query"}}), Recovery())
+	if len(cfg.CORSOrigins) > 0 {
+		router.Use(cors.New(cors.Config{
+			AllowOrigins: cfg.CORSOrigins,
+			AllowMethods: cfg.CORSMethods,
+			AllowHeaders: cfg.CORSHeaders,
+		}))
+	}
 
-	// Health check endpoint
-	router.GET("/healthz", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"message": "fern-mycelium is healthy 🍄",
-		})
-	})
+	registerProbes(router, resolver.DB, m)
 
 	// GraphQL endpoints
-	router.GET("/graphql", gin.WrapH(playground.Handler("Mycelium GraphQL Playground", "/query")))
-	router.POST("/query", gin.WrapH(NewGraphQLServer(schema)))
+	gqlServer := NewGraphQLServer(cfg, schema)
+	gqlServer.Use(m.Extension())
+	auth := APIKeyAuth(cfg.APIKeys)
+	if cfg.PlaygroundPath != "" {
+		playgroundHandlers := []gin.HandlerFunc{gin.WrapH(playground.Handler("Mycelium GraphQL Playground", "/query"))}
+		if cfg.PlaygroundAuth {
+			playgroundHandlers = append([]gin.HandlerFunc{auth}, playgroundHandlers...)
+		}
+		router.GET(cfg.PlaygroundPath, playgroundHandlers...)
+	}
+	rateLimit := RateLimit(cfg.RateLimitRPS, cfg.RateLimitBurst)
+	queryHandlers := []gin.HandlerFunc{AccessLog(slog.Default(), cfg.AccessLogDBTime), auth, rateLimit}
+	if resolver.FlakyBatcher != nil {
+		queryHandlers = append(queryHandlers, FlakyTestLoader(resolver.FlakyBatcher, loader.DefaultWait))
+	}
+	queryHandlers = append(queryHandlers, gin.WrapH(gqlServer))
+	router.POST("/query", queryHandlers...)
+	router.GET("/query", queryHandlers...)
+
+	// Flaky test exports for CI systems and spreadsheets
+	router.GET("/export/flaky.xml", auth, rateLimit, junitExportHandler(resolver.FlakyRepo))
+	router.GET("/export/flaky.csv", auth, rateLimit, csvExportHandler(resolver.FlakyRepo))
+
+	// REST facade for clients that cannot speak GraphQL
+	defaultLimit, maxLimit := restLimits(resolver)
+	router.GET(restFlakyTestsPath, auth, rateLimit, flakyTestsRESTHandler(resolver.FlakyRepo, defaultLimit, maxLimit))
+	spec, err := openAPISpec(schema.Schema(), defaultLimit, maxLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the OpenAPI document: %w", err)
+	}
+	router.GET(openAPIPath, auth, openAPIHandler(spec))
+
+	// Self-description for agents discovering the queries and tools
+	router.GET("/capabilities", auth, capabilitiesHandler(resolver.Registry))
+
+	// MCP over HTTP+SSE
+	if sse != nil {
+		router.GET(mcpSSEPath, auth, rateLimit, gin.WrapF(sse.ServeSSE))
+		router.POST(mcpMessagePath, auth, rateLimit, gin.WrapF(sse.ServeMessage))
+	}
+
+	return router, nil
+}
+
+// registerProbes adds the health and metrics endpoints. Orchestrators and
+// scrapers call them without credentials, so they are registered apart from
+// the routes behind API-key auth and rate limiting and must stay that way.
+func registerProbes(router *gin.Engine, db repo.Pinger, m *metrics.Metrics) {
+	// /healthz is kept as an alias of /readyz
+	ready := readinessHandler(db)
+	router.GET("/livez", livenessHandler)
+	router.GET("/readyz", ready)
+	router.GET("/healthz", ready)
+
+	// Prometheus scrape endpoint
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+}
+
+// TLSConfig holds the certificate and protocol settings for ServeTLS.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// MinVersion is the lowest TLS version accepted; zero means TLS 1.2.
+	MinVersion uint16
+}
+
+func (c TLSConfig) enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// Serve serves handler on ln until ctx is done, then stops accepting new
+// connections and waits up to shutdownTimeout for in-flight requests.
+// onShutdown hooks run when shutdown begins, e.g. to end long-lived streams.
+func Serve(ctx context.Context, ln net.Listener, handler http.Handler, onShutdown ...func()) error {
+	return serve(ctx, ln, handler, nil, onShutdown)
+}
+
+// ServeTLS is Serve over HTTPS, using the certificate and key files in
+// tlsCfg.
+func ServeTLS(ctx context.Context, ln net.Listener, handler http.Handler, tlsCfg TLSConfig, onShutdown ...func()) error {
+	return serve(ctx, ln, handler, &tlsCfg, onShutdown)
+}
+
+// serve runs Serve, or ServeTLS when tlsCfg is non-nil.
+func serve(ctx context.Context, ln net.Listener, handler http.Handler, tlsCfg *TLSConfig, onShutdown []func()) error {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	for _, hook := range onShutdown {
+		srv.RegisterOnShutdown(hook)
+	}
+
+	errCh := make(chan error, 1)
+	if tlsCfg != nil {
+		minVersion := tlsCfg.MinVersion
+		if minVersion == 0 {
+			minVersion = tls.VersionTLS12
+		}
+		srv.TLSConfig = &tls.Config{MinVersion: minVersion}
+		go func() {
+			errCh <- srv.ServeTLS(ln, tlsCfg.CertFile, tlsCfg.KeyFile)
+		}()
+	} else {
+		go func() {
+			errCh <- srv.Serve(ln)
+		}()
+	}
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to start server: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("🛑 Shutting down fern-mycelium server...")
 
-	log.Println("🚀 GraphQL Playground available at http://localhost:8080/graphql")
-	log.Println("✅ Health check available at http://localhost:8080/healthz")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-	// Start server
-	if err := router.Run(":8080"); err != nil {
-		log.Fatalf("❌ Failed to start server: %v", err)
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
 	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
 
-func NewGraphQLServer(schema graphql.ExecutableSchema) *handler.Server {
+// NewGraphQLServer builds the gqlgen handler for schema, applying the
+// operation limits from cfg.
+func NewGraphQLServer(cfg Config, schema graphql.ExecutableSchema) *handler.Server {
 	srv := handler.New(schema)
 
 	// Add transports (e.g., POST only for production)
+	// Batches go first: transport.POST accepts any JSON body and would
+	// reject an array of operations.
+	srv.AddTransport(batchTransport{})
 	srv.AddTransport(transport.POST{})
+	if cfg.GETQueries {
+		srv.AddTransport(transport.GET{})
+	}
+	// Subscriptions are served over WebSockets on the same endpoint.
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: websocketKeepAlive,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: websocketOriginChecker(cfg.CORSOrigins),
+		},
+	})
 
-	// Optional: configure caching and introspection
-	// srv.SetQueryCache(lru.New(1000))
-	srv.Use(extension.Introspection{})
+	// Cache parsed documents and support automatic persisted queries. The LRU
+	// caches are safe for concurrent use.
+	if cfg.QueryCacheSize > 0 {
+		srv.SetQueryCache(lru.New[*ast.QueryDocument](cfg.QueryCacheSize))
+		srv.Use(extension.AutomaticPersistedQuery{Cache: lru.New[string](cfg.QueryCacheSize)})
+	}
+	if cfg.Introspection {
+		srv.Use(extension.Introspection{})
+	}
+	srv.Use(tracing.Extension())
+	srv.Use(accessLogExtension{})
+	if cfg.OperationTimeout > 0 {
+		srv.Use(operationTimeout{timeout: cfg.OperationTimeout})
+	}
+	if cfg.ComplexityLimit > 0 {
+		srv.Use(extension.FixedComplexityLimit(cfg.ComplexityLimit))
+	}
+	switch {
+	case cfg.MockData:
+		srv.AroundFields(flakyTestFieldsOnly(errNotInMockMode))
+	case cfg.StorageBackend == storage.SQLite:
+		srv.AroundFields(flakyTestFieldsOnly(errNotInSQLiteMode))
+	}
 
-	// Optional: error presenter
-	srv.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
-		return graphql.DefaultErrorPresenter(ctx, err)
-	})
+	srv.SetErrorPresenter(errorPresenter(cfg.HideInternalErrors))
+	recoverFunc := cfg.RecoverFunc
+	if recoverFunc == nil {
+		recoverFunc = RecoverPanic
+	}
+	srv.SetRecoverFunc(recoverFunc)
 
 	return srv
 }
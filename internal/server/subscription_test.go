@@ -0,0 +1,88 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("Subscriptions", func() {
+	type message struct {
+		ID      string         `json:"id,omitempty"`
+		Type    string         `json:"type"`
+		Payload map[string]any `json:"payload,omitempty"`
+	}
+
+	var (
+		fakeFlaky *fakes.FakeFlakyTestProvider
+		srv       *httptest.Server
+	)
+
+	dial := func(header http.Header) (*websocket.Conn, *http.Response, error) {
+		dialer := websocket.Dialer{Subprotocols: []string{"graphql-transport-ws"}}
+		return dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/query", header)
+	}
+
+	BeforeEach(func() {
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+		router := mustNewRouter(server.Config{CORSOrigins: []string{"https://dash.example.com"}}, &resolvers.Resolver{
+			FlakyRepo:      fakeFlaky,
+			AlertInterval:  10 * time.Millisecond,
+			AlertThreshold: 0.5,
+		}, metrics.New(prometheus.NewRegistry()), nil)
+		srv = httptest.NewServer(router)
+		DeferCleanup(srv.Close)
+	})
+
+	It("should stream flaky test alerts over WebSockets and stop polling on disconnect", func() {
+		fakeFlaky.GetFlakyTestsReturnsOnCall(0, nil, nil)
+		fakeFlaky.GetFlakyTestsReturns([]*gql.FlakyTest{{TestName: "login", FailureRate: 0.75}}, nil)
+
+		conn, _, err := dial(http.Header{"Origin": {"https://dash.example.com"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conn.SetReadDeadline(time.Now().Add(5 * time.Second))).To(Succeed())
+
+		Expect(conn.WriteJSON(message{Type: "connection_init"})).To(Succeed())
+		var ack message
+		Expect(conn.ReadJSON(&ack)).To(Succeed())
+		Expect(ack.Type).To(Equal("connection_ack"))
+
+		Expect(conn.WriteJSON(message{ID: "1", Type: "subscribe", Payload: map[string]any{
+			"query": `subscription { flakyTestAlerts(projectID: "demo") { testName failureRate } }`,
+		}})).To(Succeed())
+
+		var next message
+		for next.Type != "next" {
+			Expect(conn.ReadJSON(&next)).To(Succeed())
+		}
+		Expect(next.ID).To(Equal("1"))
+		Expect(next.Payload).To(Equal(map[string]any{
+			"data": map[string]any{"flakyTestAlerts": map[string]any{"testName": "login", "failureRate": 0.75}},
+		}))
+
+		Expect(conn.Close()).To(Succeed())
+		Eventually(func() int {
+			calls := fakeFlaky.GetFlakyTestsCallCount()
+			time.Sleep(30 * time.Millisecond)
+			return fakeFlaky.GetFlakyTestsCallCount() - calls
+		}).Should(BeZero())
+	})
+
+	It("should reject WebSocket upgrades from origins outside the CORS list", func() {
+		_, resp, err := dial(http.Header{"Origin": {"https://evil.example.com"}})
+		Expect(err).To(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+	})
+})
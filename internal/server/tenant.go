@@ -0,0 +1,25 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// TenantHeader names the tenant whose database a request queries. Without
+// it, the request queries the default database from DB_URL.
+const TenantHeader = "X-Tenant"
+
+// Tenant returns middleware attaching the X-Tenant header to the request
+// context, where repo.TenantRouter picks the tenant's database up. Unknown
+// tenants are rejected by the router when a query is made.
+func Tenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tenant := strings.TrimSpace(c.GetHeader(TenantHeader)); tenant != "" {
+			c.Request = c.Request.WithContext(repo.WithTenant(c.Request.Context(), tenant))
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("Tenant routing", func() {
+	var defaultDB, prodDB *fakes.FakePgxQuerier
+
+	BeforeEach(func() {
+		defaultDB = &fakes.FakePgxQuerier{}
+		prodDB = &fakes.FakePgxQuerier{}
+		for _, db := range []*fakes.FakePgxQuerier{defaultDB, prodDB} {
+			db.QueryStub = func(context.Context, string, ...any) (pgx.Rows, error) {
+				return nil, context.Canceled
+			}
+		}
+	})
+
+	query := func(tenant string) *httptest.ResponseRecorder {
+		tenants := repo.NewTenantRouter(defaultDB, map[string]repo.PgxQuerier{"prod": prodDB})
+		resolver := &resolvers.Resolver{FlakyRepo: repo.NewFlakyTestRepo(tenants)}
+		router := mustNewRouter(server.Config{}, resolver, metrics.New(prometheus.NewRegistry()), nil)
+
+		body := `{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"}`
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if tenant != "" {
+			req.Header.Set(server.TenantHeader, tenant)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should query the database of the tenant named by X-Tenant", func() {
+		query("prod")
+		Expect(prodDB.QueryCallCount()).To(Equal(1))
+		Expect(defaultDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("should query the default database without X-Tenant", func() {
+		query("")
+		Expect(defaultDB.QueryCallCount()).To(Equal(1))
+		Expect(prodDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("should reject an unknown tenant as an invalid argument", func() {
+		rec := query("qa")
+		Expect(rec.Body.String()).To(ContainSubstring(`unknown tenant \"qa\"`))
+		Expect(rec.Body.String()).To(ContainSubstring(server.ErrorCodeInvalidArgument))
+		Expect(defaultDB.QueryCallCount() + prodDB.QueryCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// operationTimeout bounds each GraphQL query and mutation with a deadline,
+// so a slow resolver cannot hold a worker indefinitely. Subscriptions are
+// long-lived by design and are not bounded.
+type operationTimeout struct {
+	timeout time.Duration
+}
+
+func (operationTimeout) ExtensionName() string {
+	return "OperationTimeout"
+}
+
+func (operationTimeout) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (t operationTimeout) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if graphql.HasOperationContext(ctx) {
+		if op := graphql.GetOperationContext(ctx).Operation; op != nil && op.Operation == ast.Subscription {
+			return next(ctx)
+		}
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, t.timeout, &operationTimeoutError{timeout: t.timeout})
+	defer cancel()
+	return next(ctx)
+}
+
+// operationTimeoutError is the cause of a context ended by operationTimeout.
+type operationTimeoutError struct {
+	timeout time.Duration
+}
+
+func (e *operationTimeoutError) Error() string {
+	return fmt.Sprintf("operation exceeded the %s timeout", e.timeout)
+}
+
+// exceededOperationTimeout returns the operation timeout error when it is
+// what ended ctx, rather than a client disconnect or a database query
+// timeout.
+func exceededOperationTimeout(ctx context.Context) (*operationTimeoutError, bool) {
+	var timeoutErr *operationTimeoutError
+	if !errors.As(context.Cause(ctx), &timeoutErr) {
+		return nil, false
+	}
+	return timeoutErr, true
+}
@@ -0,0 +1,119 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/gql/resolvers"
+	"github.com/guidewire-oss/fern-mycelium/internal/metrics"
+	"github.com/guidewire-oss/fern-mycelium/internal/server"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("GraphQL operation timeout", func() {
+	type response struct {
+		Data   map[string]any `json:"data"`
+		Errors []struct {
+			Message    string         `json:"message"`
+			Path       []any          `json:"path"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"errors"`
+	}
+
+	var (
+		cfg       server.Config
+		fakeFlaky *fakes.FakeFlakyTestProvider
+	)
+
+	BeforeEach(func() {
+		cfg = server.Config{OperationTimeout: 20 * time.Millisecond}
+		fakeFlaky = &fakes.FakeFlakyTestProvider{}
+	})
+
+	query := func() response {
+		router := mustNewRouter(cfg, &resolvers.Resolver{FlakyRepo: fakeFlaky},
+			metrics.New(prometheus.NewRegistry()), nil)
+		body := `{"query":"{ flakyTests(limit: 5, projectID: \"demo\") { testName } }"}`
+		req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		var resp response
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+
+	// slowProvider blocks until the operation's context ends, failing the
+	// way a cancelled database query does.
+	slowProvider := func(ctx context.Context, _ string, _ int, _ repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+		<-ctx.Done()
+		return nil, fmt.Errorf("flaky tests: %w: %w", repo.ErrUnavailable, ctx.Err())
+	}
+
+	It("should fail a slow resolver with DEADLINE_EXCEEDED", func() {
+		fakeFlaky.GetFlakyTestsStub = slowProvider
+
+		resp := query()
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Extensions).To(HaveKeyWithValue("code", server.ErrorCodeDeadlineExceeded))
+		Expect(resp.Errors[0].Message).To(Equal("operation exceeded the 20ms timeout"))
+		Expect(resp.Errors[0].Path).To(Equal([]any{"flakyTests"}))
+	})
+
+	It("should explain the timeout even when internal errors are hidden", func() {
+		cfg.HideInternalErrors = true
+		fakeFlaky.GetFlakyTestsStub = slowProvider
+
+		resp := query()
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Extensions).To(HaveKeyWithValue("code", server.ErrorCodeDeadlineExceeded))
+		Expect(resp.Errors[0].Message).To(Equal("operation exceeded the 20ms timeout"))
+	})
+
+	It("should hand resolvers a context with the deadline", func() {
+		var deadline time.Time
+		fakeFlaky.GetFlakyTestsStub = func(ctx context.Context, _ string, _ int, _ repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+			deadline, _ = ctx.Deadline()
+			return []*gql.FlakyTest{{TestName: "login"}}, nil
+		}
+
+		start := time.Now()
+		resp := query()
+		Expect(resp.Errors).To(BeEmpty())
+		Expect(resp.Data["flakyTests"]).To(Equal([]any{map[string]any{"testName": "login"}}))
+		Expect(deadline).To(BeTemporally("~", start.Add(cfg.OperationTimeout), 15*time.Millisecond))
+	})
+
+	It("should keep the code of failures that are not the timeout", func() {
+		fakeFlaky.GetFlakyTestsReturns(nil, fmt.Errorf("flaky tests: %w: %w", repo.ErrUnavailable, context.DeadlineExceeded))
+
+		resp := query()
+		Expect(resp.Errors).To(HaveLen(1))
+		Expect(resp.Errors[0].Extensions).To(HaveKeyWithValue("code", server.ErrorCodeDBUnavailable))
+	})
+
+	It("should not bound operations when disabled", func() {
+		cfg.OperationTimeout = 0
+		fakeFlaky.GetFlakyTestsStub = func(ctx context.Context, _ string, _ int, _ repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
+			if _, ok := ctx.Deadline(); ok {
+				return nil, errors.New("unexpected deadline")
+			}
+			return nil, nil
+		}
+
+		Expect(query().Errors).To(BeEmpty())
+	})
+})
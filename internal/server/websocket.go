@@ -0,0 +1,25 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"time"
+)
+
+// websocketKeepAlive is how often idle subscription connections are pinged
+// so proxies do not drop them.
+const websocketKeepAlive = 10 * time.Second
+
+// websocketOriginChecker accepts WebSocket upgrades from the CORS origins,
+// or from any origin when they include "*". Clients that send no Origin,
+// such as CLIs, are accepted. With no origins configured it returns nil,
+// leaving gorilla's same-origin check in place.
+func websocketOriginChecker(origins []string) func(*http.Request) bool {
+	if len(origins) == 0 {
+		return nil
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || slices.Contains(origins, "*") || slices.Contains(origins, origin)
+	}
+}
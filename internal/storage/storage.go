@@ -0,0 +1,67 @@
+// Package storage selects the database that flaky test statistics are read
+// from. Postgres, through the pgx repositories, is the default; other
+// backends provide a repo.FlakyTestProvider of their own.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	// Registers the SQLiteDriver, a pure Go build of SQLite.
+	_ "modernc.org/sqlite"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+// Backend names accepted by ParseBackend.
+const (
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// SQLiteDriver is the database/sql driver the SQLite backend opens, as
+// registered by modernc.org/sqlite.
+const SQLiteDriver = "sqlite"
+
+// ParseBackend validates a STORAGE_BACKEND value, defaulting to Postgres.
+func ParseBackend(name string) (string, error) {
+	switch name {
+	case "":
+		return Postgres, nil
+	case Postgres, SQLite:
+		return name, nil
+	default:
+		return "", fmt.Errorf("unknown storage backend %q: must be %s or %s", name, Postgres, SQLite)
+	}
+}
+
+// FlakyTests is what a backend other than Postgres provides: the ranked
+// flaky tests and their count, so flakyTestsPage totals are read from the
+// same database as its items.
+type FlakyTests interface {
+	repo.FlakyTestProvider
+	repo.FlakyTestCounter
+}
+
+// OpenFlakyTests returns the flaky tests of backend and a function releasing
+// them. For Postgres it returns nil, leaving the caller's pgx repository in
+// place. dsn locates the database of other backends.
+func OpenFlakyTests(ctx context.Context, backend, dsn string) (FlakyTests, func() error, error) {
+	switch backend {
+	case Postgres:
+		return nil, func() error { return nil }, nil
+	case SQLite:
+		db, err := sql.Open(SQLiteDriver, dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := db.PingContext(ctx); err != nil {
+			_ = db.Close()
+			return nil, nil, fmt.Errorf("failed to open %s database: %w", SQLite, err)
+		}
+		return repo.NewSQLiteFlakyTestRepo(db), db.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
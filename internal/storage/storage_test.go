@@ -0,0 +1,54 @@
+package storage_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/storage"
+)
+
+var _ = Describe("ParseBackend", func() {
+	DescribeTable("should accept the known backends",
+		func(name, expected string) {
+			backend, err := storage.ParseBackend(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backend).To(Equal(expected))
+		},
+		Entry("unset defaults to postgres", "", storage.Postgres),
+		Entry("postgres", "postgres", storage.Postgres),
+		Entry("sqlite", "sqlite", storage.SQLite),
+	)
+
+	It("should reject an unknown backend", func() {
+		_, err := storage.ParseBackend("bigquery")
+		Expect(err).To(MatchError(`unknown storage backend "bigquery": must be postgres or sqlite`))
+	})
+})
+
+var _ = Describe("OpenFlakyTests", func() {
+	It("should leave the pgx repository in place for postgres", func() {
+		provider, closeFn, err := storage.OpenFlakyTests(context.Background(), storage.Postgres, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider).To(BeNil())
+		Expect(closeFn()).To(Succeed())
+	})
+
+	It("should open a SQLite database", func() {
+		provider, closeFn, err := storage.OpenFlakyTests(context.Background(), storage.SQLite, ":memory:")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider).NotTo(BeNil())
+		Expect(closeFn()).To(Succeed())
+	})
+
+	It("should report a SQLite database that cannot be opened", func() {
+		_, _, err := storage.OpenFlakyTests(context.Background(), storage.SQLite, GinkgoT().TempDir()+"/missing/results.db")
+		Expect(err).To(MatchError(ContainSubstring("failed to open sqlite database")))
+	})
+
+	It("should reject an unknown backend", func() {
+		_, _, err := storage.OpenFlakyTests(context.Background(), "bigquery", "")
+		Expect(err).To(MatchError(`unknown storage backend "bigquery"`))
+	})
+})
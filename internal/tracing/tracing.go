@@ -0,0 +1,92 @@
+// Package tracing configures OpenTelemetry tracing for fern-mycelium.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "fern-mycelium"
+	tracerName  = "github.com/guidewire-oss/fern-mycelium/internal/tracing"
+)
+
+// Setup installs an OTLP/HTTP tracer provider as the global provider when
+// OTEL_EXPORTER_OTLP_ENDPOINT is set. Otherwise tracing stays a no-op. The
+// returned function flushes and stops the provider.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// The exporter reads the endpoint and headers from the standard
+	// OTEL_EXPORTER_OTLP_* environment variables.
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+	return tp.Shutdown, nil
+}
+
+// Extension returns a gqlgen extension that wraps each GraphQL operation in
+// a span.
+func Extension() graphql.HandlerExtension {
+	return operationTracer{}
+}
+
+type operationTracer struct{}
+
+func (operationTracer) ExtensionName() string {
+	return "OperationTracing"
+}
+
+func (operationTracer) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (operationTracer) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	// Requests rejected before parsing (e.g. malformed JSON) carry no
+	// operation context.
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+	operationName := graphql.GetOperationContext(ctx).OperationName
+
+	name := "graphql.operation"
+	if operationName != "" {
+		name += " " + operationName
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("graphql.operation.name", operationName)),
+	)
+	defer span.End()
+
+	resp := next(ctx)
+	if resp != nil && len(resp.Errors) > 0 {
+		span.SetStatus(codes.Error, resp.Errors.Error())
+	}
+	return resp
+}
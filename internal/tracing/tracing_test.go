@@ -0,0 +1,45 @@
+package tracing_test
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+	"go.opentelemetry.io/otel"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/tracing"
+)
+
+var _ = Describe("Setup", func() {
+	It("should leave the global provider untouched when no endpoint is configured", func() {
+		GinkgoT().Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+		before := otel.GetTracerProvider()
+
+		shutdown, err := tracing.Setup(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(otel.GetTracerProvider()).To(BeIdenticalTo(before))
+		Expect(shutdown(context.Background())).To(Succeed())
+	})
+
+	It("should install a provider when an endpoint is configured", func() {
+		GinkgoT().Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:4318")
+		before := otel.GetTracerProvider()
+		DeferCleanup(func() { otel.SetTracerProvider(before) })
+
+		shutdown, err := tracing.Setup(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(otel.GetTracerProvider()).ToNot(BeIdenticalTo(before))
+		Expect(shutdown(context.Background())).To(Succeed())
+	})
+})
+
+var _ = Describe("Extension", func() {
+	It("should pass through responses that have no operation context", func() {
+		ext := tracing.Extension().(graphql.ResponseInterceptor)
+		resp := ext.InterceptResponse(context.Background(), func(context.Context) *graphql.Response {
+			return &graphql.Response{}
+		})
+		Expect(resp).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,18 @@
+// Package version holds build metadata injected at link time, e.g.
+//
+//	go build -ldflags "-X github.com/guidewire-oss/fern-mycelium/internal/version.Version=v1.2.3"
+package version
+
+import "fmt"
+
+// Build metadata, overridden via -ldflags -X. Local builds report "dev".
+var (
+	Version   = "dev"
+	Commit    = "dev"
+	BuildDate = "dev"
+)
+
+// String renders the build metadata for `mycel version`.
+func String() string {
+	return fmt.Sprintf("mycel %s\ncommit: %s\nbuilt:  %s", Version, Commit, BuildDate)
+}
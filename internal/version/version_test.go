@@ -0,0 +1,27 @@
+package version_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:all
+	. "github.com/onsi/gomega"    //nolint:all
+
+	"github.com/guidewire-oss/fern-mycelium/internal/version"
+)
+
+var _ = Describe("String", func() {
+	It("should report dev when no build metadata was injected", func() {
+		Expect(version.String()).To(Equal("mycel dev\ncommit: dev\nbuilt:  dev"))
+	})
+
+	It("should print the injected build metadata", func() {
+		original := []string{version.Version, version.Commit, version.BuildDate}
+		DeferCleanup(func() {
+			version.Version, version.Commit, version.BuildDate = original[0], original[1], original[2]
+		})
+
+		version.Version = "v1.4.0"
+		version.Commit = "5523beb"
+		version.BuildDate = "2025-05-01T12:00:00Z"
+
+		Expect(version.String()).To(Equal("mycel v1.4.0\ncommit: 5523beb\nbuilt:  2025-05-01T12:00:00Z"))
+	})
+})
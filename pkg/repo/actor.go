@@ -0,0 +1,18 @@
+package repo
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor returns a context whose writes are attributed to actor, such as
+// the quarantined_by of a new quarantine. An empty actor leaves them
+// unattributed.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or "" for none.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
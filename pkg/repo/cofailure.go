@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_co_failure_provider.go . CoFailureProvider
+type CoFailureProvider interface {
+	GetCoFailingTests(ctx context.Context, projectID string, limit int) ([]*gql.CoFailure, error)
+}
+
+// GetCoFailingTests pairs specs that failed in the same test runs of a
+// project over the last DefaultSinceDays, most shared failures first. Only
+// pairs with a lift above 1 are returned: the share of runs where both
+// failed must exceed what their separate failure rates would predict, so
+// two specs that merely fail often are not reported as related.
+func (r *FlakyTestRepo) GetCoFailingTests(ctx context.Context, projectID string, limit int) ([]*gql.CoFailure, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	query := `
+    WITH window_runs AS (
+        SELECT test_runs.id AS test_run_id, spec_runs.spec_description AS test_name, spec_runs.status
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectMatch + `
+          AND spec_runs.start_time >= NOW() - make_interval(days => $2)
+          AND NOT spec_runs.status = ANY($4)
+    ),
+    runs AS (
+        SELECT COUNT(DISTINCT test_run_id) AS total FROM window_runs
+    ),
+    failures AS (
+        SELECT DISTINCT test_run_id, test_name
+        FROM window_runs
+        WHERE NOT status = ANY($3)
+    ),
+    counts AS (
+        SELECT test_name, COUNT(*) AS failures
+        FROM failures
+        GROUP BY test_name
+    )
+    SELECT
+        a.test_name AS test_a,
+        b.test_name AS test_b,
+        COUNT(*) AS co_failures,
+        ca.failures AS test_a_failures,
+        cb.failures AS test_b_failures,
+        COUNT(*)::float8 * runs.total / (ca.failures * cb.failures) AS lift
+    FROM failures a
+    JOIN failures b ON b.test_run_id = a.test_run_id AND a.test_name < b.test_name
+    JOIN counts ca ON ca.test_name = a.test_name
+    JOIN counts cb ON cb.test_name = b.test_name
+    CROSS JOIN runs
+    GROUP BY a.test_name, b.test_name, ca.failures, cb.failures, runs.total
+    HAVING COUNT(*)::float8 * runs.total > ca.failures::float8 * cb.failures
+    ORDER BY co_failures DESC, lift DESC, test_a, test_b
+    LIMIT $5;
+	`
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "co_failing_tests", query, projectID, DefaultSinceDays,
+		r.successStatuses, r.ignoredStatuses, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*gql.CoFailure{}
+	for rows.Next() {
+		pair := &gql.CoFailure{}
+		if err := rows.Scan(&pair.TestA, &pair.TestB, &pair.CoFailureCount,
+			&pair.TestAFailures, &pair.TestBFailures, &pair.Lift); err != nil {
+			return nil, err
+		}
+		results = append(results, pair)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,70 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestRepo.GetCoFailingTests", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.CoFailureProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("returns pairs of specs failing in the same test runs", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{
+			{"Checkout applies coupons", "Checkout charges cards", 4, 5, 4, 2.4},
+			{"Login redirects", "Login sets cookie", 2, 2, 3, 2.0},
+		}}, nil)
+
+		results, err := repoInst.GetCoFailingTests(ctx, "demo", 10)
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal([]*gql.CoFailure{
+			{TestA: "Checkout applies coupons", TestB: "Checkout charges cards", CoFailureCount: 4, TestAFailures: 5, TestBFailures: 4, Lift: 2.4},
+			{TestA: "Login redirects", TestB: "Login sets cookie", CoFailureCount: 2, TestAFailures: 2, TestBFailures: 3, Lift: 2.0},
+		}))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("b.test_run_id = a.test_run_id AND a.test_name < b.test_name"))
+		Expect(sql).To(ContainSubstring("HAVING COUNT(*)::float8 * runs.total > ca.failures::float8 * cb.failures"))
+		Expect(sql).To(ContainSubstring("ORDER BY co_failures DESC, lift DESC, test_a, test_b"))
+		Expect(args).To(Equal([]any{"demo", repo.DefaultSinceDays,
+			repo.DefaultSuccessStatuses, repo.DefaultIgnoredStatuses, 10}))
+	})
+
+	It("returns an empty list when no specs fail together", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		results, err := repoInst.GetCoFailingTests(ctx, "demo", 10)
+		Expect(err).To(BeNil())
+		Expect(results).NotTo(BeNil())
+		Expect(results).To(BeEmpty())
+	})
+
+	It("rejects an out-of-range limit without querying", func() {
+		_, err := repoInst.GetCoFailingTests(ctx, "demo", 0)
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		_, err := repoInst.GetCoFailingTests(ctx, "demo", 10)
+		Expect(err).To(MatchError("db down"))
+	})
+})
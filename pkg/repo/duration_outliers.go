@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// DefaultOutlierStdDevs is how many standard deviations above its spec's
+// mean a run must take to count as a duration outlier.
+const DefaultOutlierStdDevs = 3.0
+
+//go:generate counterfeiter -o fakes/fake_duration_outlier_provider.go . DurationOutlierProvider
+type DurationOutlierProvider interface {
+	GetDurationOutliers(ctx context.Context, projectID string, stdDevThreshold float64) ([]*gql.DurationOutlier, error)
+}
+
+// GetDurationOutliers returns the runs of the last DefaultSinceDays that
+// took more than stdDevThreshold standard deviations longer than their
+// spec's mean duration over the same window, furthest out first and at most
+// MaxLimit of them. The mean and population standard deviation include the
+// outlier itself, so a spec needs enough runs for a high threshold to be
+// reachable. Specs whose runs all took the same time have no outliers.
+func (r *SlowTestRepo) GetDurationOutliers(ctx context.Context, projectID string, stdDevThreshold float64) ([]*gql.DurationOutlier, error) {
+	if !(stdDevThreshold > 0) || math.IsInf(stdDevThreshold, 1) {
+		return nil, InvalidArgumentf("stdDevThreshold must be a positive number, got %g", stdDevThreshold)
+	}
+
+	query := `
+    SELECT
+        spec_runs.id,
+        test_runs.id,
+        spec_runs.spec_description,
+        spec_runs.start_time,
+        (EXTRACT(EPOCH FROM (spec_runs.end_time - spec_runs.start_time)) * 1000)::float8 AS duration_ms
+    FROM spec_runs` + projectJoins + `
+    WHERE ` + projectMatch + `
+      AND spec_runs.start_time IS NOT NULL
+      AND spec_runs.end_time IS NOT NULL
+      AND spec_runs.start_time >= NOW() - make_interval(days => $2)
+    ORDER BY spec_runs.spec_description, spec_runs.id;
+	`
+	rows, err := timedQuery(ctx, r.db, "duration_outliers", query, projectID, DefaultSinceDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runsByTest := map[string][]*gql.DurationOutlier{}
+	var testNames []string
+	for rows.Next() {
+		var specRunID, testRunID int64
+		var startTime *time.Time
+		run := &gql.DurationOutlier{}
+
+		if err := rows.Scan(&specRunID, &testRunID, &run.TestName, &startTime, &run.DurationMs); err != nil {
+			return nil, err
+		}
+
+		run.SpecRunID = strconv.FormatInt(specRunID, 10)
+		run.TestRunID = strconv.FormatInt(testRunID, 10)
+		run.StartTime = formatTime(startTime)
+		if _, seen := runsByTest[run.TestName]; !seen {
+			testNames = append(testNames, run.TestName)
+		}
+		runsByTest[run.TestName] = append(runsByTest[run.TestName], run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var results []*gql.DurationOutlier
+	for _, name := range testNames {
+		results = append(results, durationOutliers(runsByTest[name], stdDevThreshold)...)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Deviations > results[j].Deviations
+	})
+	if len(results) > MaxLimit {
+		results = results[:MaxLimit]
+	}
+	return results, nil
+}
+
+// durationOutliers returns the runs of one spec more than threshold
+// standard deviations above their mean, with the statistics filled in.
+func durationOutliers(runs []*gql.DurationOutlier, threshold float64) []*gql.DurationOutlier {
+	var sum float64
+	for _, run := range runs {
+		sum += run.DurationMs
+	}
+	mean := sum / float64(len(runs))
+
+	var squares float64
+	for _, run := range runs {
+		squares += (run.DurationMs - mean) * (run.DurationMs - mean)
+	}
+	stdDev := math.Sqrt(squares / float64(len(runs)))
+	if stdDev == 0 {
+		return nil
+	}
+
+	var outliers []*gql.DurationOutlier
+	for _, run := range runs {
+		deviations := (run.DurationMs - mean) / stdDev
+		if deviations <= threshold {
+			continue
+		}
+		run.MeanDurationMs = mean
+		run.StdDevDurationMs = stdDev
+		run.Deviations = deviations
+		outliers = append(outliers, run)
+	}
+	return outliers
+}
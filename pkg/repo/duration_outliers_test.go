@@ -0,0 +1,106 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("SlowTestRepo.GetDurationOutliers", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.DurationOutlierProvider
+		start    time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewSlowTestRepo(fakeDB)
+		start = time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	})
+
+	// runs returns one row per duration for the named spec, with spec run
+	// IDs counting up from firstID.
+	runs := func(name string, firstID int64, durations ...float64) [][]any {
+		rows := make([][]any, 0, len(durations))
+		for i, d := range durations {
+			id := firstID + int64(i)
+			rows = append(rows, []any{id, int64(100) + id, name, start.Add(time.Duration(id) * time.Hour), d})
+		}
+		return rows
+	}
+
+	It("returns the run far slower than its spec's other runs", func() {
+		data := runs("Checkout charges cards", 1, 99, 101, 99, 101, 99, 101, 1000, 99, 101, 99, 101, 100)
+		data = append(data, runs("Login redirects", 20, 50, 52, 48, 51, 49)...)
+		fakeDB.QueryReturns(&fakeRows{data: data}, nil)
+
+		results, err := repoInst.GetDurationOutliers(ctx, "demo", repo.DefaultOutlierStdDevs)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(1))
+
+		outlier := results[0]
+		Expect(outlier.TestName).To(Equal("Checkout charges cards"))
+		Expect(outlier.SpecRunID).To(Equal("7"))
+		Expect(outlier.TestRunID).To(Equal("107"))
+		Expect(outlier.StartTime).To(HaveValue(Equal("2025-04-01T17:00:00Z")))
+		Expect(outlier.DurationMs).To(Equal(1000.0))
+		Expect(outlier.MeanDurationMs).To(BeNumerically("~", 175, 0.01))
+		Expect(outlier.StdDevDurationMs).To(BeNumerically("~", 248.75, 0.01))
+		Expect(outlier.Deviations).To(BeNumerically("~", 3.317, 0.001))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("spec_runs.end_time IS NOT NULL"))
+		Expect(args).To(Equal([]any{"demo", repo.DefaultSinceDays}))
+	})
+
+	It("orders outliers of several specs by how far out they are", func() {
+		data := runs("A spec", 1, 10, 10, 10, 40)
+		data = append(data, runs("B spec", 10, 10, 10, 10, 10, 10, 10, 10, 10, 90)...)
+		fakeDB.QueryReturns(&fakeRows{data: data}, nil)
+
+		results, err := repoInst.GetDurationOutliers(ctx, "demo", 1)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].TestName).To(Equal("B spec"))
+		Expect(results[1].TestName).To(Equal("A spec"))
+		Expect(results[0].Deviations).To(BeNumerically(">", results[1].Deviations))
+	})
+
+	It("finds no outliers when every run took as long", func() {
+		fakeDB.QueryReturns(&fakeRows{data: runs("Stable spec", 1, 100, 100, 100)}, nil)
+
+		results, err := repoInst.GetDurationOutliers(ctx, "demo", 1)
+		Expect(err).To(BeNil())
+		Expect(results).To(BeEmpty())
+	})
+
+	DescribeTable("rejects a threshold that is not positive without querying",
+		func(threshold float64) {
+			_, err := repoInst.GetDurationOutliers(ctx, "demo", threshold)
+			Expect(err).To(MatchError(ContainSubstring("stdDevThreshold must be a positive number")))
+			Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+			Expect(fakeDB.QueryCallCount()).To(Equal(0))
+		},
+		Entry("zero", 0.0),
+		Entry("negative", -2.0),
+		Entry("NaN", math.NaN()),
+		Entry("infinite", math.Inf(1)),
+	)
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		_, err := repoInst.GetDurationOutliers(ctx, "demo", 3)
+		Expect(err).To(MatchError("db down"))
+	})
+})
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Error kinds returned by the repositories. Match them with errors.Is; the
+// concrete errors carry their own messages.
+var (
+	// ErrInvalidArgument marks a request the caller must change to succeed.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrNotFound marks a lookup of something that does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrUnavailable marks a query that failed because the database could
+	// not be reached or did not answer in time.
+	ErrUnavailable = errors.New("database unavailable")
+)
+
+// kindError is an error of one of the kinds above, keeping its own message.
+type kindError struct {
+	kind  error
+	msg   string
+	cause error
+}
+
+func (e *kindError) Error() string        { return e.msg }
+func (e *kindError) Is(target error) bool { return target == e.kind }
+func (e *kindError) Unwrap() error        { return e.cause }
+
+// InvalidArgumentf returns an ErrInvalidArgument error with a formatted
+// message, for validation failures outside the repositories too.
+func InvalidArgumentf(format string, args ...any) error {
+	return &kindError{kind: ErrInvalidArgument, msg: fmt.Sprintf(format, args...)}
+}
+
+// classifyQueryError marks err as ErrUnavailable when it comes from a lost
+// or refused connection, a timeout, or the server shutting down.
+func classifyQueryError(err error) error {
+	if err == nil || !isUnavailable(err) {
+		return err
+	}
+	return &kindError{kind: ErrUnavailable, msg: err.Error(), cause: err}
+}
+
+func isUnavailable(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &connectErr), errors.As(err, &netErr), pgconn.Timeout(err):
+		return true
+	case errors.As(err, &pgErr):
+		// Class 08 is connection exceptions; 57P01-57P03 are shutdowns and
+		// a server not yet accepting connections.
+		return strings.HasPrefix(pgErr.Code, "08") || pgErr.Code == "57P01" || pgErr.Code == "57P02" || pgErr.Code == "57P03"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,43 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	"github.com/jackc/pgx/v5/pgconn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Repository errors", func() {
+	It("should mark validation failures as invalid arguments", func() {
+		_, err := repo.NewFlakyTestRepo(&fakes.FakePgxQuerier{}).GetFlakyTests(context.Background(), "demo", 5, repo.FlakyTestOptions{MinRuns: -1})
+		Expect(err).To(MatchError("minRuns must be non-negative, got -1"))
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+
+		Expect(errors.Is(fmt.Errorf("%w: bad base64", repo.ErrInvalidCursor), repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(errors.Is(repo.ErrProjectNotFound, repo.ErrNotFound)).To(BeTrue())
+		Expect(errors.Is(repo.ErrProjectNotFound, repo.ErrInvalidArgument)).To(BeFalse())
+	})
+
+	DescribeTable("should classify query failures",
+		func(queryErr error, unavailable bool) {
+			fakeDB := &fakes.FakePgxQuerier{}
+			fakeDB.QueryReturns(nil, queryErr)
+
+			_, err := repo.NewFlakyTestRepo(fakeDB).GetFlakyTests(context.Background(), "demo", 5, repo.FlakyTestOptions{})
+			Expect(err).To(MatchError(queryErr))
+			Expect(err.Error()).To(Equal(queryErr.Error()))
+			Expect(errors.Is(err, repo.ErrUnavailable)).To(Equal(unavailable))
+		},
+		Entry("a refused connection", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true),
+		Entry("a server shutting down", &pgconn.PgError{Code: "57P01", Message: "terminating connection due to administrator command"}, true),
+		Entry("a connection exception", &pgconn.PgError{Code: "08006", Message: "connection failure"}, true),
+		Entry("a missing table", &pgconn.PgError{Code: "42P01", Message: `relation "spec_runs" does not exist`}, false),
+		Entry("any other error", errors.New("boom"), false),
+	)
+})
@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_failure_actor_provider.go . FailureActorProvider
+type FailureActorProvider interface {
+	GetFailureActors(ctx context.Context, projectID, testName string, sinceDays int) ([]*gql.ActorCount, error)
+}
+
+// GetFailureActors counts a spec's failing runs by the build_trigger_actor
+// of the test run they belong to, most frequent first, over the last
+// sinceDays (DefaultSinceDays when zero). Runs without an actor are left
+// out rather than grouped under a placeholder.
+func (r *FlakyTestRepo) GetFailureActors(ctx context.Context, projectID, testName string, sinceDays int) ([]*gql.ActorCount, error) {
+	if sinceDays < 0 {
+		return nil, InvalidArgumentf("sinceDays must be non-negative, got %d", sinceDays)
+	}
+	if sinceDays == 0 {
+		sinceDays = DefaultSinceDays
+	}
+
+	query := `
+    SELECT test_runs.build_trigger_actor AS actor, COUNT(*) AS failure_count
+    FROM spec_runs` + projectJoins + `
+    WHERE ` + projectMatch + `
+      AND spec_runs.spec_description = $2
+      AND spec_runs.start_time >= NOW() - make_interval(days => $3)
+      AND NOT spec_runs.status = ANY($4)
+      AND NOT spec_runs.status = ANY($5)
+      AND NULLIF(TRIM(test_runs.build_trigger_actor), '') IS NOT NULL
+    GROUP BY test_runs.build_trigger_actor
+    ORDER BY failure_count DESC, actor;
+	`
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "flaky_test_failure_actors", query, projectID, testName, sinceDays,
+		r.successStatuses, r.ignoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*gql.ActorCount{}
+	for rows.Next() {
+		actor := &gql.ActorCount{}
+		if err := rows.Scan(&actor.Actor, &actor.Count); err != nil {
+			return nil, err
+		}
+		results = append(results, actor)
+	}
+	return results, rows.Err()
+}
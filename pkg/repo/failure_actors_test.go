@@ -0,0 +1,76 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestRepo.GetFailureActors", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.FailureActorProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("counts failing runs per build trigger actor", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{
+			{"renovate[bot]", 6},
+			{"alice", 3},
+			{"nightly-cron", 1},
+		}}, nil)
+
+		results, err := repoInst.GetFailureActors(ctx, "demo", "LoginService handles expired tokens", 14)
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal([]*gql.ActorCount{
+			{Actor: "renovate[bot]", Count: 6},
+			{Actor: "alice", Count: 3},
+			{Actor: "nightly-cron", Count: 1},
+		}))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("GROUP BY test_runs.build_trigger_actor"))
+		Expect(sql).To(ContainSubstring("NULLIF(TRIM(test_runs.build_trigger_actor), '') IS NOT NULL"))
+		Expect(sql).To(ContainSubstring("NOT spec_runs.status = ANY($4)"))
+		Expect(args).To(Equal([]any{"demo", "LoginService handles expired tokens", 14,
+			repo.DefaultSuccessStatuses, repo.DefaultIgnoredStatuses}))
+	})
+
+	It("uses the default lookback window and returns an empty list without failures", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		results, err := repoInst.GetFailureActors(ctx, "demo", "Stable spec", 0)
+		Expect(err).To(BeNil())
+		Expect(results).NotTo(BeNil())
+		Expect(results).To(BeEmpty())
+
+		_, _, args := fakeDB.QueryArgsForCall(0)
+		Expect(args[2]).To(Equal(repo.DefaultSinceDays))
+	})
+
+	It("rejects a negative lookback window without querying", func() {
+		_, err := repoInst.GetFailureActors(ctx, "demo", "Stable spec", -1)
+		Expect(err).To(MatchError("sinceDays must be non-negative, got -1"))
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		_, err := repoInst.GetFailureActors(ctx, "demo", "Stable spec", 0)
+		Expect(err).To(MatchError("db down"))
+	})
+})
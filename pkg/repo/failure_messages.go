@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// MaxFailureMessages caps how many distinct failure messages are attached to
+// each flaky test.
+const MaxFailureMessages = 5
+
+// failureMessageFilter narrows the runs whose messages and statuses are
+// counted so they match the runs behind the flaky test statistics.
+type failureMessageFilter struct {
+	suiteName string
+	// sinceDays limits the lookback window; zero means DefaultSinceDays,
+	// as it does for FlakyTestOptions.
+	sinceDays int
+	// namePattern is the FlakyTestOptions.NamePattern the tests were
+	// grouped with, so runs are matched to their normalized names.
+	namePattern string
+}
+
+// attachFailureMessages fills TopFailureMessages on each test with its most
+// frequent failure messages, fetched in a single query for every project.
+// byProject maps a project name or UUID to that project's tests. Runs
+// without a message, or with only whitespace, are not counted.
+func (r *FlakyTestRepo) attachFailureMessages(ctx context.Context, byProject map[string][]*gql.FlakyTest, filter failureMessageFilter) error {
+	byTest, projectIDs, names := indexFlakyTests(byProject)
+	if len(byTest) == 0 {
+		return nil
+	}
+
+	testName := testNameColumn(filter.namePattern, 8)
+	query := `
+    SELECT project_name, project_uuid, test_name, message, occurrences
+    FROM (
+        SELECT
+            project_details.name AS project_name,
+            project_details.uuid::text AS project_uuid,
+            ` + testName + ` AS test_name,
+            spec_runs.message,
+            COUNT(*) AS occurrences,
+            ROW_NUMBER() OVER (
+                PARTITION BY project_details.id, ` + testName + `
+                ORDER BY COUNT(*) DESC, spec_runs.message ASC
+            ) AS position
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectsMatch + `
+          AND ` + testName + ` = ANY($2)
+          AND NOT spec_runs.status = ANY($3)
+          AND NOT spec_runs.status = ANY($4)
+          AND NULLIF(TRIM(spec_runs.message), '') IS NOT NULL
+          AND ($5 = '' OR suite_runs.suite_name = $5)
+          AND spec_runs.start_time >= NOW() - make_interval(days => $6)
+        GROUP BY project_details.id, project_details.name, project_details.uuid,
+            ` + testName + `, spec_runs.message
+    ) ranked
+    WHERE position <= $7
+    ORDER BY project_name, test_name, position;
+	`
+	rows, err := timedQuery(ctx, r.reader(), "flaky_test_failure_messages", query, withNamePattern(filter.namePattern,
+		projectIDs, names, r.successStatuses, r.ignoredStatuses, filter.suiteName, filter.sinceDays, MaxFailureMessages)...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var projectName, projectUUID, testName, message string
+		var count int
+		if err := rows.Scan(&projectName, &projectUUID, &testName, &message, &count); err != nil {
+			return err
+		}
+
+		// A project may have been requested by name, by UUID, or both.
+		for _, projectID := range []string{projectName, projectUUID} {
+			test, ok := byTest[flakyTestKey{projectID, testName}]
+			if !ok || len(test.TopFailureMessages) >= MaxFailureMessages {
+				continue
+			}
+			test.TopFailureMessages = append(test.TopFailureMessages, &gql.FailureMessage{Message: message, Count: count})
+		}
+	}
+	return classifyQueryError(rows.Err())
+}
+
+// flakyTestKey identifies a test within the project it was requested for.
+type flakyTestKey struct{ projectID, testName string }
+
+// indexFlakyTests maps each test of byProject by project and name for the
+// follow-up queries, returning the projects and distinct names to query.
+func indexFlakyTests(byProject map[string][]*gql.FlakyTest) (map[flakyTestKey]*gql.FlakyTest, []string, []string) {
+	byTest := map[flakyTestKey]*gql.FlakyTest{}
+	projectIDs := make([]string, 0, len(byProject))
+	var names []string
+	seenNames := map[string]bool{}
+	for projectID, tests := range byProject {
+		if len(tests) == 0 {
+			continue
+		}
+		projectIDs = append(projectIDs, projectID)
+		for _, test := range tests {
+			byTest[flakyTestKey{projectID, test.TestName}] = test
+			if !seenNames[test.TestName] {
+				seenNames[test.TestName] = true
+				names = append(names, test.TestName)
+			}
+		}
+	}
+	return byTest, projectIDs, names
+}
+
+// attachRunDetails fills in the failure messages and status counts of the
+// tests, which the flaky test queries do not aggregate themselves.
+func (r *FlakyTestRepo) attachRunDetails(ctx context.Context, byProject map[string][]*gql.FlakyTest, filter failureMessageFilter) error {
+	if filter.sinceDays == 0 {
+		filter.sinceDays = DefaultSinceDays
+	}
+	if err := r.attachFailureMessages(ctx, byProject, filter); err != nil {
+		return err
+	}
+	return r.attachStatusCounts(ctx, byProject, filter)
+}
@@ -0,0 +1,109 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakyTestRepo failure messages", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("attaches the most frequent messages to each flaky test", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{
+			{"login", 10, 6, nil},
+			{"logout", 4, 1, nil},
+			{"signup", 4, 2, nil},
+		}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{data: [][]any{
+			{"demo", "uuid-demo", "login", "timeout waiting for token", 4},
+			{"demo", "uuid-demo", "login", "connection reset", 2},
+			{"demo", "uuid-demo", "logout", "session not found", 1},
+		}}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{}, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 3, repo.FlakyTestOptions{SuiteName: "Auth Suite", SinceDays: 7})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(3))
+		Expect(results[0].TopFailureMessages).To(Equal([]*gql.FailureMessage{
+			{Message: "timeout waiting for token", Count: 4},
+			{Message: "connection reset", Count: 2},
+		}))
+		Expect(results[1].TopFailureMessages).To(Equal([]*gql.FailureMessage{
+			{Message: "session not found", Count: 1},
+		}))
+		Expect(results[2].TopFailureMessages).To(BeNil())
+
+		Expect(fakeDB.QueryCallCount()).To(Equal(3))
+		_, sql, args := fakeDB.QueryArgsForCall(1)
+		Expect(sql).To(ContainSubstring("NULLIF(TRIM(spec_runs.message), '') IS NOT NULL"))
+		Expect(sql).To(ContainSubstring("WHERE position <= $7"))
+		Expect(sql).To(ContainSubstring("AND spec_runs.start_time >= NOW() - make_interval(days => $6)"))
+		Expect(sql).ToNot(ContainSubstring("$6 = 0"))
+		Expect(args[0]).To(Equal([]string{"demo"}))
+		Expect(args[1]).To(ConsistOf("login", "logout", "signup"))
+		Expect(args[4:]).To(Equal([]any{"Auth Suite", 7, repo.MaxFailureMessages}))
+	})
+
+	It("caps the messages attached to a single test", func() {
+		var messages [][]any
+		for i := range repo.MaxFailureMessages + 2 {
+			messages = append(messages, []any{"demo", "uuid-demo", "login", fmt.Sprintf("failure %d", i), 10 - i})
+		}
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"login", 20, 20, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{data: messages}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{}, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 1, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results[0].TopFailureMessages).To(HaveLen(repo.MaxFailureMessages))
+		Expect(results[0].TopFailureMessages[0]).To(Equal(&gql.FailureMessage{Message: "failure 0", Count: 10}))
+	})
+
+	It("skips the message query when no flaky tests are found", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(BeEmpty())
+		Expect(fakeDB.QueryCallCount()).To(Equal(1))
+	})
+
+	It("returns an error when the message query fails", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"login", 10, 6, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, nil, errors.New("boom"))
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 1, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError("boom"))
+		Expect(results).To(BeNil())
+	})
+
+	It("attaches messages to paged results over the default lookback window", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"login", 10, 6, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{data: [][]any{{"demo", "uuid-demo", "login", "timeout", 6}}}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{}, nil)
+
+		results, _, err := repoInst.GetFlakyTestsPage(ctx, "demo", 5, nil)
+		Expect(err).To(BeNil())
+		Expect(results[0].TopFailureMessages).To(Equal([]*gql.FailureMessage{{Message: "timeout", Count: 6}}))
+
+		_, _, args := fakeDB.QueryArgsForCall(1)
+		Expect(args[4:]).To(Equal([]any{"", repo.DefaultSinceDays, repo.MaxFailureMessages}))
+	})
+})
@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeCoFailureProvider struct {
+	GetCoFailingTestsStub        func(context.Context, string, int) ([]*gql.CoFailure, error)
+	getCoFailingTestsMutex       sync.RWMutex
+	getCoFailingTestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}
+	getCoFailingTestsReturns struct {
+		result1 []*gql.CoFailure
+		result2 error
+	}
+	getCoFailingTestsReturnsOnCall map[int]struct {
+		result1 []*gql.CoFailure
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCoFailureProvider) GetCoFailingTests(arg1 context.Context, arg2 string, arg3 int) ([]*gql.CoFailure, error) {
+	fake.getCoFailingTestsMutex.Lock()
+	ret, specificReturn := fake.getCoFailingTestsReturnsOnCall[len(fake.getCoFailingTestsArgsForCall)]
+	fake.getCoFailingTestsArgsForCall = append(fake.getCoFailingTestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetCoFailingTestsStub
+	fakeReturns := fake.getCoFailingTestsReturns
+	fake.recordInvocation("GetCoFailingTests", []interface{}{arg1, arg2, arg3})
+	fake.getCoFailingTestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCoFailureProvider) GetCoFailingTestsCallCount() int {
+	fake.getCoFailingTestsMutex.RLock()
+	defer fake.getCoFailingTestsMutex.RUnlock()
+	return len(fake.getCoFailingTestsArgsForCall)
+}
+
+func (fake *FakeCoFailureProvider) GetCoFailingTestsCalls(stub func(context.Context, string, int) ([]*gql.CoFailure, error)) {
+	fake.getCoFailingTestsMutex.Lock()
+	defer fake.getCoFailingTestsMutex.Unlock()
+	fake.GetCoFailingTestsStub = stub
+}
+
+func (fake *FakeCoFailureProvider) GetCoFailingTestsArgsForCall(i int) (context.Context, string, int) {
+	fake.getCoFailingTestsMutex.RLock()
+	defer fake.getCoFailingTestsMutex.RUnlock()
+	argsForCall := fake.getCoFailingTestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoFailureProvider) GetCoFailingTestsReturns(result1 []*gql.CoFailure, result2 error) {
+	fake.getCoFailingTestsMutex.Lock()
+	defer fake.getCoFailingTestsMutex.Unlock()
+	fake.GetCoFailingTestsStub = nil
+	fake.getCoFailingTestsReturns = struct {
+		result1 []*gql.CoFailure
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCoFailureProvider) GetCoFailingTestsReturnsOnCall(i int, result1 []*gql.CoFailure, result2 error) {
+	fake.getCoFailingTestsMutex.Lock()
+	defer fake.getCoFailingTestsMutex.Unlock()
+	fake.GetCoFailingTestsStub = nil
+	if fake.getCoFailingTestsReturnsOnCall == nil {
+		fake.getCoFailingTestsReturnsOnCall = make(map[int]struct {
+			result1 []*gql.CoFailure
+			result2 error
+		})
+	}
+	fake.getCoFailingTestsReturnsOnCall[i] = struct {
+		result1 []*gql.CoFailure
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCoFailureProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getCoFailingTestsMutex.RLock()
+	defer fake.getCoFailingTestsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCoFailureProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.CoFailureProvider = new(FakeCoFailureProvider)
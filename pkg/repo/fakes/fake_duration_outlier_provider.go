@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeDurationOutlierProvider struct {
+	GetDurationOutliersStub        func(context.Context, string, float64) ([]*gql.DurationOutlier, error)
+	getDurationOutliersMutex       sync.RWMutex
+	getDurationOutliersArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 float64
+	}
+	getDurationOutliersReturns struct {
+		result1 []*gql.DurationOutlier
+		result2 error
+	}
+	getDurationOutliersReturnsOnCall map[int]struct {
+		result1 []*gql.DurationOutlier
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDurationOutlierProvider) GetDurationOutliers(arg1 context.Context, arg2 string, arg3 float64) ([]*gql.DurationOutlier, error) {
+	fake.getDurationOutliersMutex.Lock()
+	ret, specificReturn := fake.getDurationOutliersReturnsOnCall[len(fake.getDurationOutliersArgsForCall)]
+	fake.getDurationOutliersArgsForCall = append(fake.getDurationOutliersArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 float64
+	}{arg1, arg2, arg3})
+	stub := fake.GetDurationOutliersStub
+	fakeReturns := fake.getDurationOutliersReturns
+	fake.recordInvocation("GetDurationOutliers", []interface{}{arg1, arg2, arg3})
+	fake.getDurationOutliersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDurationOutlierProvider) GetDurationOutliersCallCount() int {
+	fake.getDurationOutliersMutex.RLock()
+	defer fake.getDurationOutliersMutex.RUnlock()
+	return len(fake.getDurationOutliersArgsForCall)
+}
+
+func (fake *FakeDurationOutlierProvider) GetDurationOutliersCalls(stub func(context.Context, string, float64) ([]*gql.DurationOutlier, error)) {
+	fake.getDurationOutliersMutex.Lock()
+	defer fake.getDurationOutliersMutex.Unlock()
+	fake.GetDurationOutliersStub = stub
+}
+
+func (fake *FakeDurationOutlierProvider) GetDurationOutliersArgsForCall(i int) (context.Context, string, float64) {
+	fake.getDurationOutliersMutex.RLock()
+	defer fake.getDurationOutliersMutex.RUnlock()
+	argsForCall := fake.getDurationOutliersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDurationOutlierProvider) GetDurationOutliersReturns(result1 []*gql.DurationOutlier, result2 error) {
+	fake.getDurationOutliersMutex.Lock()
+	defer fake.getDurationOutliersMutex.Unlock()
+	fake.GetDurationOutliersStub = nil
+	fake.getDurationOutliersReturns = struct {
+		result1 []*gql.DurationOutlier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDurationOutlierProvider) GetDurationOutliersReturnsOnCall(i int, result1 []*gql.DurationOutlier, result2 error) {
+	fake.getDurationOutliersMutex.Lock()
+	defer fake.getDurationOutliersMutex.Unlock()
+	fake.GetDurationOutliersStub = nil
+	if fake.getDurationOutliersReturnsOnCall == nil {
+		fake.getDurationOutliersReturnsOnCall = make(map[int]struct {
+			result1 []*gql.DurationOutlier
+			result2 error
+		})
+	}
+	fake.getDurationOutliersReturnsOnCall[i] = struct {
+		result1 []*gql.DurationOutlier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDurationOutlierProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getDurationOutliersMutex.RLock()
+	defer fake.getDurationOutliersMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDurationOutlierProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.DurationOutlierProvider = new(FakeDurationOutlierProvider)
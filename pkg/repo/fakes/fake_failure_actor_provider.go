@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeFailureActorProvider struct {
+	GetFailureActorsStub        func(context.Context, string, string, int) ([]*gql.ActorCount, error)
+	getFailureActorsMutex       sync.RWMutex
+	getFailureActorsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+	}
+	getFailureActorsReturns struct {
+		result1 []*gql.ActorCount
+		result2 error
+	}
+	getFailureActorsReturnsOnCall map[int]struct {
+		result1 []*gql.ActorCount
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFailureActorProvider) GetFailureActors(arg1 context.Context, arg2 string, arg3 string, arg4 int) ([]*gql.ActorCount, error) {
+	fake.getFailureActorsMutex.Lock()
+	ret, specificReturn := fake.getFailureActorsReturnsOnCall[len(fake.getFailureActorsArgsForCall)]
+	fake.getFailureActorsArgsForCall = append(fake.getFailureActorsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetFailureActorsStub
+	fakeReturns := fake.getFailureActorsReturns
+	fake.recordInvocation("GetFailureActors", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getFailureActorsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFailureActorProvider) GetFailureActorsCallCount() int {
+	fake.getFailureActorsMutex.RLock()
+	defer fake.getFailureActorsMutex.RUnlock()
+	return len(fake.getFailureActorsArgsForCall)
+}
+
+func (fake *FakeFailureActorProvider) GetFailureActorsCalls(stub func(context.Context, string, string, int) ([]*gql.ActorCount, error)) {
+	fake.getFailureActorsMutex.Lock()
+	defer fake.getFailureActorsMutex.Unlock()
+	fake.GetFailureActorsStub = stub
+}
+
+func (fake *FakeFailureActorProvider) GetFailureActorsArgsForCall(i int) (context.Context, string, string, int) {
+	fake.getFailureActorsMutex.RLock()
+	defer fake.getFailureActorsMutex.RUnlock()
+	argsForCall := fake.getFailureActorsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeFailureActorProvider) GetFailureActorsReturns(result1 []*gql.ActorCount, result2 error) {
+	fake.getFailureActorsMutex.Lock()
+	defer fake.getFailureActorsMutex.Unlock()
+	fake.GetFailureActorsStub = nil
+	fake.getFailureActorsReturns = struct {
+		result1 []*gql.ActorCount
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFailureActorProvider) GetFailureActorsReturnsOnCall(i int, result1 []*gql.ActorCount, result2 error) {
+	fake.getFailureActorsMutex.Lock()
+	defer fake.getFailureActorsMutex.Unlock()
+	fake.GetFailureActorsStub = nil
+	if fake.getFailureActorsReturnsOnCall == nil {
+		fake.getFailureActorsReturnsOnCall = make(map[int]struct {
+			result1 []*gql.ActorCount
+			result2 error
+		})
+	}
+	fake.getFailureActorsReturnsOnCall[i] = struct {
+		result1 []*gql.ActorCount
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFailureActorProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getFailureActorsMutex.RLock()
+	defer fake.getFailureActorsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeFailureActorProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.FailureActorProvider = new(FakeFailureActorProvider)
@@ -0,0 +1,129 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeFlakyTestBatcher struct {
+	GetFlakyTestsBatchStub        func(context.Context, []string, int, repo.FlakyTestOptions) (map[string][]*gql.FlakyTest, error)
+	getFlakyTestsBatchMutex       sync.RWMutex
+	getFlakyTestsBatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 int
+		arg4 repo.FlakyTestOptions
+	}
+	getFlakyTestsBatchReturns struct {
+		result1 map[string][]*gql.FlakyTest
+		result2 error
+	}
+	getFlakyTestsBatchReturnsOnCall map[int]struct {
+		result1 map[string][]*gql.FlakyTest
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFlakyTestBatcher) GetFlakyTestsBatch(arg1 context.Context, arg2 []string, arg3 int, arg4 repo.FlakyTestOptions) (map[string][]*gql.FlakyTest, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getFlakyTestsBatchMutex.Lock()
+	ret, specificReturn := fake.getFlakyTestsBatchReturnsOnCall[len(fake.getFlakyTestsBatchArgsForCall)]
+	fake.getFlakyTestsBatchArgsForCall = append(fake.getFlakyTestsBatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 int
+		arg4 repo.FlakyTestOptions
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.GetFlakyTestsBatchStub
+	fakeReturns := fake.getFlakyTestsBatchReturns
+	fake.recordInvocation("GetFlakyTestsBatch", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.getFlakyTestsBatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFlakyTestBatcher) GetFlakyTestsBatchCallCount() int {
+	fake.getFlakyTestsBatchMutex.RLock()
+	defer fake.getFlakyTestsBatchMutex.RUnlock()
+	return len(fake.getFlakyTestsBatchArgsForCall)
+}
+
+func (fake *FakeFlakyTestBatcher) GetFlakyTestsBatchCalls(stub func(context.Context, []string, int, repo.FlakyTestOptions) (map[string][]*gql.FlakyTest, error)) {
+	fake.getFlakyTestsBatchMutex.Lock()
+	defer fake.getFlakyTestsBatchMutex.Unlock()
+	fake.GetFlakyTestsBatchStub = stub
+}
+
+func (fake *FakeFlakyTestBatcher) GetFlakyTestsBatchArgsForCall(i int) (context.Context, []string, int, repo.FlakyTestOptions) {
+	fake.getFlakyTestsBatchMutex.RLock()
+	defer fake.getFlakyTestsBatchMutex.RUnlock()
+	argsForCall := fake.getFlakyTestsBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeFlakyTestBatcher) GetFlakyTestsBatchReturns(result1 map[string][]*gql.FlakyTest, result2 error) {
+	fake.getFlakyTestsBatchMutex.Lock()
+	defer fake.getFlakyTestsBatchMutex.Unlock()
+	fake.GetFlakyTestsBatchStub = nil
+	fake.getFlakyTestsBatchReturns = struct {
+		result1 map[string][]*gql.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFlakyTestBatcher) GetFlakyTestsBatchReturnsOnCall(i int, result1 map[string][]*gql.FlakyTest, result2 error) {
+	fake.getFlakyTestsBatchMutex.Lock()
+	defer fake.getFlakyTestsBatchMutex.Unlock()
+	fake.GetFlakyTestsBatchStub = nil
+	if fake.getFlakyTestsBatchReturnsOnCall == nil {
+		fake.getFlakyTestsBatchReturnsOnCall = make(map[int]struct {
+			result1 map[string][]*gql.FlakyTest
+			result2 error
+		})
+	}
+	fake.getFlakyTestsBatchReturnsOnCall[i] = struct {
+		result1 map[string][]*gql.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFlakyTestBatcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getFlakyTestsBatchMutex.RLock()
+	defer fake.getFlakyTestsBatchMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeFlakyTestBatcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.FlakyTestBatcher = new(FakeFlakyTestBatcher)
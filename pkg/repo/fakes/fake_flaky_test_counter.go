@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeFlakyTestCounter struct {
+	CountFlakyTestsStub        func(context.Context, string, repo.FlakyTestOptions) (int, error)
+	countFlakyTestsMutex       sync.RWMutex
+	countFlakyTestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 repo.FlakyTestOptions
+	}
+	countFlakyTestsReturns struct {
+		result1 int
+		result2 error
+	}
+	countFlakyTestsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFlakyTestCounter) CountFlakyTests(arg1 context.Context, arg2 string, arg3 repo.FlakyTestOptions) (int, error) {
+	fake.countFlakyTestsMutex.Lock()
+	ret, specificReturn := fake.countFlakyTestsReturnsOnCall[len(fake.countFlakyTestsArgsForCall)]
+	fake.countFlakyTestsArgsForCall = append(fake.countFlakyTestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 repo.FlakyTestOptions
+	}{arg1, arg2, arg3})
+	stub := fake.CountFlakyTestsStub
+	fakeReturns := fake.countFlakyTestsReturns
+	fake.recordInvocation("CountFlakyTests", []interface{}{arg1, arg2, arg3})
+	fake.countFlakyTestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFlakyTestCounter) CountFlakyTestsCallCount() int {
+	fake.countFlakyTestsMutex.RLock()
+	defer fake.countFlakyTestsMutex.RUnlock()
+	return len(fake.countFlakyTestsArgsForCall)
+}
+
+func (fake *FakeFlakyTestCounter) CountFlakyTestsCalls(stub func(context.Context, string, repo.FlakyTestOptions) (int, error)) {
+	fake.countFlakyTestsMutex.Lock()
+	defer fake.countFlakyTestsMutex.Unlock()
+	fake.CountFlakyTestsStub = stub
+}
+
+func (fake *FakeFlakyTestCounter) CountFlakyTestsArgsForCall(i int) (context.Context, string, repo.FlakyTestOptions) {
+	fake.countFlakyTestsMutex.RLock()
+	defer fake.countFlakyTestsMutex.RUnlock()
+	argsForCall := fake.countFlakyTestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeFlakyTestCounter) CountFlakyTestsReturns(result1 int, result2 error) {
+	fake.countFlakyTestsMutex.Lock()
+	defer fake.countFlakyTestsMutex.Unlock()
+	fake.CountFlakyTestsStub = nil
+	fake.countFlakyTestsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFlakyTestCounter) CountFlakyTestsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.countFlakyTestsMutex.Lock()
+	defer fake.countFlakyTestsMutex.Unlock()
+	fake.CountFlakyTestsStub = nil
+	if fake.countFlakyTestsReturnsOnCall == nil {
+		fake.countFlakyTestsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.countFlakyTestsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFlakyTestCounter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.countFlakyTestsMutex.RLock()
+	defer fake.countFlakyTestsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeFlakyTestCounter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.FlakyTestCounter = new(FakeFlakyTestCounter)
@@ -0,0 +1,129 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeFlakyTestPager struct {
+	GetFlakyTestsPageStub        func(context.Context, string, int, *repo.FlakyTestCursor) ([]*gql.FlakyTest, bool, error)
+	getFlakyTestsPageMutex       sync.RWMutex
+	getFlakyTestsPageArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 *repo.FlakyTestCursor
+	}
+	getFlakyTestsPageReturns struct {
+		result1 []*gql.FlakyTest
+		result2 bool
+		result3 error
+	}
+	getFlakyTestsPageReturnsOnCall map[int]struct {
+		result1 []*gql.FlakyTest
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFlakyTestPager) GetFlakyTestsPage(arg1 context.Context, arg2 string, arg3 int, arg4 *repo.FlakyTestCursor) ([]*gql.FlakyTest, bool, error) {
+	fake.getFlakyTestsPageMutex.Lock()
+	ret, specificReturn := fake.getFlakyTestsPageReturnsOnCall[len(fake.getFlakyTestsPageArgsForCall)]
+	fake.getFlakyTestsPageArgsForCall = append(fake.getFlakyTestsPageArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 *repo.FlakyTestCursor
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetFlakyTestsPageStub
+	fakeReturns := fake.getFlakyTestsPageReturns
+	fake.recordInvocation("GetFlakyTestsPage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getFlakyTestsPageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeFlakyTestPager) GetFlakyTestsPageCallCount() int {
+	fake.getFlakyTestsPageMutex.RLock()
+	defer fake.getFlakyTestsPageMutex.RUnlock()
+	return len(fake.getFlakyTestsPageArgsForCall)
+}
+
+func (fake *FakeFlakyTestPager) GetFlakyTestsPageCalls(stub func(context.Context, string, int, *repo.FlakyTestCursor) ([]*gql.FlakyTest, bool, error)) {
+	fake.getFlakyTestsPageMutex.Lock()
+	defer fake.getFlakyTestsPageMutex.Unlock()
+	fake.GetFlakyTestsPageStub = stub
+}
+
+func (fake *FakeFlakyTestPager) GetFlakyTestsPageArgsForCall(i int) (context.Context, string, int, *repo.FlakyTestCursor) {
+	fake.getFlakyTestsPageMutex.RLock()
+	defer fake.getFlakyTestsPageMutex.RUnlock()
+	argsForCall := fake.getFlakyTestsPageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeFlakyTestPager) GetFlakyTestsPageReturns(result1 []*gql.FlakyTest, result2 bool, result3 error) {
+	fake.getFlakyTestsPageMutex.Lock()
+	defer fake.getFlakyTestsPageMutex.Unlock()
+	fake.GetFlakyTestsPageStub = nil
+	fake.getFlakyTestsPageReturns = struct {
+		result1 []*gql.FlakyTest
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeFlakyTestPager) GetFlakyTestsPageReturnsOnCall(i int, result1 []*gql.FlakyTest, result2 bool, result3 error) {
+	fake.getFlakyTestsPageMutex.Lock()
+	defer fake.getFlakyTestsPageMutex.Unlock()
+	fake.GetFlakyTestsPageStub = nil
+	if fake.getFlakyTestsPageReturnsOnCall == nil {
+		fake.getFlakyTestsPageReturnsOnCall = make(map[int]struct {
+			result1 []*gql.FlakyTest
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getFlakyTestsPageReturnsOnCall[i] = struct {
+		result1 []*gql.FlakyTest
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeFlakyTestPager) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getFlakyTestsPageMutex.RLock()
+	defer fake.getFlakyTestsPageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeFlakyTestPager) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.FlakyTestPager = new(FakeFlakyTestPager)
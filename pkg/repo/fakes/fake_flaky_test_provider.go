@@ -10,12 +10,13 @@ import (
 )
 
 type FakeFlakyTestProvider struct {
-	GetFlakyTestsStub        func(context.Context, string, int) ([]*gql.FlakyTest, error)
+	GetFlakyTestsStub        func(context.Context, string, int, repo.FlakyTestOptions) ([]*gql.FlakyTest, error)
 	getFlakyTestsMutex       sync.RWMutex
 	getFlakyTestsArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 int
+		arg4 repo.FlakyTestOptions
 	}
 	getFlakyTestsReturns struct {
 		result1 []*gql.FlakyTest
@@ -29,20 +30,21 @@ type FakeFlakyTestProvider struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFlakyTestProvider) GetFlakyTests(arg1 context.Context, arg2 string, arg3 int) ([]*gql.FlakyTest, error) {
+func (fake *FakeFlakyTestProvider) GetFlakyTests(arg1 context.Context, arg2 string, arg3 int, arg4 repo.FlakyTestOptions) ([]*gql.FlakyTest, error) {
 	fake.getFlakyTestsMutex.Lock()
 	ret, specificReturn := fake.getFlakyTestsReturnsOnCall[len(fake.getFlakyTestsArgsForCall)]
 	fake.getFlakyTestsArgsForCall = append(fake.getFlakyTestsArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 		arg3 int
-	}{arg1, arg2, arg3})
+		arg4 repo.FlakyTestOptions
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.GetFlakyTestsStub
 	fakeReturns := fake.getFlakyTestsReturns
-	fake.recordInvocation("GetFlakyTests", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("GetFlakyTests", []interface{}{arg1, arg2, arg3, arg4})
 	fake.getFlakyTestsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -56,17 +58,17 @@ func (fake *FakeFlakyTestProvider) GetFlakyTestsCallCount() int {
 	return len(fake.getFlakyTestsArgsForCall)
 }
 
-func (fake *FakeFlakyTestProvider) GetFlakyTestsCalls(stub func(context.Context, string, int) ([]*gql.FlakyTest, error)) {
+func (fake *FakeFlakyTestProvider) GetFlakyTestsCalls(stub func(context.Context, string, int, repo.FlakyTestOptions) ([]*gql.FlakyTest, error)) {
 	fake.getFlakyTestsMutex.Lock()
 	defer fake.getFlakyTestsMutex.Unlock()
 	fake.GetFlakyTestsStub = stub
 }
 
-func (fake *FakeFlakyTestProvider) GetFlakyTestsArgsForCall(i int) (context.Context, string, int) {
+func (fake *FakeFlakyTestProvider) GetFlakyTestsArgsForCall(i int) (context.Context, string, int, repo.FlakyTestOptions) {
 	fake.getFlakyTestsMutex.RLock()
 	defer fake.getFlakyTestsMutex.RUnlock()
 	argsForCall := fake.getFlakyTestsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeFlakyTestProvider) GetFlakyTestsReturns(result1 []*gql.FlakyTest, result2 error) {
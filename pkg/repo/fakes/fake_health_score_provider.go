@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeHealthScoreProvider struct {
+	GetProjectHealthScoreStub        func(context.Context, string) (*gql.HealthScore, error)
+	getProjectHealthScoreMutex       sync.RWMutex
+	getProjectHealthScoreArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getProjectHealthScoreReturns struct {
+		result1 *gql.HealthScore
+		result2 error
+	}
+	getProjectHealthScoreReturnsOnCall map[int]struct {
+		result1 *gql.HealthScore
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHealthScoreProvider) GetProjectHealthScore(arg1 context.Context, arg2 string) (*gql.HealthScore, error) {
+	fake.getProjectHealthScoreMutex.Lock()
+	ret, specificReturn := fake.getProjectHealthScoreReturnsOnCall[len(fake.getProjectHealthScoreArgsForCall)]
+	fake.getProjectHealthScoreArgsForCall = append(fake.getProjectHealthScoreArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetProjectHealthScoreStub
+	fakeReturns := fake.getProjectHealthScoreReturns
+	fake.recordInvocation("GetProjectHealthScore", []interface{}{arg1, arg2})
+	fake.getProjectHealthScoreMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeHealthScoreProvider) GetProjectHealthScoreCallCount() int {
+	fake.getProjectHealthScoreMutex.RLock()
+	defer fake.getProjectHealthScoreMutex.RUnlock()
+	return len(fake.getProjectHealthScoreArgsForCall)
+}
+
+func (fake *FakeHealthScoreProvider) GetProjectHealthScoreCalls(stub func(context.Context, string) (*gql.HealthScore, error)) {
+	fake.getProjectHealthScoreMutex.Lock()
+	defer fake.getProjectHealthScoreMutex.Unlock()
+	fake.GetProjectHealthScoreStub = stub
+}
+
+func (fake *FakeHealthScoreProvider) GetProjectHealthScoreArgsForCall(i int) (context.Context, string) {
+	fake.getProjectHealthScoreMutex.RLock()
+	defer fake.getProjectHealthScoreMutex.RUnlock()
+	argsForCall := fake.getProjectHealthScoreArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHealthScoreProvider) GetProjectHealthScoreReturns(result1 *gql.HealthScore, result2 error) {
+	fake.getProjectHealthScoreMutex.Lock()
+	defer fake.getProjectHealthScoreMutex.Unlock()
+	fake.GetProjectHealthScoreStub = nil
+	fake.getProjectHealthScoreReturns = struct {
+		result1 *gql.HealthScore
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHealthScoreProvider) GetProjectHealthScoreReturnsOnCall(i int, result1 *gql.HealthScore, result2 error) {
+	fake.getProjectHealthScoreMutex.Lock()
+	defer fake.getProjectHealthScoreMutex.Unlock()
+	fake.GetProjectHealthScoreStub = nil
+	if fake.getProjectHealthScoreReturnsOnCall == nil {
+		fake.getProjectHealthScoreReturnsOnCall = make(map[int]struct {
+			result1 *gql.HealthScore
+			result2 error
+		})
+	}
+	fake.getProjectHealthScoreReturnsOnCall[i] = struct {
+		result1 *gql.HealthScore
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHealthScoreProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getProjectHealthScoreMutex.RLock()
+	defer fake.getProjectHealthScoreMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeHealthScoreProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.HealthScoreProvider = new(FakeHealthScoreProvider)
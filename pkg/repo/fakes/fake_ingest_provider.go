@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeIngestProvider struct {
+	IngestTestRunStub        func(context.Context, *gql.TestRunInput) (*gql.IngestResult, error)
+	ingestTestRunMutex       sync.RWMutex
+	ingestTestRunArgsForCall []struct {
+		arg1 context.Context
+		arg2 *gql.TestRunInput
+	}
+	ingestTestRunReturns struct {
+		result1 *gql.IngestResult
+		result2 error
+	}
+	ingestTestRunReturnsOnCall map[int]struct {
+		result1 *gql.IngestResult
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeIngestProvider) IngestTestRun(arg1 context.Context, arg2 *gql.TestRunInput) (*gql.IngestResult, error) {
+	fake.ingestTestRunMutex.Lock()
+	ret, specificReturn := fake.ingestTestRunReturnsOnCall[len(fake.ingestTestRunArgsForCall)]
+	fake.ingestTestRunArgsForCall = append(fake.ingestTestRunArgsForCall, struct {
+		arg1 context.Context
+		arg2 *gql.TestRunInput
+	}{arg1, arg2})
+	stub := fake.IngestTestRunStub
+	fakeReturns := fake.ingestTestRunReturns
+	fake.recordInvocation("IngestTestRun", []interface{}{arg1, arg2})
+	fake.ingestTestRunMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIngestProvider) IngestTestRunCallCount() int {
+	fake.ingestTestRunMutex.RLock()
+	defer fake.ingestTestRunMutex.RUnlock()
+	return len(fake.ingestTestRunArgsForCall)
+}
+
+func (fake *FakeIngestProvider) IngestTestRunCalls(stub func(context.Context, *gql.TestRunInput) (*gql.IngestResult, error)) {
+	fake.ingestTestRunMutex.Lock()
+	defer fake.ingestTestRunMutex.Unlock()
+	fake.IngestTestRunStub = stub
+}
+
+func (fake *FakeIngestProvider) IngestTestRunArgsForCall(i int) (context.Context, *gql.TestRunInput) {
+	fake.ingestTestRunMutex.RLock()
+	defer fake.ingestTestRunMutex.RUnlock()
+	argsForCall := fake.ingestTestRunArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeIngestProvider) IngestTestRunReturns(result1 *gql.IngestResult, result2 error) {
+	fake.ingestTestRunMutex.Lock()
+	defer fake.ingestTestRunMutex.Unlock()
+	fake.IngestTestRunStub = nil
+	fake.ingestTestRunReturns = struct {
+		result1 *gql.IngestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIngestProvider) IngestTestRunReturnsOnCall(i int, result1 *gql.IngestResult, result2 error) {
+	fake.ingestTestRunMutex.Lock()
+	defer fake.ingestTestRunMutex.Unlock()
+	fake.IngestTestRunStub = nil
+	if fake.ingestTestRunReturnsOnCall == nil {
+		fake.ingestTestRunReturnsOnCall = make(map[int]struct {
+			result1 *gql.IngestResult
+			result2 error
+		})
+	}
+	fake.ingestTestRunReturnsOnCall[i] = struct {
+		result1 *gql.IngestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIngestProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.ingestTestRunMutex.RLock()
+	defer fake.ingestTestRunMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeIngestProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.IngestProvider = new(FakeIngestProvider)
@@ -0,0 +1,126 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeNewlyFlakyTestProvider struct {
+	GetNewlyFlakyTestsStub        func(context.Context, string, int, int, float64) ([]*gql.FlakyTest, error)
+	getNewlyFlakyTestsMutex       sync.RWMutex
+	getNewlyFlakyTestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 int
+		arg5 float64
+	}
+	getNewlyFlakyTestsReturns struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}
+	getNewlyFlakyTestsReturnsOnCall map[int]struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNewlyFlakyTestProvider) GetNewlyFlakyTests(arg1 context.Context, arg2 string, arg3 int, arg4 int, arg5 float64) ([]*gql.FlakyTest, error) {
+	fake.getNewlyFlakyTestsMutex.Lock()
+	ret, specificReturn := fake.getNewlyFlakyTestsReturnsOnCall[len(fake.getNewlyFlakyTestsArgsForCall)]
+	fake.getNewlyFlakyTestsArgsForCall = append(fake.getNewlyFlakyTestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 int
+		arg5 float64
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetNewlyFlakyTestsStub
+	fakeReturns := fake.getNewlyFlakyTestsReturns
+	fake.recordInvocation("GetNewlyFlakyTests", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getNewlyFlakyTestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNewlyFlakyTestProvider) GetNewlyFlakyTestsCallCount() int {
+	fake.getNewlyFlakyTestsMutex.RLock()
+	defer fake.getNewlyFlakyTestsMutex.RUnlock()
+	return len(fake.getNewlyFlakyTestsArgsForCall)
+}
+
+func (fake *FakeNewlyFlakyTestProvider) GetNewlyFlakyTestsCalls(stub func(context.Context, string, int, int, float64) ([]*gql.FlakyTest, error)) {
+	fake.getNewlyFlakyTestsMutex.Lock()
+	defer fake.getNewlyFlakyTestsMutex.Unlock()
+	fake.GetNewlyFlakyTestsStub = stub
+}
+
+func (fake *FakeNewlyFlakyTestProvider) GetNewlyFlakyTestsArgsForCall(i int) (context.Context, string, int, int, float64) {
+	fake.getNewlyFlakyTestsMutex.RLock()
+	defer fake.getNewlyFlakyTestsMutex.RUnlock()
+	argsForCall := fake.getNewlyFlakyTestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeNewlyFlakyTestProvider) GetNewlyFlakyTestsReturns(result1 []*gql.FlakyTest, result2 error) {
+	fake.getNewlyFlakyTestsMutex.Lock()
+	defer fake.getNewlyFlakyTestsMutex.Unlock()
+	fake.GetNewlyFlakyTestsStub = nil
+	fake.getNewlyFlakyTestsReturns = struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNewlyFlakyTestProvider) GetNewlyFlakyTestsReturnsOnCall(i int, result1 []*gql.FlakyTest, result2 error) {
+	fake.getNewlyFlakyTestsMutex.Lock()
+	defer fake.getNewlyFlakyTestsMutex.Unlock()
+	fake.GetNewlyFlakyTestsStub = nil
+	if fake.getNewlyFlakyTestsReturnsOnCall == nil {
+		fake.getNewlyFlakyTestsReturnsOnCall = make(map[int]struct {
+			result1 []*gql.FlakyTest
+			result2 error
+		})
+	}
+	fake.getNewlyFlakyTestsReturnsOnCall[i] = struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNewlyFlakyTestProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getNewlyFlakyTestsMutex.RLock()
+	defer fake.getNewlyFlakyTestsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNewlyFlakyTestProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.NewlyFlakyTestProvider = new(FakeNewlyFlakyTestProvider)
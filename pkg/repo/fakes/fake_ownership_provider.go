@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeOwnershipProvider struct {
+	GetTestOwnerStub        func(context.Context, string, string) (*gql.TestOwner, error)
+	getTestOwnerMutex       sync.RWMutex
+	getTestOwnerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getTestOwnerReturns struct {
+		result1 *gql.TestOwner
+		result2 error
+	}
+	getTestOwnerReturnsOnCall map[int]struct {
+		result1 *gql.TestOwner
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeOwnershipProvider) GetTestOwner(arg1 context.Context, arg2 string, arg3 string) (*gql.TestOwner, error) {
+	fake.getTestOwnerMutex.Lock()
+	ret, specificReturn := fake.getTestOwnerReturnsOnCall[len(fake.getTestOwnerArgsForCall)]
+	fake.getTestOwnerArgsForCall = append(fake.getTestOwnerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetTestOwnerStub
+	fakeReturns := fake.getTestOwnerReturns
+	fake.recordInvocation("GetTestOwner", []interface{}{arg1, arg2, arg3})
+	fake.getTestOwnerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeOwnershipProvider) GetTestOwnerCallCount() int {
+	fake.getTestOwnerMutex.RLock()
+	defer fake.getTestOwnerMutex.RUnlock()
+	return len(fake.getTestOwnerArgsForCall)
+}
+
+func (fake *FakeOwnershipProvider) GetTestOwnerCalls(stub func(context.Context, string, string) (*gql.TestOwner, error)) {
+	fake.getTestOwnerMutex.Lock()
+	defer fake.getTestOwnerMutex.Unlock()
+	fake.GetTestOwnerStub = stub
+}
+
+func (fake *FakeOwnershipProvider) GetTestOwnerArgsForCall(i int) (context.Context, string, string) {
+	fake.getTestOwnerMutex.RLock()
+	defer fake.getTestOwnerMutex.RUnlock()
+	argsForCall := fake.getTestOwnerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeOwnershipProvider) GetTestOwnerReturns(result1 *gql.TestOwner, result2 error) {
+	fake.getTestOwnerMutex.Lock()
+	defer fake.getTestOwnerMutex.Unlock()
+	fake.GetTestOwnerStub = nil
+	fake.getTestOwnerReturns = struct {
+		result1 *gql.TestOwner
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOwnershipProvider) GetTestOwnerReturnsOnCall(i int, result1 *gql.TestOwner, result2 error) {
+	fake.getTestOwnerMutex.Lock()
+	defer fake.getTestOwnerMutex.Unlock()
+	fake.GetTestOwnerStub = nil
+	if fake.getTestOwnerReturnsOnCall == nil {
+		fake.getTestOwnerReturnsOnCall = make(map[int]struct {
+			result1 *gql.TestOwner
+			result2 error
+		})
+	}
+	fake.getTestOwnerReturnsOnCall[i] = struct {
+		result1 *gql.TestOwner
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOwnershipProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getTestOwnerMutex.RLock()
+	defer fake.getTestOwnerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeOwnershipProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.OwnershipProvider = new(FakeOwnershipProvider)
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeProjectProvider struct {
+	ListProjectsStub        func(context.Context, string) ([]*gql.Project, error)
+	listProjectsMutex       sync.RWMutex
+	listProjectsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	listProjectsReturns struct {
+		result1 []*gql.Project
+		result2 error
+	}
+	listProjectsReturnsOnCall map[int]struct {
+		result1 []*gql.Project
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeProjectProvider) ListProjects(arg1 context.Context, arg2 string) ([]*gql.Project, error) {
+	fake.listProjectsMutex.Lock()
+	ret, specificReturn := fake.listProjectsReturnsOnCall[len(fake.listProjectsArgsForCall)]
+	fake.listProjectsArgsForCall = append(fake.listProjectsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListProjectsStub
+	fakeReturns := fake.listProjectsReturns
+	fake.recordInvocation("ListProjects", []interface{}{arg1, arg2})
+	fake.listProjectsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeProjectProvider) ListProjectsCallCount() int {
+	fake.listProjectsMutex.RLock()
+	defer fake.listProjectsMutex.RUnlock()
+	return len(fake.listProjectsArgsForCall)
+}
+
+func (fake *FakeProjectProvider) ListProjectsCalls(stub func(context.Context, string) ([]*gql.Project, error)) {
+	fake.listProjectsMutex.Lock()
+	defer fake.listProjectsMutex.Unlock()
+	fake.ListProjectsStub = stub
+}
+
+func (fake *FakeProjectProvider) ListProjectsArgsForCall(i int) (context.Context, string) {
+	fake.listProjectsMutex.RLock()
+	defer fake.listProjectsMutex.RUnlock()
+	argsForCall := fake.listProjectsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeProjectProvider) ListProjectsReturns(result1 []*gql.Project, result2 error) {
+	fake.listProjectsMutex.Lock()
+	defer fake.listProjectsMutex.Unlock()
+	fake.ListProjectsStub = nil
+	fake.listProjectsReturns = struct {
+		result1 []*gql.Project
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProjectProvider) ListProjectsReturnsOnCall(i int, result1 []*gql.Project, result2 error) {
+	fake.listProjectsMutex.Lock()
+	defer fake.listProjectsMutex.Unlock()
+	fake.ListProjectsStub = nil
+	if fake.listProjectsReturnsOnCall == nil {
+		fake.listProjectsReturnsOnCall = make(map[int]struct {
+			result1 []*gql.Project
+			result2 error
+		})
+	}
+	fake.listProjectsReturnsOnCall[i] = struct {
+		result1 []*gql.Project
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProjectProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listProjectsMutex.RLock()
+	defer fake.listProjectsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeProjectProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.ProjectProvider = new(FakeProjectProvider)
@@ -0,0 +1,207 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeQuarantineProvider struct {
+	ListQuarantinedTestsStub        func(context.Context, string, bool) ([]*gql.QuarantinedTest, error)
+	listQuarantinedTestsMutex       sync.RWMutex
+	listQuarantinedTestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}
+	listQuarantinedTestsReturns struct {
+		result1 []*gql.QuarantinedTest
+		result2 error
+	}
+	listQuarantinedTestsReturnsOnCall map[int]struct {
+		result1 []*gql.QuarantinedTest
+		result2 error
+	}
+	QuarantineTestStub        func(context.Context, string, string, *string) (*gql.QuarantineResult, error)
+	quarantineTestMutex       sync.RWMutex
+	quarantineTestArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *string
+	}
+	quarantineTestReturns struct {
+		result1 *gql.QuarantineResult
+		result2 error
+	}
+	quarantineTestReturnsOnCall map[int]struct {
+		result1 *gql.QuarantineResult
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQuarantineProvider) ListQuarantinedTests(arg1 context.Context, arg2 string, arg3 bool) ([]*gql.QuarantinedTest, error) {
+	fake.listQuarantinedTestsMutex.Lock()
+	ret, specificReturn := fake.listQuarantinedTestsReturnsOnCall[len(fake.listQuarantinedTestsArgsForCall)]
+	fake.listQuarantinedTestsArgsForCall = append(fake.listQuarantinedTestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.ListQuarantinedTestsStub
+	fakeReturns := fake.listQuarantinedTestsReturns
+	fake.recordInvocation("ListQuarantinedTests", []interface{}{arg1, arg2, arg3})
+	fake.listQuarantinedTestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuarantineProvider) ListQuarantinedTestsCallCount() int {
+	fake.listQuarantinedTestsMutex.RLock()
+	defer fake.listQuarantinedTestsMutex.RUnlock()
+	return len(fake.listQuarantinedTestsArgsForCall)
+}
+
+func (fake *FakeQuarantineProvider) ListQuarantinedTestsCalls(stub func(context.Context, string, bool) ([]*gql.QuarantinedTest, error)) {
+	fake.listQuarantinedTestsMutex.Lock()
+	defer fake.listQuarantinedTestsMutex.Unlock()
+	fake.ListQuarantinedTestsStub = stub
+}
+
+func (fake *FakeQuarantineProvider) ListQuarantinedTestsArgsForCall(i int) (context.Context, string, bool) {
+	fake.listQuarantinedTestsMutex.RLock()
+	defer fake.listQuarantinedTestsMutex.RUnlock()
+	argsForCall := fake.listQuarantinedTestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeQuarantineProvider) ListQuarantinedTestsReturns(result1 []*gql.QuarantinedTest, result2 error) {
+	fake.listQuarantinedTestsMutex.Lock()
+	defer fake.listQuarantinedTestsMutex.Unlock()
+	fake.ListQuarantinedTestsStub = nil
+	fake.listQuarantinedTestsReturns = struct {
+		result1 []*gql.QuarantinedTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineProvider) ListQuarantinedTestsReturnsOnCall(i int, result1 []*gql.QuarantinedTest, result2 error) {
+	fake.listQuarantinedTestsMutex.Lock()
+	defer fake.listQuarantinedTestsMutex.Unlock()
+	fake.ListQuarantinedTestsStub = nil
+	if fake.listQuarantinedTestsReturnsOnCall == nil {
+		fake.listQuarantinedTestsReturnsOnCall = make(map[int]struct {
+			result1 []*gql.QuarantinedTest
+			result2 error
+		})
+	}
+	fake.listQuarantinedTestsReturnsOnCall[i] = struct {
+		result1 []*gql.QuarantinedTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineProvider) QuarantineTest(arg1 context.Context, arg2 string, arg3 string, arg4 *string) (*gql.QuarantineResult, error) {
+	fake.quarantineTestMutex.Lock()
+	ret, specificReturn := fake.quarantineTestReturnsOnCall[len(fake.quarantineTestArgsForCall)]
+	fake.quarantineTestArgsForCall = append(fake.quarantineTestArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.QuarantineTestStub
+	fakeReturns := fake.quarantineTestReturns
+	fake.recordInvocation("QuarantineTest", []interface{}{arg1, arg2, arg3, arg4})
+	fake.quarantineTestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeQuarantineProvider) QuarantineTestCallCount() int {
+	fake.quarantineTestMutex.RLock()
+	defer fake.quarantineTestMutex.RUnlock()
+	return len(fake.quarantineTestArgsForCall)
+}
+
+func (fake *FakeQuarantineProvider) QuarantineTestCalls(stub func(context.Context, string, string, *string) (*gql.QuarantineResult, error)) {
+	fake.quarantineTestMutex.Lock()
+	defer fake.quarantineTestMutex.Unlock()
+	fake.QuarantineTestStub = stub
+}
+
+func (fake *FakeQuarantineProvider) QuarantineTestArgsForCall(i int) (context.Context, string, string, *string) {
+	fake.quarantineTestMutex.RLock()
+	defer fake.quarantineTestMutex.RUnlock()
+	argsForCall := fake.quarantineTestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeQuarantineProvider) QuarantineTestReturns(result1 *gql.QuarantineResult, result2 error) {
+	fake.quarantineTestMutex.Lock()
+	defer fake.quarantineTestMutex.Unlock()
+	fake.QuarantineTestStub = nil
+	fake.quarantineTestReturns = struct {
+		result1 *gql.QuarantineResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineProvider) QuarantineTestReturnsOnCall(i int, result1 *gql.QuarantineResult, result2 error) {
+	fake.quarantineTestMutex.Lock()
+	defer fake.quarantineTestMutex.Unlock()
+	fake.QuarantineTestStub = nil
+	if fake.quarantineTestReturnsOnCall == nil {
+		fake.quarantineTestReturnsOnCall = make(map[int]struct {
+			result1 *gql.QuarantineResult
+			result2 error
+		})
+	}
+	fake.quarantineTestReturnsOnCall[i] = struct {
+		result1 *gql.QuarantineResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeQuarantineProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listQuarantinedTestsMutex.RLock()
+	defer fake.listQuarantinedTestsMutex.RUnlock()
+	fake.quarantineTestMutex.RLock()
+	defer fake.quarantineTestMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeQuarantineProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.QuarantineProvider = new(FakeQuarantineProvider)
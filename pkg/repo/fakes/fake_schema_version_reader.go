@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeSchemaVersionReader struct {
+	SchemaVersionStub        func(context.Context) (int, bool, error)
+	schemaVersionMutex       sync.RWMutex
+	schemaVersionArgsForCall []struct {
+		arg1 context.Context
+	}
+	schemaVersionReturns struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
+	schemaVersionReturnsOnCall map[int]struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSchemaVersionReader) SchemaVersion(arg1 context.Context) (int, bool, error) {
+	fake.schemaVersionMutex.Lock()
+	ret, specificReturn := fake.schemaVersionReturnsOnCall[len(fake.schemaVersionArgsForCall)]
+	fake.schemaVersionArgsForCall = append(fake.schemaVersionArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.SchemaVersionStub
+	fakeReturns := fake.schemaVersionReturns
+	fake.recordInvocation("SchemaVersion", []interface{}{arg1})
+	fake.schemaVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeSchemaVersionReader) SchemaVersionCallCount() int {
+	fake.schemaVersionMutex.RLock()
+	defer fake.schemaVersionMutex.RUnlock()
+	return len(fake.schemaVersionArgsForCall)
+}
+
+func (fake *FakeSchemaVersionReader) SchemaVersionCalls(stub func(context.Context) (int, bool, error)) {
+	fake.schemaVersionMutex.Lock()
+	defer fake.schemaVersionMutex.Unlock()
+	fake.SchemaVersionStub = stub
+}
+
+func (fake *FakeSchemaVersionReader) SchemaVersionArgsForCall(i int) context.Context {
+	fake.schemaVersionMutex.RLock()
+	defer fake.schemaVersionMutex.RUnlock()
+	argsForCall := fake.schemaVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSchemaVersionReader) SchemaVersionReturns(result1 int, result2 bool, result3 error) {
+	fake.schemaVersionMutex.Lock()
+	defer fake.schemaVersionMutex.Unlock()
+	fake.SchemaVersionStub = nil
+	fake.schemaVersionReturns = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSchemaVersionReader) SchemaVersionReturnsOnCall(i int, result1 int, result2 bool, result3 error) {
+	fake.schemaVersionMutex.Lock()
+	defer fake.schemaVersionMutex.Unlock()
+	fake.SchemaVersionStub = nil
+	if fake.schemaVersionReturnsOnCall == nil {
+		fake.schemaVersionReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 bool
+			result3 error
+		})
+	}
+	fake.schemaVersionReturnsOnCall[i] = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSchemaVersionReader) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.schemaVersionMutex.RLock()
+	defer fake.schemaVersionMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSchemaVersionReader) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.SchemaVersionReader = new(FakeSchemaVersionReader)
@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeSlowTestProvider struct {
+	GetSlowestTestsStub        func(context.Context, string, int) ([]*gql.SlowTest, error)
+	getSlowestTestsMutex       sync.RWMutex
+	getSlowestTestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}
+	getSlowestTestsReturns struct {
+		result1 []*gql.SlowTest
+		result2 error
+	}
+	getSlowestTestsReturnsOnCall map[int]struct {
+		result1 []*gql.SlowTest
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSlowTestProvider) GetSlowestTests(arg1 context.Context, arg2 string, arg3 int) ([]*gql.SlowTest, error) {
+	fake.getSlowestTestsMutex.Lock()
+	ret, specificReturn := fake.getSlowestTestsReturnsOnCall[len(fake.getSlowestTestsArgsForCall)]
+	fake.getSlowestTestsArgsForCall = append(fake.getSlowestTestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetSlowestTestsStub
+	fakeReturns := fake.getSlowestTestsReturns
+	fake.recordInvocation("GetSlowestTests", []interface{}{arg1, arg2, arg3})
+	fake.getSlowestTestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSlowTestProvider) GetSlowestTestsCallCount() int {
+	fake.getSlowestTestsMutex.RLock()
+	defer fake.getSlowestTestsMutex.RUnlock()
+	return len(fake.getSlowestTestsArgsForCall)
+}
+
+func (fake *FakeSlowTestProvider) GetSlowestTestsCalls(stub func(context.Context, string, int) ([]*gql.SlowTest, error)) {
+	fake.getSlowestTestsMutex.Lock()
+	defer fake.getSlowestTestsMutex.Unlock()
+	fake.GetSlowestTestsStub = stub
+}
+
+func (fake *FakeSlowTestProvider) GetSlowestTestsArgsForCall(i int) (context.Context, string, int) {
+	fake.getSlowestTestsMutex.RLock()
+	defer fake.getSlowestTestsMutex.RUnlock()
+	argsForCall := fake.getSlowestTestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSlowTestProvider) GetSlowestTestsReturns(result1 []*gql.SlowTest, result2 error) {
+	fake.getSlowestTestsMutex.Lock()
+	defer fake.getSlowestTestsMutex.Unlock()
+	fake.GetSlowestTestsStub = nil
+	fake.getSlowestTestsReturns = struct {
+		result1 []*gql.SlowTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSlowTestProvider) GetSlowestTestsReturnsOnCall(i int, result1 []*gql.SlowTest, result2 error) {
+	fake.getSlowestTestsMutex.Lock()
+	defer fake.getSlowestTestsMutex.Unlock()
+	fake.GetSlowestTestsStub = nil
+	if fake.getSlowestTestsReturnsOnCall == nil {
+		fake.getSlowestTestsReturnsOnCall = make(map[int]struct {
+			result1 []*gql.SlowTest
+			result2 error
+		})
+	}
+	fake.getSlowestTestsReturnsOnCall[i] = struct {
+		result1 []*gql.SlowTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSlowTestProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getSlowestTestsMutex.RLock()
+	defer fake.getSlowestTestsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSlowTestProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.SlowTestProvider = new(FakeSlowTestProvider)
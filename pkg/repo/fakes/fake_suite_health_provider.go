@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeSuiteHealthProvider struct {
+	GetSuiteHealthStub        func(context.Context, string) ([]*gql.SuiteHealth, error)
+	getSuiteHealthMutex       sync.RWMutex
+	getSuiteHealthArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getSuiteHealthReturns struct {
+		result1 []*gql.SuiteHealth
+		result2 error
+	}
+	getSuiteHealthReturnsOnCall map[int]struct {
+		result1 []*gql.SuiteHealth
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSuiteHealthProvider) GetSuiteHealth(arg1 context.Context, arg2 string) ([]*gql.SuiteHealth, error) {
+	fake.getSuiteHealthMutex.Lock()
+	ret, specificReturn := fake.getSuiteHealthReturnsOnCall[len(fake.getSuiteHealthArgsForCall)]
+	fake.getSuiteHealthArgsForCall = append(fake.getSuiteHealthArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetSuiteHealthStub
+	fakeReturns := fake.getSuiteHealthReturns
+	fake.recordInvocation("GetSuiteHealth", []interface{}{arg1, arg2})
+	fake.getSuiteHealthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSuiteHealthProvider) GetSuiteHealthCallCount() int {
+	fake.getSuiteHealthMutex.RLock()
+	defer fake.getSuiteHealthMutex.RUnlock()
+	return len(fake.getSuiteHealthArgsForCall)
+}
+
+func (fake *FakeSuiteHealthProvider) GetSuiteHealthCalls(stub func(context.Context, string) ([]*gql.SuiteHealth, error)) {
+	fake.getSuiteHealthMutex.Lock()
+	defer fake.getSuiteHealthMutex.Unlock()
+	fake.GetSuiteHealthStub = stub
+}
+
+func (fake *FakeSuiteHealthProvider) GetSuiteHealthArgsForCall(i int) (context.Context, string) {
+	fake.getSuiteHealthMutex.RLock()
+	defer fake.getSuiteHealthMutex.RUnlock()
+	argsForCall := fake.getSuiteHealthArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSuiteHealthProvider) GetSuiteHealthReturns(result1 []*gql.SuiteHealth, result2 error) {
+	fake.getSuiteHealthMutex.Lock()
+	defer fake.getSuiteHealthMutex.Unlock()
+	fake.GetSuiteHealthStub = nil
+	fake.getSuiteHealthReturns = struct {
+		result1 []*gql.SuiteHealth
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSuiteHealthProvider) GetSuiteHealthReturnsOnCall(i int, result1 []*gql.SuiteHealth, result2 error) {
+	fake.getSuiteHealthMutex.Lock()
+	defer fake.getSuiteHealthMutex.Unlock()
+	fake.GetSuiteHealthStub = nil
+	if fake.getSuiteHealthReturnsOnCall == nil {
+		fake.getSuiteHealthReturnsOnCall = make(map[int]struct {
+			result1 []*gql.SuiteHealth
+			result2 error
+		})
+	}
+	fake.getSuiteHealthReturnsOnCall[i] = struct {
+		result1 []*gql.SuiteHealth
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSuiteHealthProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getSuiteHealthMutex.RLock()
+	defer fake.getSuiteHealthMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSuiteHealthProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.SuiteHealthProvider = new(FakeSuiteHealthProvider)
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeTeamHealthProvider struct {
+	GetTeamHealthStub        func(context.Context, string) (*gql.TeamHealth, error)
+	getTeamHealthMutex       sync.RWMutex
+	getTeamHealthArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getTeamHealthReturns struct {
+		result1 *gql.TeamHealth
+		result2 error
+	}
+	getTeamHealthReturnsOnCall map[int]struct {
+		result1 *gql.TeamHealth
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTeamHealthProvider) GetTeamHealth(arg1 context.Context, arg2 string) (*gql.TeamHealth, error) {
+	fake.getTeamHealthMutex.Lock()
+	ret, specificReturn := fake.getTeamHealthReturnsOnCall[len(fake.getTeamHealthArgsForCall)]
+	fake.getTeamHealthArgsForCall = append(fake.getTeamHealthArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetTeamHealthStub
+	fakeReturns := fake.getTeamHealthReturns
+	fake.recordInvocation("GetTeamHealth", []interface{}{arg1, arg2})
+	fake.getTeamHealthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeamHealthProvider) GetTeamHealthCallCount() int {
+	fake.getTeamHealthMutex.RLock()
+	defer fake.getTeamHealthMutex.RUnlock()
+	return len(fake.getTeamHealthArgsForCall)
+}
+
+func (fake *FakeTeamHealthProvider) GetTeamHealthCalls(stub func(context.Context, string) (*gql.TeamHealth, error)) {
+	fake.getTeamHealthMutex.Lock()
+	defer fake.getTeamHealthMutex.Unlock()
+	fake.GetTeamHealthStub = stub
+}
+
+func (fake *FakeTeamHealthProvider) GetTeamHealthArgsForCall(i int) (context.Context, string) {
+	fake.getTeamHealthMutex.RLock()
+	defer fake.getTeamHealthMutex.RUnlock()
+	argsForCall := fake.getTeamHealthArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeamHealthProvider) GetTeamHealthReturns(result1 *gql.TeamHealth, result2 error) {
+	fake.getTeamHealthMutex.Lock()
+	defer fake.getTeamHealthMutex.Unlock()
+	fake.GetTeamHealthStub = nil
+	fake.getTeamHealthReturns = struct {
+		result1 *gql.TeamHealth
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamHealthProvider) GetTeamHealthReturnsOnCall(i int, result1 *gql.TeamHealth, result2 error) {
+	fake.getTeamHealthMutex.Lock()
+	defer fake.getTeamHealthMutex.Unlock()
+	fake.GetTeamHealthStub = nil
+	if fake.getTeamHealthReturnsOnCall == nil {
+		fake.getTeamHealthReturnsOnCall = make(map[int]struct {
+			result1 *gql.TeamHealth
+			result2 error
+		})
+	}
+	fake.getTeamHealthReturnsOnCall[i] = struct {
+		result1 *gql.TeamHealth
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamHealthProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getTeamHealthMutex.RLock()
+	defer fake.getTeamHealthMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTeamHealthProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.TeamHealthProvider = new(FakeTeamHealthProvider)
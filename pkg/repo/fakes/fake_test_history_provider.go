@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeTestHistoryProvider struct {
+	GetTestHistoryStub        func(context.Context, string, string, int) ([]*gql.TestRunResult, error)
+	getTestHistoryMutex       sync.RWMutex
+	getTestHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+	}
+	getTestHistoryReturns struct {
+		result1 []*gql.TestRunResult
+		result2 error
+	}
+	getTestHistoryReturnsOnCall map[int]struct {
+		result1 []*gql.TestRunResult
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTestHistoryProvider) GetTestHistory(arg1 context.Context, arg2 string, arg3 string, arg4 int) ([]*gql.TestRunResult, error) {
+	fake.getTestHistoryMutex.Lock()
+	ret, specificReturn := fake.getTestHistoryReturnsOnCall[len(fake.getTestHistoryArgsForCall)]
+	fake.getTestHistoryArgsForCall = append(fake.getTestHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetTestHistoryStub
+	fakeReturns := fake.getTestHistoryReturns
+	fake.recordInvocation("GetTestHistory", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getTestHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTestHistoryProvider) GetTestHistoryCallCount() int {
+	fake.getTestHistoryMutex.RLock()
+	defer fake.getTestHistoryMutex.RUnlock()
+	return len(fake.getTestHistoryArgsForCall)
+}
+
+func (fake *FakeTestHistoryProvider) GetTestHistoryCalls(stub func(context.Context, string, string, int) ([]*gql.TestRunResult, error)) {
+	fake.getTestHistoryMutex.Lock()
+	defer fake.getTestHistoryMutex.Unlock()
+	fake.GetTestHistoryStub = stub
+}
+
+func (fake *FakeTestHistoryProvider) GetTestHistoryArgsForCall(i int) (context.Context, string, string, int) {
+	fake.getTestHistoryMutex.RLock()
+	defer fake.getTestHistoryMutex.RUnlock()
+	argsForCall := fake.getTestHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTestHistoryProvider) GetTestHistoryReturns(result1 []*gql.TestRunResult, result2 error) {
+	fake.getTestHistoryMutex.Lock()
+	defer fake.getTestHistoryMutex.Unlock()
+	fake.GetTestHistoryStub = nil
+	fake.getTestHistoryReturns = struct {
+		result1 []*gql.TestRunResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTestHistoryProvider) GetTestHistoryReturnsOnCall(i int, result1 []*gql.TestRunResult, result2 error) {
+	fake.getTestHistoryMutex.Lock()
+	defer fake.getTestHistoryMutex.Unlock()
+	fake.GetTestHistoryStub = nil
+	if fake.getTestHistoryReturnsOnCall == nil {
+		fake.getTestHistoryReturnsOnCall = make(map[int]struct {
+			result1 []*gql.TestRunResult
+			result2 error
+		})
+	}
+	fake.getTestHistoryReturnsOnCall[i] = struct {
+		result1 []*gql.TestRunResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTestHistoryProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getTestHistoryMutex.RLock()
+	defer fake.getTestHistoryMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTestHistoryProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.TestHistoryProvider = new(FakeTestHistoryProvider)
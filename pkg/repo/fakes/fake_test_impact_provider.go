@@ -0,0 +1,127 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeTestImpactProvider struct {
+	GetTestsForFilesStub        func(context.Context, string, []string) ([]*gql.FlakyTest, error)
+	getTestsForFilesMutex       sync.RWMutex
+	getTestsForFilesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}
+	getTestsForFilesReturns struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}
+	getTestsForFilesReturnsOnCall map[int]struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTestImpactProvider) GetTestsForFiles(arg1 context.Context, arg2 string, arg3 []string) ([]*gql.FlakyTest, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getTestsForFilesMutex.Lock()
+	ret, specificReturn := fake.getTestsForFilesReturnsOnCall[len(fake.getTestsForFilesArgsForCall)]
+	fake.getTestsForFilesArgsForCall = append(fake.getTestsForFilesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.GetTestsForFilesStub
+	fakeReturns := fake.getTestsForFilesReturns
+	fake.recordInvocation("GetTestsForFiles", []interface{}{arg1, arg2, arg3Copy})
+	fake.getTestsForFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTestImpactProvider) GetTestsForFilesCallCount() int {
+	fake.getTestsForFilesMutex.RLock()
+	defer fake.getTestsForFilesMutex.RUnlock()
+	return len(fake.getTestsForFilesArgsForCall)
+}
+
+func (fake *FakeTestImpactProvider) GetTestsForFilesCalls(stub func(context.Context, string, []string) ([]*gql.FlakyTest, error)) {
+	fake.getTestsForFilesMutex.Lock()
+	defer fake.getTestsForFilesMutex.Unlock()
+	fake.GetTestsForFilesStub = stub
+}
+
+func (fake *FakeTestImpactProvider) GetTestsForFilesArgsForCall(i int) (context.Context, string, []string) {
+	fake.getTestsForFilesMutex.RLock()
+	defer fake.getTestsForFilesMutex.RUnlock()
+	argsForCall := fake.getTestsForFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTestImpactProvider) GetTestsForFilesReturns(result1 []*gql.FlakyTest, result2 error) {
+	fake.getTestsForFilesMutex.Lock()
+	defer fake.getTestsForFilesMutex.Unlock()
+	fake.GetTestsForFilesStub = nil
+	fake.getTestsForFilesReturns = struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTestImpactProvider) GetTestsForFilesReturnsOnCall(i int, result1 []*gql.FlakyTest, result2 error) {
+	fake.getTestsForFilesMutex.Lock()
+	defer fake.getTestsForFilesMutex.Unlock()
+	fake.GetTestsForFilesStub = nil
+	if fake.getTestsForFilesReturnsOnCall == nil {
+		fake.getTestsForFilesReturnsOnCall = make(map[int]struct {
+			result1 []*gql.FlakyTest
+			result2 error
+		})
+	}
+	fake.getTestsForFilesReturnsOnCall[i] = struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTestImpactProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getTestsForFilesMutex.RLock()
+	defer fake.getTestsForFilesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTestImpactProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.TestImpactProvider = new(FakeTestImpactProvider)
@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeTestRunProvider struct {
+	GetRecentTestRunsStub        func(context.Context, string, int) ([]*gql.TestRun, error)
+	getRecentTestRunsMutex       sync.RWMutex
+	getRecentTestRunsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}
+	getRecentTestRunsReturns struct {
+		result1 []*gql.TestRun
+		result2 error
+	}
+	getRecentTestRunsReturnsOnCall map[int]struct {
+		result1 []*gql.TestRun
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTestRunProvider) GetRecentTestRuns(arg1 context.Context, arg2 string, arg3 int) ([]*gql.TestRun, error) {
+	fake.getRecentTestRunsMutex.Lock()
+	ret, specificReturn := fake.getRecentTestRunsReturnsOnCall[len(fake.getRecentTestRunsArgsForCall)]
+	fake.getRecentTestRunsArgsForCall = append(fake.getRecentTestRunsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetRecentTestRunsStub
+	fakeReturns := fake.getRecentTestRunsReturns
+	fake.recordInvocation("GetRecentTestRuns", []interface{}{arg1, arg2, arg3})
+	fake.getRecentTestRunsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTestRunProvider) GetRecentTestRunsCallCount() int {
+	fake.getRecentTestRunsMutex.RLock()
+	defer fake.getRecentTestRunsMutex.RUnlock()
+	return len(fake.getRecentTestRunsArgsForCall)
+}
+
+func (fake *FakeTestRunProvider) GetRecentTestRunsCalls(stub func(context.Context, string, int) ([]*gql.TestRun, error)) {
+	fake.getRecentTestRunsMutex.Lock()
+	defer fake.getRecentTestRunsMutex.Unlock()
+	fake.GetRecentTestRunsStub = stub
+}
+
+func (fake *FakeTestRunProvider) GetRecentTestRunsArgsForCall(i int) (context.Context, string, int) {
+	fake.getRecentTestRunsMutex.RLock()
+	defer fake.getRecentTestRunsMutex.RUnlock()
+	argsForCall := fake.getRecentTestRunsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTestRunProvider) GetRecentTestRunsReturns(result1 []*gql.TestRun, result2 error) {
+	fake.getRecentTestRunsMutex.Lock()
+	defer fake.getRecentTestRunsMutex.Unlock()
+	fake.GetRecentTestRunsStub = nil
+	fake.getRecentTestRunsReturns = struct {
+		result1 []*gql.TestRun
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTestRunProvider) GetRecentTestRunsReturnsOnCall(i int, result1 []*gql.TestRun, result2 error) {
+	fake.getRecentTestRunsMutex.Lock()
+	defer fake.getRecentTestRunsMutex.Unlock()
+	fake.GetRecentTestRunsStub = nil
+	if fake.getRecentTestRunsReturnsOnCall == nil {
+		fake.getRecentTestRunsReturnsOnCall = make(map[int]struct {
+			result1 []*gql.TestRun
+			result2 error
+		})
+	}
+	fake.getRecentTestRunsReturnsOnCall[i] = struct {
+		result1 []*gql.TestRun
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTestRunProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getRecentTestRunsMutex.RLock()
+	defer fake.getRecentTestRunsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTestRunProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.TestRunProvider = new(FakeTestRunProvider)
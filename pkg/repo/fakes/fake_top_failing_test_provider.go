@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeTopFailingTestProvider struct {
+	GetTopFailingTestsStub        func(context.Context, int, int) ([]*gql.FlakyTest, error)
+	getTopFailingTestsMutex       sync.RWMutex
+	getTopFailingTestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}
+	getTopFailingTestsReturns struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}
+	getTopFailingTestsReturnsOnCall map[int]struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTopFailingTestProvider) GetTopFailingTests(arg1 context.Context, arg2 int, arg3 int) ([]*gql.FlakyTest, error) {
+	fake.getTopFailingTestsMutex.Lock()
+	ret, specificReturn := fake.getTopFailingTestsReturnsOnCall[len(fake.getTopFailingTestsArgsForCall)]
+	fake.getTopFailingTestsArgsForCall = append(fake.getTopFailingTestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetTopFailingTestsStub
+	fakeReturns := fake.getTopFailingTestsReturns
+	fake.recordInvocation("GetTopFailingTests", []interface{}{arg1, arg2, arg3})
+	fake.getTopFailingTestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTopFailingTestProvider) GetTopFailingTestsCallCount() int {
+	fake.getTopFailingTestsMutex.RLock()
+	defer fake.getTopFailingTestsMutex.RUnlock()
+	return len(fake.getTopFailingTestsArgsForCall)
+}
+
+func (fake *FakeTopFailingTestProvider) GetTopFailingTestsCalls(stub func(context.Context, int, int) ([]*gql.FlakyTest, error)) {
+	fake.getTopFailingTestsMutex.Lock()
+	defer fake.getTopFailingTestsMutex.Unlock()
+	fake.GetTopFailingTestsStub = stub
+}
+
+func (fake *FakeTopFailingTestProvider) GetTopFailingTestsArgsForCall(i int) (context.Context, int, int) {
+	fake.getTopFailingTestsMutex.RLock()
+	defer fake.getTopFailingTestsMutex.RUnlock()
+	argsForCall := fake.getTopFailingTestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTopFailingTestProvider) GetTopFailingTestsReturns(result1 []*gql.FlakyTest, result2 error) {
+	fake.getTopFailingTestsMutex.Lock()
+	defer fake.getTopFailingTestsMutex.Unlock()
+	fake.GetTopFailingTestsStub = nil
+	fake.getTopFailingTestsReturns = struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTopFailingTestProvider) GetTopFailingTestsReturnsOnCall(i int, result1 []*gql.FlakyTest, result2 error) {
+	fake.getTopFailingTestsMutex.Lock()
+	defer fake.getTopFailingTestsMutex.Unlock()
+	fake.GetTopFailingTestsStub = nil
+	if fake.getTopFailingTestsReturnsOnCall == nil {
+		fake.getTopFailingTestsReturnsOnCall = make(map[int]struct {
+			result1 []*gql.FlakyTest
+			result2 error
+		})
+	}
+	fake.getTopFailingTestsReturnsOnCall[i] = struct {
+		result1 []*gql.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTopFailingTestProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getTopFailingTestsMutex.RLock()
+	defer fake.getTopFailingTestsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTopFailingTestProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.TopFailingTestProvider = new(FakeTopFailingTestProvider)
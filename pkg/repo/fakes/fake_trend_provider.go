@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+type FakeTrendProvider struct {
+	GetPassRateTrendStub        func(context.Context, string, string, string) ([]*gql.TrendPoint, error)
+	getPassRateTrendMutex       sync.RWMutex
+	getPassRateTrendArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	getPassRateTrendReturns struct {
+		result1 []*gql.TrendPoint
+		result2 error
+	}
+	getPassRateTrendReturnsOnCall map[int]struct {
+		result1 []*gql.TrendPoint
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTrendProvider) GetPassRateTrend(arg1 context.Context, arg2 string, arg3 string, arg4 string) ([]*gql.TrendPoint, error) {
+	fake.getPassRateTrendMutex.Lock()
+	ret, specificReturn := fake.getPassRateTrendReturnsOnCall[len(fake.getPassRateTrendArgsForCall)]
+	fake.getPassRateTrendArgsForCall = append(fake.getPassRateTrendArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetPassRateTrendStub
+	fakeReturns := fake.getPassRateTrendReturns
+	fake.recordInvocation("GetPassRateTrend", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getPassRateTrendMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTrendProvider) GetPassRateTrendCallCount() int {
+	fake.getPassRateTrendMutex.RLock()
+	defer fake.getPassRateTrendMutex.RUnlock()
+	return len(fake.getPassRateTrendArgsForCall)
+}
+
+func (fake *FakeTrendProvider) GetPassRateTrendCalls(stub func(context.Context, string, string, string) ([]*gql.TrendPoint, error)) {
+	fake.getPassRateTrendMutex.Lock()
+	defer fake.getPassRateTrendMutex.Unlock()
+	fake.GetPassRateTrendStub = stub
+}
+
+func (fake *FakeTrendProvider) GetPassRateTrendArgsForCall(i int) (context.Context, string, string, string) {
+	fake.getPassRateTrendMutex.RLock()
+	defer fake.getPassRateTrendMutex.RUnlock()
+	argsForCall := fake.getPassRateTrendArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTrendProvider) GetPassRateTrendReturns(result1 []*gql.TrendPoint, result2 error) {
+	fake.getPassRateTrendMutex.Lock()
+	defer fake.getPassRateTrendMutex.Unlock()
+	fake.GetPassRateTrendStub = nil
+	fake.getPassRateTrendReturns = struct {
+		result1 []*gql.TrendPoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTrendProvider) GetPassRateTrendReturnsOnCall(i int, result1 []*gql.TrendPoint, result2 error) {
+	fake.getPassRateTrendMutex.Lock()
+	defer fake.getPassRateTrendMutex.Unlock()
+	fake.GetPassRateTrendStub = nil
+	if fake.getPassRateTrendReturnsOnCall == nil {
+		fake.getPassRateTrendReturnsOnCall = make(map[int]struct {
+			result1 []*gql.TrendPoint
+			result2 error
+		})
+	}
+	fake.getPassRateTrendReturnsOnCall[i] = struct {
+		result1 []*gql.TrendPoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTrendProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getPassRateTrendMutex.RLock()
+	defer fake.getPassRateTrendMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTrendProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repo.TrendProvider = new(FakeTrendProvider)
@@ -6,11 +6,50 @@ import (
 
 	"github.com/guidewire-oss/fern-mycelium/internal/gql"
 	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate counterfeiter -o fakes/fake_flaky_test_provider.go . FlakyTestProvider
 type FlakyTestProvider interface {
-	GetFlakyTests(ctx context.Context, projectID string, limit int) ([]*gql.FlakyTest, error)
+	GetFlakyTests(ctx context.Context, projectID string, limit int, opts FlakyTestOptions) ([]*gql.FlakyTest, error)
+}
+
+// DefaultSinceDays is the lookback window used when FlakyTestOptions.SinceDays is unset.
+const DefaultSinceDays = 30
+
+// DefaultQueryTimeout bounds each FlakyTestRepo query unless overridden with
+// WithQueryTimeout.
+const DefaultQueryTimeout = 30 * time.Second
+
+// DefaultSlowQueryThreshold is the query duration above which
+// WithQueryLogging warns, unless configured otherwise.
+const DefaultSlowQueryThreshold = time.Second
+
+// MaxLimit is the most flaky tests a single query may return.
+const MaxLimit = 1000
+
+// FlakyTestOptions holds the optional filters applied by GetFlakyTests.
+type FlakyTestOptions struct {
+	// SuiteName restricts the results to a single suite within the project.
+	SuiteName string
+	// Offset skips that many results, for paging past the first limit rows.
+	Offset int
+	// SinceDays only counts runs started within that many days; zero means DefaultSinceDays.
+	SinceDays int
+	// MinRuns excludes specs with fewer runs in the window; zero means no minimum.
+	MinRuns int
+	// MinFailureRate excludes specs failing less often than this ratio, in [0, 1].
+	MinFailureRate float64
+	// SortBy picks the ranking column; empty means failure rate.
+	SortBy gql.FlakyTestSortField
+	// SortOrder is the ranking direction; empty means descending.
+	SortOrder gql.SortOrder
+	// NamePattern, when set, is removed from spec names before grouping, so
+	// parameterized specs count as one test; see DefaultNamePattern. Empty
+	// groups by the raw spec name.
+	NamePattern string
 }
 
 //go:generate counterfeiter -o fakes/fake_pgx_querier.go . PgxQuerier
@@ -20,58 +59,287 @@ type PgxQuerier interface {
 
 type FlakyTestRepo struct {
 	db PgxQuerier
+	// replica serves the read-only analytical queries when set.
+	replica         PgxQuerier
+	successStatuses []string
+	ignoredStatuses []string
+	queryTimeout    time.Duration
+	queryLog        *queryLogger
 }
 
-func NewFlakyTestRepo(db PgxQuerier) *FlakyTestRepo {
-	return &FlakyTestRepo{db: db}
+// Option configures a FlakyTestRepo.
+type Option func(*FlakyTestRepo)
+
+// WithStatuses overrides which statuses count as passing and which are
+// ignored. Any other status counts as a failure.
+func WithStatuses(success, ignored []string) Option {
+	return func(r *FlakyTestRepo) {
+		r.successStatuses = success
+		r.ignoredStatuses = ignored
+	}
+}
+
+// WithQueryTimeout cancels queries that run longer than d. Zero disables the
+// timeout, leaving only the caller's deadline.
+func WithQueryTimeout(d time.Duration) Option {
+	return func(r *FlakyTestRepo) {
+		r.queryTimeout = d
+	}
 }
 
-func (r *FlakyTestRepo) GetFlakyTests(ctx context.Context, projectID string, limit int) ([]*gql.FlakyTest, error) {
+// WithReadReplica sends the repo's read-only queries to replica instead of
+// the primary, e.g. a pool connected to a Postgres streaming replica.
+func WithReadReplica(replica PgxQuerier) Option {
+	return func(r *FlakyTestRepo) {
+		r.replica = replica
+	}
+}
+
+// WithQueryLogging logs each of the repo's queries at debug level with its
+// statement, redacted arguments and duration. Queries slower than slow are
+// logged as warnings instead; zero never warns.
+func WithQueryLogging(slow time.Duration) Option {
+	return func(r *FlakyTestRepo) {
+		r.queryLog = &queryLogger{slow: slow}
+	}
+}
+
+func NewFlakyTestRepo(db PgxQuerier, opts ...Option) *FlakyTestRepo {
+	r := &FlakyTestRepo{
+		db:              db,
+		successStatuses: DefaultSuccessStatuses,
+		ignoredStatuses: DefaultIgnoredStatuses,
+		queryTimeout:    DefaultQueryTimeout,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.queryLog != nil {
+		r.db = r.queryLog.wrap(r.db)
+		if r.replica != nil {
+			r.replica = r.queryLog.wrap(r.replica)
+		}
+	}
+	return r
+}
+
+// validateLimit rejects limits outside [1, MaxLimit] before they reach SQL.
+func validateLimit(limit int) error {
+	if limit < 1 || limit > MaxLimit {
+		return InvalidArgumentf("limit must be between 1 and %d, got %d", MaxLimit, limit)
+	}
+	return nil
+}
+
+// normalize validates the options and fills in their defaults.
+func (o *FlakyTestOptions) normalize() error {
+	if o.Offset < 0 {
+		return InvalidArgumentf("offset must be non-negative, got %d", o.Offset)
+	}
+	if o.SinceDays < 0 {
+		return InvalidArgumentf("sinceDays must be non-negative, got %d", o.SinceDays)
+	}
+	if o.MinRuns < 0 {
+		return InvalidArgumentf("minRuns must be non-negative, got %d", o.MinRuns)
+	}
+	if o.MinFailureRate < 0 || o.MinFailureRate > 1 {
+		return InvalidArgumentf("minFailureRate must be between 0 and 1, got %g", o.MinFailureRate)
+	}
+	if err := validateNamePattern(o.NamePattern); err != nil {
+		return err
+	}
+	if o.SinceDays == 0 {
+		o.SinceDays = DefaultSinceDays
+	}
+	return nil
+}
+
+// GetFlakyTests returns the specs with the highest failure rate for a project,
+// where projectID matches either the project name or its UUID.
+func (r *FlakyTestRepo) GetFlakyTests(ctx context.Context, projectID string, limit int, opts FlakyTestOptions) ([]*gql.FlakyTest, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	testName := testNameColumn(opts.NamePattern, 10)
+	orderBy, err := opts.orderBy(testName)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
     SELECT
-        spec_runs.spec_description AS test_name,
+        ` + testName + ` AS test_name,
         COUNT(*) AS total_runs,
-        COUNT(*) FILTER (WHERE spec_runs.status <> 'passed') AS failure_count,
-        MAX(spec_runs.end_time) FILTER (WHERE spec_runs.status <> 'passed') AS last_failure
-    FROM spec_runs
-    JOIN suite_runs ON spec_runs.suite_id = suite_runs.id
-    WHERE suite_runs.suite_name = $1
-    GROUP BY spec_runs.spec_description
-    ORDER BY (COUNT(*) FILTER (WHERE spec_runs.status <> 'passed'))::float / COUNT(*) DESC
-    LIMIT $2;
+        COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($6)) AS failure_count,
+        MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($6)) AS last_failure,
+        array_agg(NOT spec_runs.status = ANY($6) ORDER BY spec_runs.start_time) AS outcomes,
+        (array_agg(test_runs.git_branch ORDER BY ` + lastFailureOrder + `)
+            FILTER (WHERE NOT spec_runs.status = ANY($6)))[1] AS last_failure_branch,
+        (array_agg(test_runs.git_sha ORDER BY ` + lastFailureOrder + `)
+            FILTER (WHERE NOT spec_runs.status = ANY($6)))[1] AS last_failure_sha
+    FROM spec_runs` + projectJoins + `
+    WHERE ` + projectMatch + `
+      AND ($3 = '' OR suite_runs.suite_name = $3)
+      AND spec_runs.start_time >= NOW() - make_interval(days => $5)
+      AND NOT spec_runs.status = ANY($7)
+    GROUP BY ` + testName + `
+    HAVING COUNT(*) >= $8
+      AND (COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($6)))::float / COUNT(*) >= $9
+    ORDER BY ` + orderBy + `
+    LIMIT $2 OFFSET $4;
 	`
-	rows, err := r.db.Query(ctx, query, projectID, limit)
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "FlakyTestRepo.GetFlakyTests", trace.WithAttributes(
+		attribute.String("db.statement", query),
+		attribute.String("mycelium.project_id", projectID),
+	))
+	defer span.End()
+
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "flaky_tests", query, withNamePattern(opts.NamePattern,
+		projectID, limit, opts.SuiteName, opts.Offset, opts.SinceDays,
+		r.successStatuses, r.ignoredStatuses, opts.MinRuns, opts.MinFailureRate)...)
 	if err != nil {
+		recordSpanError(span, err)
 		return nil, err
 	}
 	defer rows.Close()
 
+	results, err := scanFlakyTests(rows)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+	// Release the connection before issuing the follow-up queries.
+	rows.Close()
+
+	filter := failureMessageFilter{suiteName: opts.SuiteName, sinceDays: opts.SinceDays, namePattern: opts.NamePattern}
+	if err := r.attachRunDetails(ctx, map[string][]*gql.FlakyTest{projectID: results}, filter); err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("db.rows", len(results)))
+	return results, nil
+}
+
+// reader returns the querier for read-only queries: the replica when one is
+// configured and the primary otherwise.
+func (r *FlakyTestRepo) reader() PgxQuerier {
+	if r.replica != nil {
+		return r.replica
+	}
+	return r.db
+}
+
+// withQueryTimeout derives a context bounded by the configured query timeout.
+func (r *FlakyTestRepo) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.queryTimeout)
+}
+
+// scanFlakyTests reads rows of flakyTestRow columns. pgx reports failures
+// while the query runs, such as a timeout, only through rows.Err, so they
+// are classified like those of Query.
+func scanFlakyTests(rows pgx.Rows) ([]*gql.FlakyTest, error) {
 	var results []*gql.FlakyTest
 
 	for rows.Next() {
-		var testName string
-		var runCount, failureCount int
-		var lastFailure *time.Time
-
-		if err := rows.Scan(&testName, &runCount, &failureCount, &lastFailure); err != nil {
+		var row flakyTestRow
+		if err := rows.Scan(row.columns()...); err != nil {
 			return nil, err
 		}
+		results = append(results, row.flakyTest())
+	}
 
-		test := &gql.FlakyTest{
-			TestID:      testName, // Use test name as ID for now
-			TestName:    testName,
-			PassRate:    float64(runCount-failureCount) / float64(runCount),
-			FailureRate: float64(failureCount) / float64(runCount),
-			RunCount:    runCount,
-		}
+	if err := rows.Err(); err != nil {
+		return nil, classifyQueryError(err)
+	}
+	return results, nil
+}
+
+// flakyTestRow holds the per-spec aggregates shared by the flaky test queries,
+// which select (test_name, total_runs, failure_count, last_failure, outcomes,
+// last_failure_branch, last_failure_sha). outcomes holds one failed flag per
+// run in start-time order.
+type flakyTestRow struct {
+	testName          string
+	runCount          int
+	failureCount      int
+	lastFailure       *time.Time
+	outcomes          []bool
+	lastFailureBranch *string
+	lastFailureSha    *string
+}
+
+// columns returns the scan destinations in select order.
+func (r *flakyTestRow) columns() []any {
+	return []any{&r.testName, &r.runCount, &r.failureCount, &r.lastFailure, &r.outcomes,
+		&r.lastFailureBranch, &r.lastFailureSha}
+}
+
+func (r *flakyTestRow) flakyTest() *gql.FlakyTest {
+	return &gql.FlakyTest{
+		TestID:            r.testName, // Use test name as ID for now
+		TestName:          r.testName,
+		PassRate:          ratio(r.runCount-r.failureCount, r.runCount),
+		FailureRate:       ratio(r.failureCount, r.runCount),
+		FlakinessScore:    flakinessScore(r.outcomes),
+		LastFailure:       formatTime(r.lastFailure),
+		LastFailureBranch: r.lastFailureBranch,
+		LastFailureSha:    r.lastFailureSha,
+		RunCount:          r.runCount,
+	}
+}
+
+// projectJoins links spec_runs to the owning project; queries using it filter
+// with projectMatch, which expects the project name or UUID as $1.
+const (
+	projectJoins = `
+    JOIN suite_runs ON spec_runs.suite_id = suite_runs.id
+    JOIN test_runs ON suite_runs.test_run_id = test_runs.id
+    JOIN project_details ON test_runs.project_id = project_details.id`
+	projectMatch = `(project_details.name = $1 OR project_details.uuid::text = $1)`
+	// projectsMatch is projectMatch for an array of project names or UUIDs in $1.
+	projectsMatch = `(project_details.name = ANY($1) OR project_details.uuid::text = ANY($1))`
+)
+
+// lastFailureOrder sorts the runs aggregated into last_failure_branch and
+// last_failure_sha latest first, keeping runs without an end time behind
+// those that finished, which Postgres would otherwise sort ahead of them.
+const lastFailureOrder = `spec_runs.end_time DESC NULLS LAST, spec_runs.id DESC`
 
-		if lastFailure != nil {
-			formattedTime := lastFailure.Format(time.RFC3339)
-			test.LastFailure = &formattedTime
+// flakinessScore is the fraction of consecutive run pairs whose outcome flips
+// between pass and fail. A test alternating on every run scores 1, while one
+// that broke once and stayed broken scores close to 0.
+func flakinessScore(outcomes []bool) float64 {
+	transitions := 0
+	for i := 1; i < len(outcomes); i++ {
+		if outcomes[i] != outcomes[i-1] {
+			transitions++
 		}
+	}
+	return ratio(transitions, len(outcomes)-1)
+}
 
-		results = append(results, test)
+// ratio returns n/total, or 0 when total is zero so callers never see NaN or Inf.
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
 	}
+	return float64(n) / float64(total)
+}
 
-	return results, nil
+// formatTime renders t as RFC 3339, keeping NULL timestamps nil.
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
 }
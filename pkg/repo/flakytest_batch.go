@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_flaky_test_batcher.go . FlakyTestBatcher
+type FlakyTestBatcher interface {
+	GetFlakyTestsBatch(ctx context.Context, projectIDs []string, limit int, opts FlakyTestOptions) (map[string][]*gql.FlakyTest, error)
+}
+
+// GetFlakyTestsBatch is GetFlakyTests for several projects at once, issuing a
+// single query for all of them. The result maps each requested project name
+// or UUID to its flaky tests; projects without any are absent.
+func (r *FlakyTestRepo) GetFlakyTestsBatch(ctx context.Context, projectIDs []string, limit int, opts FlakyTestOptions) (map[string][]*gql.FlakyTest, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	testName := testNameColumn(opts.NamePattern, 10)
+	orderBy, err := opts.orderBy(testName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+    SELECT test_name, total_runs, failure_count, last_failure, outcomes, last_failure_branch, last_failure_sha,
+        project_name, project_uuid
+    FROM (
+        SELECT
+            ` + testName + ` AS test_name,
+            COUNT(*) AS total_runs,
+            COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($6)) AS failure_count,
+            MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($6)) AS last_failure,
+            array_agg(NOT spec_runs.status = ANY($6) ORDER BY spec_runs.start_time) AS outcomes,
+            (array_agg(test_runs.git_branch ORDER BY ` + lastFailureOrder + `)
+                FILTER (WHERE NOT spec_runs.status = ANY($6)))[1] AS last_failure_branch,
+            (array_agg(test_runs.git_sha ORDER BY ` + lastFailureOrder + `)
+                FILTER (WHERE NOT spec_runs.status = ANY($6)))[1] AS last_failure_sha,
+            project_details.name AS project_name,
+            project_details.uuid::text AS project_uuid,
+            ROW_NUMBER() OVER (
+                PARTITION BY project_details.id
+                ORDER BY ` + orderBy + `
+            ) AS position
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectsMatch + `
+          AND ($3 = '' OR suite_runs.suite_name = $3)
+          AND spec_runs.start_time >= NOW() - make_interval(days => $5)
+          AND NOT spec_runs.status = ANY($7)
+        GROUP BY project_details.id, project_details.name, project_details.uuid, ` + testName + `
+        HAVING COUNT(*) >= $8
+          AND (COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($6)))::float / COUNT(*) >= $9
+    ) ranked
+    WHERE position > $4 AND position <= $4 + $2
+    ORDER BY project_name, position;
+	`
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "flaky_tests_batch", query, withNamePattern(opts.NamePattern,
+		projectIDs, limit, opts.SuiteName, opts.Offset, opts.SinceDays,
+		r.successStatuses, r.ignoredStatuses, opts.MinRuns, opts.MinFailureRate)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requested := make(map[string]bool, len(projectIDs))
+	for _, projectID := range projectIDs {
+		requested[projectID] = true
+	}
+
+	results := map[string][]*gql.FlakyTest{}
+	for rows.Next() {
+		var row flakyTestRow
+		var projectName, projectUUID string
+		if err := rows.Scan(append(row.columns(), &projectName, &projectUUID)...); err != nil {
+			return nil, err
+		}
+
+		// A project may have been requested by name, by UUID, or both; each
+		// key gets its own copy so failure messages are attached once.
+		for _, projectID := range []string{projectName, projectUUID} {
+			if requested[projectID] {
+				results[projectID] = append(results[projectID], row.flakyTest())
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, classifyQueryError(err)
+	}
+	// Release the connection before issuing the follow-up queries.
+	rows.Close()
+
+	filter := failureMessageFilter{suiteName: opts.SuiteName, sinceDays: opts.SinceDays, namePattern: opts.NamePattern}
+	if err := r.attachRunDetails(ctx, results, filter); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,101 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakyTestRepo.GetFlakyTestsBatch", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("fetches every project with a single query", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{
+			{"login", 10, 6, nil, nil, nil, nil, "demo", "uuid-demo"},
+			{"invoice", 4, 2, nil, nil, nil, nil, "billing", "uuid-billing"},
+		}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{}, nil)
+
+		results, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo", "uuid-billing", "paging"}, 5,
+			repo.FlakyTestOptions{SuiteName: "Auth Suite"})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results["demo"]).To(HaveLen(1))
+		Expect(results["demo"][0].TestName).To(Equal("login"))
+		Expect(results["uuid-billing"][0].TestName).To(Equal("invoice"))
+		Expect(results).ToNot(HaveKey("paging"))
+
+		// One query for the flaky tests, one each for their failure messages
+		// and status counts.
+		Expect(fakeDB.QueryCallCount()).To(Equal(3))
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("PARTITION BY project_details.id"))
+		Expect(args[:5]).To(Equal([]any{[]string{"demo", "uuid-billing", "paging"}, 5, "Auth Suite", 0, repo.DefaultSinceDays}))
+
+		// The follow-up queries count runs over the same window and suite.
+		_, _, args = fakeDB.QueryArgsForCall(1)
+		Expect(args[4:6]).To(Equal([]any{"Auth Suite", repo.DefaultSinceDays}))
+		_, _, args = fakeDB.QueryArgsForCall(2)
+		Expect(args[5:7]).To(Equal([]any{"Auth Suite", repo.DefaultSinceDays}))
+	})
+
+	It("gives a project requested by name and UUID a copy under each key", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{
+			{"login", 10, 6, nil, nil, nil, nil, "demo", "uuid-demo"},
+		}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{data: [][]any{
+			{"demo", "uuid-demo", "login", "timeout", 6},
+		}}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{}, nil)
+
+		results, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo", "uuid-demo"}, 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results["demo"][0]).ToNot(BeIdenticalTo(results["uuid-demo"][0]))
+		Expect(results["demo"][0].TopFailureMessages).To(HaveLen(1))
+		Expect(results["uuid-demo"][0].TopFailureMessages).To(HaveLen(1))
+	})
+
+	It("rejects an out of range limit without querying", func() {
+		_, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo"}, 0, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError("limit must be between 1 and 1000, got 0"))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("rejects a negative offset without querying", func() {
+		_, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo"}, 5, repo.FlakyTestOptions{Offset: -1})
+		Expect(err).To(MatchError("offset must be non-negative, got -1"))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("returns the query error", func() {
+		fakeDB.QueryReturns(nil, errors.New("boom"))
+
+		results, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo"}, 5, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError("boom"))
+		Expect(results).To(BeNil())
+	})
+
+	It("classifies a timeout raised while reading the rows", func() {
+		fakeDB.QueryReturns(&fakeRows{err: context.DeadlineExceeded}, nil)
+
+		results, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo"}, 5, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(errors.Is(err, repo.ErrUnavailable)).To(BeTrue())
+		Expect(results).To(BeNil())
+	})
+})
@@ -0,0 +1,134 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// FlakyTestCache is a FlakyTestProvider that serves repeated identical
+// requests from memory until their TTL elapses. Errors are never cached.
+// Cached results are shared between callers and must not be modified.
+//
+// With WithStaleOnError, an expired result is kept for a while longer and
+// served, marked IsStale, when refreshing it fails, so dashboards keep
+// showing data through a brief database outage.
+type FlakyTestCache struct {
+	provider FlakyTestProvider
+	ttl      time.Duration
+	staleFor time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[flakyTestCacheKey]flakyTestCacheEntry
+}
+
+type flakyTestCacheKey struct {
+	// tenant keeps the same project of different tenant databases apart.
+	tenant    string
+	projectID string
+	limit     int
+	opts      FlakyTestOptions
+}
+
+type flakyTestCacheEntry struct {
+	results []*gql.FlakyTest
+	fetched time.Time
+	expires time.Time
+	// staleUntil is when the results may no longer stand in for a failed
+	// refresh; it equals expires without WithStaleOnError.
+	staleUntil time.Time
+}
+
+// CacheOption configures a FlakyTestCache.
+type CacheOption func(*FlakyTestCache)
+
+// WithClock replaces time.Now, letting tests control expiry.
+func WithClock(now func() time.Time) CacheOption {
+	return func(c *FlakyTestCache) {
+		c.now = now
+	}
+}
+
+// WithStaleOnError serves the last results of a request for up to window
+// after they expire whenever the wrapped provider fails to refresh them.
+// It applies even with a zero TTL, where every request is refreshed.
+func WithStaleOnError(window time.Duration) CacheOption {
+	return func(c *FlakyTestCache) {
+		c.staleFor = window
+	}
+}
+
+// NewFlakyTestCache wraps provider so results are reused for ttl.
+func NewFlakyTestCache(provider FlakyTestProvider, ttl time.Duration, opts ...CacheOption) *FlakyTestCache {
+	c := &FlakyTestCache{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  map[flakyTestCacheKey]flakyTestCacheEntry{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetFlakyTests returns cached results for an identical earlier request that
+// has not expired, and otherwise delegates to the wrapped provider. When the
+// provider fails and the earlier results are within the stale window, they
+// are returned instead of the error.
+func (c *FlakyTestCache) GetFlakyTests(ctx context.Context, projectID string, limit int, opts FlakyTestOptions) ([]*gql.FlakyTest, error) {
+	key := flakyTestCacheKey{tenant: TenantFromContext(ctx), projectID: projectID, limit: limit, opts: opts}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.results, nil
+	}
+
+	results, err := c.provider.GetFlakyTests(ctx, projectID, limit, opts)
+	if err != nil {
+		if ok && c.now().Before(entry.staleUntil) {
+			slog.WarnContext(ctx, "⚠️ Serving stale flaky tests after a failed refresh",
+				"project", projectID, "age", c.now().Sub(entry.fetched), "error", err)
+			return markStale(entry.results), nil
+		}
+		return nil, err
+	}
+
+	c.store(key, results)
+	return results, nil
+}
+
+// store records results and drops entries that have already expired, so keys
+// that are never requested again do not accumulate.
+func (c *FlakyTestCache) store(key flakyTestCacheKey, results []*gql.FlakyTest) {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, e := range c.entries {
+		if !now.Before(e.staleUntil) {
+			delete(c.entries, k)
+		}
+	}
+	expires := now.Add(c.ttl)
+	c.entries[key] = flakyTestCacheEntry{results: results, fetched: now, expires: expires, staleUntil: expires.Add(c.staleFor)}
+}
+
+// markStale returns copies of tests flagged IsStale, leaving the cached,
+// shared tests untouched.
+func markStale(tests []*gql.FlakyTest) []*gql.FlakyTest {
+	stale := make([]*gql.FlakyTest, len(tests))
+	for i, test := range tests {
+		copied := *test
+		copied.IsStale = true
+		stale[i] = &copied
+	}
+	return stale
+}
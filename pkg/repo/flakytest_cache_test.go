@@ -0,0 +1,176 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestCache", func() {
+	var (
+		ctx      context.Context
+		provider *fakes.FakeFlakyTestProvider
+		now      time.Time
+		cache    repo.FlakyTestProvider
+		expected []*gql.FlakyTest
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		provider = &fakes.FakeFlakyTestProvider{}
+		now = time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+		cache = repo.NewFlakyTestCache(provider, time.Minute, repo.WithClock(func() time.Time { return now }))
+		expected = []*gql.FlakyTest{{TestID: "login", TestName: "login", FailureRate: 0.5}}
+		provider.GetFlakyTestsReturns(expected, nil)
+	})
+
+	It("serves a repeated request from the cache", func() {
+		first, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		second, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		Expect(first).To(Equal(expected))
+		Expect(second).To(Equal(expected))
+		Expect(provider.GetFlakyTestsCallCount()).To(Equal(1))
+	})
+
+	It("misses for a different project, limit or filter", func() {
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		_, _ = cache.GetFlakyTests(ctx, "billing", 5, repo.FlakyTestOptions{})
+		_, _ = cache.GetFlakyTests(ctx, "demo", 10, repo.FlakyTestOptions{})
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SuiteName: "Auth Suite"})
+
+		Expect(provider.GetFlakyTestsCallCount()).To(Equal(4))
+	})
+
+	It("keeps the same project of different tenants apart", func() {
+		_, _ = cache.GetFlakyTests(repo.WithTenant(ctx, "staging"), "demo", 5, repo.FlakyTestOptions{})
+		_, _ = cache.GetFlakyTests(repo.WithTenant(ctx, "prod"), "demo", 5, repo.FlakyTestOptions{})
+		_, _ = cache.GetFlakyTests(repo.WithTenant(ctx, "prod"), "demo", 5, repo.FlakyTestOptions{})
+
+		Expect(provider.GetFlakyTestsCallCount()).To(Equal(2))
+	})
+
+	It("reloads once the TTL has elapsed", func() {
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+
+		now = now.Add(59 * time.Second)
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(provider.GetFlakyTestsCallCount()).To(Equal(1))
+
+		now = now.Add(time.Second)
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(provider.GetFlakyTestsCallCount()).To(Equal(2))
+	})
+
+	It("does not cache errors", func() {
+		provider.GetFlakyTestsReturnsOnCall(0, nil, errors.New("db down"))
+
+		_, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError("db down"))
+
+		results, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal(expected))
+		Expect(provider.GetFlakyTestsCallCount()).To(Equal(2))
+	})
+
+	It("is safe for concurrent use", func() {
+		cache = repo.NewFlakyTestCache(provider, time.Minute)
+
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				results, err := cache.GetFlakyTests(ctx, "demo", i%3, repo.FlakyTestOptions{})
+				Expect(err).To(BeNil())
+				Expect(results).To(Equal(expected))
+			}()
+		}
+		wg.Wait()
+	})
+})
+
+var _ = Describe("FlakyTestCache with a stale window", func() {
+	var (
+		ctx      context.Context
+		provider *fakes.FakeFlakyTestProvider
+		now      time.Time
+		cache    repo.FlakyTestProvider
+		expected []*gql.FlakyTest
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		provider = &fakes.FakeFlakyTestProvider{}
+		now = time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+		cache = repo.NewFlakyTestCache(provider, time.Minute, repo.WithStaleOnError(10*time.Minute),
+			repo.WithClock(func() time.Time { return now }))
+		expected = []*gql.FlakyTest{{TestID: "login", TestName: "login", FailureRate: 0.5}}
+		provider.GetFlakyTestsReturnsOnCall(0, expected, nil)
+		provider.GetFlakyTestsReturns(nil, errors.New("db down"))
+	})
+
+	It("serves the last results marked stale when the refresh fails", func() {
+		_, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		now = now.Add(5 * time.Minute)
+		results, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(provider.GetFlakyTestsCallCount()).To(Equal(2))
+		Expect(results).To(Equal([]*gql.FlakyTest{{TestID: "login", TestName: "login", FailureRate: 0.5, IsStale: true}}))
+
+		// The cached results themselves stay fresh for other callers.
+		Expect(expected[0].IsStale).To(BeFalse())
+	})
+
+	It("returns the error once the stale window has passed", func() {
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+
+		now = now.Add(11 * time.Minute)
+		_, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError("db down"))
+	})
+
+	It("returns the error for a request that never succeeded", func() {
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+
+		_, err := cache.GetFlakyTests(ctx, "billing", 5, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError("db down"))
+	})
+
+	It("serves fresh results again once the database recovers", func() {
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		now = now.Add(2 * time.Minute)
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+
+		recovered := []*gql.FlakyTest{{TestID: "login", TestName: "login", FailureRate: 0.25}}
+		provider.GetFlakyTestsReturnsOnCall(2, recovered, nil)
+		results, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal(recovered))
+	})
+
+	It("falls back to the last results even without a TTL", func() {
+		cache = repo.NewFlakyTestCache(provider, 0, repo.WithStaleOnError(time.Minute),
+			repo.WithClock(func() time.Time { return now }))
+
+		_, _ = cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		results, err := cache.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].IsStale).To(BeTrue())
+	})
+})
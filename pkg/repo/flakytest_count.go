@@ -0,0 +1,52 @@
+package repo
+
+import (
+	"context"
+)
+
+//go:generate counterfeiter -o fakes/fake_flaky_test_counter.go . FlakyTestCounter
+type FlakyTestCounter interface {
+	CountFlakyTests(ctx context.Context, projectID string, opts FlakyTestOptions) (int, error)
+}
+
+// CountFlakyTests returns how many specs GetFlakyTests would rank for
+// the same project and filters, ignoring the offset and limit.
+func (r *FlakyTestRepo) CountFlakyTests(ctx context.Context, projectID string, opts FlakyTestOptions) (int, error) {
+	if err := opts.normalize(); err != nil {
+		return 0, err
+	}
+
+	testName := testNameColumn(opts.NamePattern, 8)
+	query := `
+    SELECT COUNT(*)
+    FROM (
+        SELECT 1
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectMatch + `
+          AND ($2 = '' OR suite_runs.suite_name = $2)
+          AND spec_runs.start_time >= NOW() - make_interval(days => $3)
+          AND NOT spec_runs.status = ANY($5)
+        GROUP BY ` + testName + `
+        HAVING COUNT(*) >= $6
+          AND (COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($4)))::float / COUNT(*) >= $7
+    ) qualifying;
+	`
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "flaky_tests_count", query, withNamePattern(opts.NamePattern,
+		projectID, opts.SuiteName, opts.SinceDays,
+		r.successStatuses, r.ignoredStatuses, opts.MinRuns, opts.MinFailureRate)...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, err
+		}
+	}
+	return count, classifyQueryError(rows.Err())
+}
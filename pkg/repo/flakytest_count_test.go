@@ -0,0 +1,63 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakyTestRepo.CountFlakyTests", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("counts the qualifying specs with the same filters as GetFlakyTests", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{7}}}, nil)
+
+		count, err := repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{
+			SuiteName: "auth", Offset: 20, MinRuns: 5, MinFailureRate: 0.1,
+		})
+		Expect(err).To(BeNil())
+		Expect(count).To(Equal(7))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("GROUP BY spec_runs.spec_description"))
+		Expect(sql).To(ContainSubstring("HAVING COUNT(*) >= $6"))
+		Expect(sql).NotTo(ContainSubstring("LIMIT"))
+		Expect(args).To(Equal([]any{"demo", "auth", repo.DefaultSinceDays,
+			repo.DefaultSuccessStatuses, repo.DefaultIgnoredStatuses, 5, 0.1}))
+	})
+
+	It("returns zero when nothing qualifies", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{0}}}, nil)
+
+		count, err := repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(count).To(BeZero())
+	})
+
+	It("rejects invalid filters without querying", func() {
+		_, err := repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{MinFailureRate: 2})
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("returns the query error", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		_, err := repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{})
+		Expect(err).To(MatchError("db down"))
+	})
+})
@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// DefaultNamePattern matches bracketed spec parameters, so that
+// "handles token [case 1]" and "handles token [case 2]" both group as
+// "handles token". It is valid in both Go and Postgres regular expressions.
+const DefaultNamePattern = `\s*\[[^\]]*\]`
+
+// rawTestName is the spec name column the flaky test queries group by when
+// names are not normalized.
+const rawTestName = "spec_runs.spec_description"
+
+// testNameColumn returns the expression the flaky test queries group specs
+// by. With a pattern, its matches are removed from the spec name before
+// grouping, so parameterized specs aggregate into a single test; the pattern
+// itself is bound as parameter param and never spliced into the query.
+func testNameColumn(pattern string, param int) string {
+	if pattern == "" {
+		return rawTestName
+	}
+	return "btrim(regexp_replace(" + rawTestName + ", $" + strconv.Itoa(param) + ", '', 'g'))"
+}
+
+// withNamePattern appends the pattern to args when testNameColumn refers to
+// it, as Postgres rejects arguments that a query does not use.
+func withNamePattern(pattern string, args ...any) []any {
+	if pattern == "" {
+		return args
+	}
+	return append(args, pattern)
+}
+
+// validateNamePattern rejects patterns that do not compile. Go and Postgres
+// regular expressions agree on the common syntax, so this catches typos
+// before they reach SQL.
+func validateNamePattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return InvalidArgumentf("invalid namePattern %q: %v", pattern, err)
+	}
+	return nil
+}
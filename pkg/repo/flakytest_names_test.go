@@ -0,0 +1,123 @@
+package repo_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestRepo name normalization", func() {
+	const normalized = `btrim(regexp_replace(spec_runs.spec_description, $10, '', 'g'))`
+
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	DescribeTable("DefaultNamePattern collapses parameterized spec names",
+		func(names []string, expected string) {
+			pattern := regexp.MustCompile(repo.DefaultNamePattern)
+			for _, name := range names {
+				Expect(strings.TrimSpace(pattern.ReplaceAllString(name, ""))).To(Equal(expected), name)
+			}
+		},
+		Entry("numbered cases", []string{"handles token [case 1]", "handles token [case 2]", "handles token [case 10]"}, "handles token"),
+		Entry("a parameter mid-name", []string{"login [admin] redirects", "login [guest] redirects"}, "login redirects"),
+		Entry("several parameters", []string{"retries [3] on [503]", "retries [5] on [429]"}, "retries on"),
+		Entry("empty brackets", []string{"renders []", "renders"}, "renders"),
+	)
+
+	It("groups by the raw spec name by default", func() {
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		_, query, args := fakeDB.QueryArgsForCall(0)
+		Expect(query).To(ContainSubstring("GROUP BY spec_runs.spec_description\n"))
+		Expect(query).NotTo(ContainSubstring("regexp_replace"))
+		Expect(args).To(HaveLen(9))
+	})
+
+	It("aggregates parameterized specs under their normalized name", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"handles token", 4, 2, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{data: [][]any{{"demo", "uuid-demo", "handles token", "token expired", 2}}}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{data: [][]any{{"demo", "uuid-demo", "handles token", 2, 2, 0, 1}}}, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{NamePattern: repo.DefaultNamePattern})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].TestName).To(Equal("handles token"))
+		Expect(results[0].RunCount).To(Equal(4))
+		Expect(results[0].FailureRate).To(Equal(0.5))
+		Expect(results[0].TopFailureMessages).To(Equal([]*gql.FailureMessage{{Message: "token expired", Count: 2}}))
+		Expect(results[0].StatusCounts).To(Equal(&gql.StatusCounts{Passed: 2, Failed: 2, Error: 0, Skipped: 1}))
+
+		_, query, args := fakeDB.QueryArgsForCall(0)
+		Expect(query).To(ContainSubstring(normalized + " AS test_name"))
+		Expect(query).To(ContainSubstring("GROUP BY " + normalized + "\n"))
+		Expect(query).To(ContainSubstring("NULLS LAST, " + normalized + "\n"))
+		Expect(query).NotTo(ContainSubstring(repo.DefaultNamePattern))
+		Expect(args).To(HaveLen(10))
+		Expect(args[9]).To(Equal(repo.DefaultNamePattern))
+
+		// The follow-up queries match runs by the same normalized name.
+		for call := 1; call <= 2; call++ {
+			_, query, args := fakeDB.QueryArgsForCall(call)
+			Expect(query).To(ContainSubstring("AND btrim(regexp_replace(spec_runs.spec_description, $8, '', 'g')) = ANY($2)"))
+			Expect(args[1]).To(Equal([]string{"handles token"}))
+			Expect(args[len(args)-1]).To(Equal(repo.DefaultNamePattern))
+		}
+	})
+
+	It("normalizes batched and counted results the same way", func() {
+		opts := repo.FlakyTestOptions{NamePattern: `\(.*\)`}
+
+		_, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo"}, 5, opts)
+		Expect(err).To(BeNil())
+		_, query, args := fakeDB.QueryArgsForCall(0)
+		Expect(query).To(ContainSubstring("project_details.uuid, " + normalized + "\n"))
+		Expect(args[9]).To(Equal(`\(.*\)`))
+
+		_, err = repoInst.CountFlakyTests(ctx, "demo", opts)
+		Expect(err).To(BeNil())
+		_, query, args = fakeDB.QueryArgsForCall(1)
+		Expect(query).To(ContainSubstring("GROUP BY btrim(regexp_replace(spec_runs.spec_description, $8, '', 'g'))"))
+		Expect(args).To(HaveLen(8))
+		Expect(args[7]).To(Equal(`\(.*\)`))
+	})
+
+	It("rejects a pattern that does not compile without querying", func() {
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{NamePattern: "[case"})
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring(`invalid namePattern "[case"`)))
+
+		_, err = repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{NamePattern: "(unclosed"})
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeDB.QueryCallCount()).To(BeZero())
+	})
+
+	It("is not supported by the SQLite backend", func() {
+		db, err := sql.Open("sqlite-stub", ":memory:")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(db.Close)
+
+		_, err = repo.NewSQLiteFlakyTestRepo(db).GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{NamePattern: repo.DefaultNamePattern})
+		Expect(err).To(MatchError(ContainSubstring("namePattern is not supported by the SQLite backend")))
+	})
+})
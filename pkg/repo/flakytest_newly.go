@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// DefaultMinFailureRateIncrease is how much a test's failure rate must rise
+// over its baseline for it to count as newly flaky.
+const DefaultMinFailureRateIncrease = 0.2
+
+// rateTolerance absorbs float rounding when comparing rate differences, so a
+// rise from 0.1 to 0.3 meets a minimum increase of 0.2.
+const rateTolerance = 1e-9
+
+//go:generate counterfeiter -o fakes/fake_newly_flaky_test_provider.go . NewlyFlakyTestProvider
+type NewlyFlakyTestProvider interface {
+	GetNewlyFlakyTests(ctx context.Context, projectID string, recentDays, baselineDays int, minIncrease float64) ([]*gql.FlakyTest, error)
+}
+
+// GetNewlyFlakyTests compares each spec's failure rate over the last
+// recentDays with the baselineDays before that, and returns the specs whose
+// rate rose by at least minIncrease, largest rise first. Results carry the
+// recent window's statistics. Specs without runs in both windows are left
+// out, as there is nothing to compare.
+func (r *FlakyTestRepo) GetNewlyFlakyTests(ctx context.Context, projectID string, recentDays, baselineDays int, minIncrease float64) ([]*gql.FlakyTest, error) {
+	if recentDays < 1 {
+		return nil, InvalidArgumentf("recentDays must be positive, got %d", recentDays)
+	}
+	if baselineDays < 1 {
+		return nil, InvalidArgumentf("baselineDays must be positive, got %d", baselineDays)
+	}
+	if minIncrease <= 0 || minIncrease > 1 {
+		return nil, InvalidArgumentf("minIncrease must be in (0, 1], got %g", minIncrease)
+	}
+
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	recent, err := r.windowStats(ctx, projectID, recentDays, 0)
+	if err != nil {
+		return nil, err
+	}
+	baseline, err := r.windowStats(ctx, projectID, recentDays+baselineDays, recentDays)
+	if err != nil {
+		return nil, err
+	}
+
+	baselineRates := make(map[string]float64, len(baseline))
+	for _, test := range baseline {
+		baselineRates[test.TestName] = test.FailureRate
+	}
+
+	increases := map[string]float64{}
+	results := []*gql.FlakyTest{}
+	for _, test := range recent {
+		baselineRate, ok := baselineRates[test.TestName]
+		if !ok {
+			continue
+		}
+		if increase := test.FailureRate - baselineRate; increase >= minIncrease-rateTolerance {
+			increases[test.TestName] = increase
+			results = append(results, test)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return increases[results[i].TestName] > increases[results[j].TestName]
+	})
+	return results, nil
+}
+
+// windowStats aggregates each spec's runs that started between fromDays and
+// toDays ago, ordered by test name.
+func (r *FlakyTestRepo) windowStats(ctx context.Context, projectID string, fromDays, toDays int) ([]*gql.FlakyTest, error) {
+	query := `
+    SELECT
+        spec_runs.spec_description AS test_name,
+        COUNT(*) AS total_runs,
+        COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($4)) AS failure_count,
+        MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($4)) AS last_failure,
+        array_agg(NOT spec_runs.status = ANY($4) ORDER BY spec_runs.start_time) AS outcomes,
+        (array_agg(test_runs.git_branch ORDER BY ` + lastFailureOrder + `)
+            FILTER (WHERE NOT spec_runs.status = ANY($4)))[1] AS last_failure_branch,
+        (array_agg(test_runs.git_sha ORDER BY ` + lastFailureOrder + `)
+            FILTER (WHERE NOT spec_runs.status = ANY($4)))[1] AS last_failure_sha
+    FROM spec_runs` + projectJoins + `
+    WHERE ` + projectMatch + `
+      AND spec_runs.start_time >= NOW() - make_interval(days => $2)
+      AND ($3 = 0 OR spec_runs.start_time < NOW() - make_interval(days => $3))
+      AND NOT spec_runs.status = ANY($5)
+    GROUP BY spec_runs.spec_description
+    ORDER BY spec_runs.spec_description;
+	`
+	rows, err := timedQuery(ctx, r.reader(), "flaky_test_window", query, projectID, fromDays, toDays,
+		r.successStatuses, r.ignoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFlakyTests(rows)
+}
@@ -0,0 +1,95 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakyTestRepo.GetNewlyFlakyTests", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("returns specs that were stable in the baseline but fail recently", func() {
+		// Recent window
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{
+			{"checkout", 4, 2, nil},
+			{"login", 4, 3, nil},
+			{"search", 4, 2, nil},
+			{"signup", 2, 2, nil},
+		}}, nil)
+		// Baseline window; signup has no runs to compare against.
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{data: [][]any{
+			{"checkout", 10, 0, nil},
+			{"login", 10, 0, nil},
+			{"search", 10, 5, nil},
+		}}, nil)
+
+		results, err := repoInst.GetNewlyFlakyTests(ctx, "demo", 7, 30, repo.DefaultMinFailureRateIncrease)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].TestName).To(Equal("login"))
+		Expect(results[0].FailureRate).To(BeNumerically("~", 0.75, 0.001))
+		Expect(results[1].TestName).To(Equal("checkout"))
+
+		Expect(fakeDB.QueryCallCount()).To(Equal(2))
+		_, sql, recentArgs := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("spec_runs.start_time < NOW() - make_interval(days => $3)"))
+		Expect(recentArgs[:3]).To(Equal([]any{"demo", 7, 0}))
+		_, _, baselineArgs := fakeDB.QueryArgsForCall(1)
+		Expect(baselineArgs[:3]).To(Equal([]any{"demo", 37, 7}))
+	})
+
+	It("includes a spec whose rise equals the minimum increase", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"login", 10, 3, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{data: [][]any{{"login", 10, 1, nil}}}, nil)
+
+		results, err := repoInst.GetNewlyFlakyTests(ctx, "demo", 7, 30, 0.2)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(1))
+	})
+
+	It("returns an empty list when nothing regressed", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{"login", 10, 1, nil}}}, nil)
+
+		results, err := repoInst.GetNewlyFlakyTests(ctx, "demo", 7, 30, 0.2)
+		Expect(err).To(BeNil())
+		Expect(results).NotTo(BeNil())
+		Expect(results).To(BeEmpty())
+	})
+
+	DescribeTable("rejects invalid windows and thresholds without querying",
+		func(recentDays, baselineDays int, minIncrease float64, message string) {
+			_, err := repoInst.GetNewlyFlakyTests(ctx, "demo", recentDays, baselineDays, minIncrease)
+			Expect(err).To(MatchError(message))
+			Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+			Expect(fakeDB.QueryCallCount()).To(Equal(0))
+		},
+		Entry("an empty recent window", 0, 30, 0.2, "recentDays must be positive, got 0"),
+		Entry("an empty baseline window", 7, -1, 0.2, "baselineDays must be positive, got -1"),
+		Entry("a zero increase", 7, 30, 0.0, "minIncrease must be in (0, 1], got 0"),
+		Entry("an increase above one", 7, 30, 1.5, "minIncrease must be in (0, 1], got 1.5"),
+	)
+
+	It("returns the baseline query error", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{}, nil)
+		fakeDB.QueryReturnsOnCall(1, nil, errors.New("boom"))
+
+		results, err := repoInst.GetNewlyFlakyTests(ctx, "demo", 7, 30, 0.2)
+		Expect(err).To(MatchError("boom"))
+		Expect(results).To(BeNil())
+	})
+})
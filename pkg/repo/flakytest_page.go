@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor error = &kindError{kind: ErrInvalidArgument, msg: "invalid cursor"}
+
+//go:generate counterfeiter -o fakes/fake_flaky_test_pager.go . FlakyTestPager
+type FlakyTestPager interface {
+	GetFlakyTestsPage(ctx context.Context, projectID string, first int, after *FlakyTestCursor) ([]*gql.FlakyTest, bool, error)
+}
+
+// FlakyTestCursor identifies a position in the flaky test ordering, which is
+// failure rate descending and then test name ascending.
+type FlakyTestCursor struct {
+	FailureRate float64 `json:"r"`
+	TestName    string  `json:"n"`
+}
+
+// CursorFor returns the cursor positioned at the given test.
+func CursorFor(test *gql.FlakyTest) FlakyTestCursor {
+	return FlakyTestCursor{FailureRate: test.FailureRate, TestName: test.TestName}
+}
+
+// EncodeCursor returns the opaque string form of c.
+func EncodeCursor(c FlakyTestCursor) string {
+	raw, _ := json.Marshal(c) //nolint:all // marshalling a float and a string cannot fail
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor.
+func DecodeCursor(s string) (*FlakyTestCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var c FlakyTestCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return &c, nil
+}
+
+// GetFlakyTestsPage returns up to first flaky tests positioned after the
+// cursor (or from the start when after is nil), and whether more remain.
+// Like GetFlakyTests, it only counts runs from the last DefaultSinceDays
+// days.
+func (r *FlakyTestRepo) GetFlakyTestsPage(ctx context.Context, projectID string, first int, after *FlakyTestCursor) ([]*gql.FlakyTest, bool, error) {
+	if err := validateLimit(first); err != nil {
+		return nil, false, err
+	}
+
+	// Fetch one extra row to learn whether another page exists.
+	args := []any{projectID, first + 1, r.successStatuses, r.ignoredStatuses, DefaultSinceDays}
+	keyset := ""
+	if after != nil {
+		keyset = `WHERE failure_rate < $6 OR (failure_rate = $6 AND test_name > $7)`
+		args = append(args, after.FailureRate, after.TestName)
+	}
+
+	query := `
+    SELECT test_name, total_runs, failure_count, last_failure, outcomes, last_failure_branch, last_failure_sha
+    FROM (
+        SELECT
+            spec_runs.spec_description AS test_name,
+            COUNT(*) AS total_runs,
+            COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($3)) AS failure_count,
+            MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($3)) AS last_failure,
+            (COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($3)))::float8 / COUNT(*) AS failure_rate,
+            array_agg(NOT spec_runs.status = ANY($3) ORDER BY spec_runs.start_time) AS outcomes,
+            (array_agg(test_runs.git_branch ORDER BY ` + lastFailureOrder + `)
+                FILTER (WHERE NOT spec_runs.status = ANY($3)))[1] AS last_failure_branch,
+            (array_agg(test_runs.git_sha ORDER BY ` + lastFailureOrder + `)
+                FILTER (WHERE NOT spec_runs.status = ANY($3)))[1] AS last_failure_sha
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectMatch + `
+          AND NOT spec_runs.status = ANY($4)
+          AND spec_runs.start_time >= NOW() - make_interval(days => $5)
+        GROUP BY spec_runs.spec_description
+    ) stats
+    ` + keyset + `
+    ORDER BY failure_rate DESC, test_name ASC
+    LIMIT $2;
+	`
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "flaky_tests_page", query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	results, err := scanFlakyTests(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Release the connection before issuing the follow-up queries.
+	rows.Close()
+
+	hasNext := len(results) > first
+	if hasNext {
+		results = results[:first]
+	}
+	if err := r.attachRunDetails(ctx, map[string][]*gql.FlakyTest{projectID: results}, failureMessageFilter{sinceDays: DefaultSinceDays}); err != nil {
+		return nil, false, err
+	}
+	return results, hasNext, nil
+}
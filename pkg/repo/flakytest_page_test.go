@@ -0,0 +1,99 @@
+package repo_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("Flaky test cursors", func() {
+	It("round-trips a cursor through its opaque encoding", func() {
+		cursor := repo.FlakyTestCursor{FailureRate: 0.3333333333333333, TestName: "LoginService handles expired tokens"}
+
+		encoded := repo.EncodeCursor(cursor)
+		Expect(encoded).ToNot(ContainSubstring("LoginService"))
+
+		decoded, err := repo.DecodeCursor(encoded)
+		Expect(err).To(BeNil())
+		Expect(*decoded).To(Equal(cursor))
+	})
+
+	It("rejects cursors that are not valid base64", func() {
+		_, err := repo.DecodeCursor("not a cursor!")
+		Expect(err).To(MatchError(repo.ErrInvalidCursor))
+	})
+
+	It("rejects cursors that do not contain a position", func() {
+		_, err := repo.DecodeCursor("bm90LWpzb24")
+		Expect(err).To(MatchError(repo.ErrInvalidCursor))
+	})
+})
+
+var _ = Describe("FlakyTestRepo pages", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.FlakyTestPager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("fetches one extra row to report a next page", func() {
+		fakeDB.QueryReturns(&fakeRows{
+			data: [][]any{
+				{"spec_a", 2, 2, nil},
+				{"spec_b", 2, 1, nil},
+				{"spec_c", 2, 0, nil},
+			},
+		}, nil)
+
+		results, hasNext, err := repoInst.GetFlakyTestsPage(ctx, "paging", 2, nil)
+		Expect(err).To(BeNil())
+		Expect(hasNext).To(BeTrue())
+		Expect(results).To(HaveLen(2))
+		Expect(results[1].TestName).To(Equal("spec_b"))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("ORDER BY failure_rate DESC, test_name ASC"))
+		Expect(sql).To(ContainSubstring("spec_runs.start_time >= NOW() - make_interval(days => $5)"))
+		Expect(sql).ToNot(ContainSubstring("failure_rate < $6"))
+		Expect(args[:2]).To(Equal([]any{"paging", 3}))
+		Expect(args[4]).To(Equal(repo.DefaultSinceDays))
+	})
+
+	It("filters by the cursor position on later pages", func() {
+		fakeDB.QueryReturns(&fakeRows{
+			data: [][]any{
+				{"spec_c", 2, 0, nil},
+			},
+		}, nil)
+
+		after := &repo.FlakyTestCursor{FailureRate: 0.5, TestName: "spec_b"}
+		results, hasNext, err := repoInst.GetFlakyTestsPage(ctx, "paging", 2, after)
+		Expect(err).To(BeNil())
+		Expect(hasNext).To(BeFalse())
+		Expect(results).To(HaveLen(1))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("failure_rate < $6 OR (failure_rate = $6 AND test_name > $7)"))
+		Expect(args[:2]).To(Equal([]any{"paging", 3}))
+		Expect(args[5:]).To(Equal([]any{0.5, "spec_b"}))
+	})
+
+	It("rejects page sizes outside the limit bounds", func() {
+		_, _, err := repoInst.GetFlakyTestsPage(ctx, "paging", 0, nil)
+		Expect(err).To(MatchError(repo.ErrInvalidArgument))
+
+		_, _, err = repoInst.GetFlakyTestsPage(ctx, "paging", repo.MaxLimit+1, nil)
+		Expect(err).To(MatchError(repo.ErrInvalidArgument))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+})
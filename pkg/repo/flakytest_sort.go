@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// flakyTestSortColumns maps each sort field to the aggregate it ranks by.
+// ORDER BY is built only from these fixed expressions, never from caller
+// input; $6 is the success statuses in every query that uses them.
+var flakyTestSortColumns = map[gql.FlakyTestSortField]string{
+	gql.FlakyTestSortFieldFailureRate: `(COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($6)))::float / COUNT(*)`,
+	gql.FlakyTestSortFieldRunCount:    `COUNT(*)`,
+	gql.FlakyTestSortFieldLastFailure: `MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($6))`,
+}
+
+var flakyTestSortDirections = map[gql.SortOrder]string{
+	gql.SortOrderAsc:  "ASC",
+	gql.SortOrderDesc: "DESC",
+}
+
+// orderBy returns the ORDER BY expression for the sort options, defaulting
+// to failure rate descending. Specs that never failed sort last by
+// LAST_FAILURE in either direction, and ties fall back to the spec name so
+// pages are stable. testName is the expression the query groups specs by.
+func (o FlakyTestOptions) orderBy(testName string) (string, error) {
+	return o.orderByColumns(flakyTestSortColumns, testName)
+}
+
+// orderByColumns is orderBy over another backend's sort expressions.
+func (o FlakyTestOptions) orderByColumns(columns map[gql.FlakyTestSortField]string, testName string) (string, error) {
+	sortBy, sortOrder := o.SortBy, o.SortOrder
+	if sortBy == "" {
+		sortBy = gql.FlakyTestSortFieldFailureRate
+	}
+	if sortOrder == "" {
+		sortOrder = gql.SortOrderDesc
+	}
+
+	column, ok := columns[sortBy]
+	if !ok {
+		return "", InvalidArgumentf("unsupported sortBy %q", sortBy)
+	}
+	direction, ok := flakyTestSortDirections[sortOrder]
+	if !ok {
+		return "", InvalidArgumentf("unsupported sortOrder %q", sortOrder)
+	}
+	return column + " " + direction + " NULLS LAST, " + testName, nil
+}
@@ -0,0 +1,67 @@
+package repo_test
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakyTestRepo sorting", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	orderBy := func(opts repo.FlakyTestOptions) string {
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, opts)
+		Expect(err).To(BeNil())
+		_, sql, _ := fakeDB.QueryArgsForCall(fakeDB.QueryCallCount() - 1)
+		return sql
+	}
+
+	DescribeTable("orders by the whitelisted column",
+		func(sortBy gql.FlakyTestSortField, sortOrder gql.SortOrder, expected string) {
+			Expect(orderBy(repo.FlakyTestOptions{SortBy: sortBy, SortOrder: sortOrder})).
+				To(ContainSubstring("ORDER BY " + expected + " NULLS LAST, spec_runs.spec_description\n"))
+		},
+		Entry("failure rate by default", gql.FlakyTestSortField(""), gql.SortOrder(""),
+			"(COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($6)))::float / COUNT(*) DESC"),
+		Entry("failure rate ascending", gql.FlakyTestSortFieldFailureRate, gql.SortOrderAsc,
+			"(COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($6)))::float / COUNT(*) ASC"),
+		Entry("run count descending", gql.FlakyTestSortFieldRunCount, gql.SortOrderDesc, "COUNT(*) DESC"),
+		Entry("run count ascending", gql.FlakyTestSortFieldRunCount, gql.SortOrderAsc, "COUNT(*) ASC"),
+		Entry("last failure descending", gql.FlakyTestSortFieldLastFailure, gql.SortOrderDesc,
+			"MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($6)) DESC"),
+		Entry("last failure ascending", gql.FlakyTestSortFieldLastFailure, gql.SortOrderAsc,
+			"MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($6)) ASC"),
+	)
+
+	It("ranks batched results with the same ordering", func() {
+		_, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo"}, 5,
+			repo.FlakyTestOptions{SortBy: gql.FlakyTestSortFieldRunCount, SortOrder: gql.SortOrderAsc})
+		Expect(err).To(BeNil())
+		_, sql, _ := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("ORDER BY COUNT(*) ASC NULLS LAST, spec_runs.spec_description\n"))
+	})
+
+	It("rejects sort values outside the whitelist without querying", func() {
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SortBy: "test_name; DROP TABLE spec_runs"})
+		Expect(err).To(MatchError(`unsupported sortBy "test_name; DROP TABLE spec_runs"`))
+
+		_, err = repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SortOrder: "SIDEWAYS"})
+		Expect(err).To(MatchError(`unsupported sortOrder "SIDEWAYS"`))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// attachStatusCounts fills StatusCounts on each test with its runs per
+// outcome, fetched in a single query for every project. A run passed when
+// its status is a success status, was skipped when it is ignored, errored
+// when it is one of DefaultErrorStatuses and failed otherwise, so passed,
+// failed and error add up to the test's RunCount.
+func (r *FlakyTestRepo) attachStatusCounts(ctx context.Context, byProject map[string][]*gql.FlakyTest, filter failureMessageFilter) error {
+	byTest, projectIDs, names := indexFlakyTests(byProject)
+	if len(byTest) == 0 {
+		return nil
+	}
+
+	testName := testNameColumn(filter.namePattern, 8)
+	query := `
+    SELECT
+        project_name,
+        project_uuid,
+        test_name,
+        COUNT(*) FILTER (WHERE outcome = 'passed') AS passed,
+        COUNT(*) FILTER (WHERE outcome = 'failed') AS failed,
+        COUNT(*) FILTER (WHERE outcome = 'error') AS errored,
+        COUNT(*) FILTER (WHERE outcome = 'skipped') AS skipped
+    FROM (
+        SELECT
+            project_details.id AS project_id,
+            project_details.name AS project_name,
+            project_details.uuid::text AS project_uuid,
+            ` + testName + ` AS test_name,
+            CASE
+                WHEN spec_runs.status = ANY($3) THEN 'passed'
+                WHEN spec_runs.status = ANY($4) THEN 'skipped'
+                WHEN spec_runs.status = ANY($5) THEN 'error'
+                ELSE 'failed'
+            END AS outcome
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectsMatch + `
+          AND ` + testName + ` = ANY($2)
+          AND ($6 = '' OR suite_runs.suite_name = $6)
+          AND spec_runs.start_time >= NOW() - make_interval(days => $7)
+    ) runs
+    GROUP BY project_id, project_name, project_uuid, test_name;
+	`
+	rows, err := timedQuery(ctx, r.reader(), "flaky_test_status_counts", query, withNamePattern(filter.namePattern,
+		projectIDs, names, r.successStatuses, r.ignoredStatuses, DefaultErrorStatuses, filter.suiteName, filter.sinceDays)...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var projectName, projectUUID, testName string
+		counts := gql.StatusCounts{}
+		if err := rows.Scan(&projectName, &projectUUID, &testName,
+			&counts.Passed, &counts.Failed, &counts.Error, &counts.Skipped); err != nil {
+			return err
+		}
+
+		// A project may have been requested by name, by UUID, or both.
+		for _, projectID := range []string{projectName, projectUUID} {
+			if test, ok := byTest[flakyTestKey{projectID, testName}]; ok {
+				test.StatusCounts = &gql.StatusCounts{
+					Passed: counts.Passed, Failed: counts.Failed, Error: counts.Error, Skipped: counts.Skipped,
+				}
+			}
+		}
+	}
+	return classifyQueryError(rows.Err())
+}
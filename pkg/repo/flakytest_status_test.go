@@ -0,0 +1,97 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestRepo status counts", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("breaks each flaky test's runs down by status", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{
+			{"checkout", 10, 6, nil},
+			{"login", 4, 1, nil},
+			{"signup", 3, 0, nil},
+		}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{data: [][]any{
+			{"demo", "uuid-demo", "checkout", 4, 3, 3, 2},
+			{"demo", "uuid-demo", "login", 3, 0, 1, 0},
+			{"demo", "uuid-demo", "signup", 3, 0, 0, 5},
+		}}, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 3, repo.FlakyTestOptions{SuiteName: "Shop Suite", SinceDays: 7})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(3))
+		Expect(results[0].StatusCounts).To(Equal(&gql.StatusCounts{Passed: 4, Failed: 3, Error: 3, Skipped: 2}))
+		Expect(results[1].StatusCounts).To(Equal(&gql.StatusCounts{Passed: 3, Failed: 0, Error: 1, Skipped: 0}))
+		Expect(results[2].StatusCounts).To(Equal(&gql.StatusCounts{Passed: 3, Failed: 0, Error: 0, Skipped: 5}))
+
+		_, sql, args := fakeDB.QueryArgsForCall(2)
+		Expect(sql).To(ContainSubstring("WHEN spec_runs.status = ANY($5) THEN 'error'"))
+		Expect(sql).To(ContainSubstring("COUNT(*) FILTER (WHERE outcome = 'skipped')"))
+		Expect(sql).NotTo(ContainSubstring("NOT spec_runs.status = ANY($4)"))
+		Expect(args[0]).To(Equal([]string{"demo"}))
+		Expect(args[1]).To(ConsistOf("checkout", "login", "signup"))
+		Expect(args[2:]).To(Equal([]any{repo.DefaultSuccessStatuses, repo.DefaultIgnoredStatuses,
+			repo.DefaultErrorStatuses, "Shop Suite", 7}))
+	})
+
+	It("gives a project requested by name and UUID the counts under each key", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{
+			{"login", 10, 6, nil, nil, nil, nil, "demo", "uuid-demo"},
+		}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{data: [][]any{
+			{"demo", "uuid-demo", "login", 4, 5, 1, 1},
+		}}, nil)
+
+		results, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo", "uuid-demo"}, 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		want := &gql.StatusCounts{Passed: 4, Failed: 5, Error: 1, Skipped: 1}
+		Expect(results["demo"][0].StatusCounts).To(Equal(want))
+		Expect(results["uuid-demo"][0].StatusCounts).To(Equal(want))
+		Expect(results["demo"][0].StatusCounts).NotTo(BeIdenticalTo(results["uuid-demo"][0].StatusCounts))
+	})
+
+	It("counts paged results over the default lookback window", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"login", 10, 6, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{data: [][]any{{"demo", "uuid-demo", "login", 4, 6, 0, 0}}}, nil)
+
+		results, _, err := repoInst.GetFlakyTestsPage(ctx, "demo", 5, nil)
+		Expect(err).To(BeNil())
+		Expect(results[0].StatusCounts).To(Equal(&gql.StatusCounts{Passed: 4, Failed: 6}))
+
+		_, _, args := fakeDB.QueryArgsForCall(2)
+		Expect(args[5:]).To(Equal([]any{"", repo.DefaultSinceDays}))
+	})
+
+	It("returns an error when the status query fails", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"login", 10, 6, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{}, nil)
+		fakeDB.QueryReturnsOnCall(2, nil, errors.New("boom"))
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 1, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError("boom"))
+		Expect(results).To(BeNil())
+	})
+})
@@ -1,7 +1,12 @@
 package repo_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"reflect"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -10,12 +15,18 @@ import (
 	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
 	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
 	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 type fakeRows struct {
 	pgx.Rows
 	index int
 	data  [][]any
+	// err is returned by Err, as pgx reports failures while a query runs.
+	err error
 }
 
 func (f *fakeRows) Next() bool {
@@ -23,13 +34,38 @@ func (f *fakeRows) Next() bool {
 }
 
 func (f *fakeRows) Scan(dest ...any) error {
-	copy(dest, f.data[f.index])
+	row := f.data[f.index]
 	f.index++
+	for i := range dest {
+		if i >= len(row) {
+			break
+		}
+		assign(dest[i], row[i])
+	}
 	return nil
 }
 
 func (f *fakeRows) Close() {}
 
+func (f *fakeRows) Err() error { return f.err }
+
+// assign writes src into the pointer dest the way pgx would, allocating when
+// dest points at a pointer (nullable column) and src is a plain value.
+func assign(dest, src any) {
+	target := reflect.ValueOf(dest).Elem()
+	value := reflect.ValueOf(src)
+	switch {
+	case !value.IsValid():
+		target.Set(reflect.Zero(target.Type()))
+	case value.Type().AssignableTo(target.Type()):
+		target.Set(value)
+	case target.Kind() == reflect.Pointer && value.Type().AssignableTo(target.Type().Elem()):
+		ptr := reflect.New(target.Type().Elem())
+		ptr.Elem().Set(value)
+		target.Set(ptr)
+	}
+}
+
 var _ = Describe("FlakyTestRepo", func() {
 	var (
 		ctx      context.Context
@@ -46,16 +82,335 @@ var _ = Describe("FlakyTestRepo", func() {
 	It("returns flaky test results from fake rows", func() {
 		mockRows := &fakeRows{
 			data: [][]any{
-				{"LoginSpec", "auth_invalid_token", 40, 12, time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)},
+				{"auth_invalid_token", 40, 12, time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)},
 			},
 		}
 
 		fakeDB.QueryReturns(mockRows, nil)
 
-		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 1)
+		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 1, repo.FlakyTestOptions{})
 		Expect(err).To(BeNil())
 		Expect(results).To(HaveLen(1))
 		Expect(results[0].TestID).To(Equal("auth_invalid_token"))
 		Expect(results[0].FailureRate).To(BeNumerically("~", 0.3, 0.01))
 	})
+
+	It("reports zero rates instead of NaN when a spec has no runs", func() {
+		mockRows := &fakeRows{
+			data: [][]any{
+				{"never_ran", 0, 0, nil},
+			},
+		}
+
+		fakeDB.QueryReturns(mockRows, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 1, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(1))
+		Expect(math.IsNaN(results[0].PassRate)).To(BeFalse())
+		Expect(math.IsNaN(results[0].FailureRate)).To(BeFalse())
+		Expect(results[0].PassRate).To(BeZero())
+		Expect(results[0].FailureRate).To(BeZero())
+		Expect(results[0].LastFailure).To(BeNil())
+	})
+
+	It("scores alternating outcomes as flakier than clustered ones", func() {
+		mockRows := &fakeRows{
+			data: [][]any{
+				{"alternating", 4, 2, nil, []bool{true, false, true, false}},
+				{"clustered", 4, 2, nil, []bool{true, true, false, false}},
+				{"single_run", 1, 1, nil, []bool{true}},
+			},
+		}
+
+		fakeDB.QueryReturns(mockRows, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 3, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(3))
+		Expect(results[0].FlakinessScore).To(Equal(1.0))
+		Expect(results[1].FlakinessScore).To(BeNumerically("~", 1.0/3, 0.001))
+		Expect(results[2].FlakinessScore).To(BeZero())
+
+		_, sql, _ := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("array_agg(NOT spec_runs.status = ANY($6) ORDER BY spec_runs.start_time) AS outcomes"))
+	})
+
+	It("reports the branch and SHA of the most recent failure", func() {
+		mockRows := &fakeRows{
+			data: [][]any{
+				{"checkout", 4, 2, time.Now(), []bool{true, false, true, false}, "feature/retry", "bbb222"},
+				{"stable", 2, 0, nil, []bool{false, false}, nil, nil},
+			},
+		}
+
+		fakeDB.QueryReturns(mockRows, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 2, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].LastFailureBranch).To(HaveValue(Equal("feature/retry")))
+		Expect(results[0].LastFailureSha).To(HaveValue(Equal("bbb222")))
+		Expect(results[1].LastFailureBranch).To(BeNil())
+		Expect(results[1].LastFailureSha).To(BeNil())
+
+		_, sql, _ := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("array_agg(test_runs.git_branch ORDER BY spec_runs.end_time DESC NULLS LAST, spec_runs.id DESC)"))
+	})
+
+	It("passes limit and offset as bound parameters", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{Offset: 40})
+		Expect(err).To(BeNil())
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("OFFSET $4"))
+		Expect(args[:5]).To(Equal([]any{"policy-admin-ui", 20, "", 40, repo.DefaultSinceDays}))
+	})
+
+	It("rejects limits outside the allowed range without querying", func() {
+		for _, limit := range []int{0, -5, repo.MaxLimit + 1} {
+			results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", limit, repo.FlakyTestOptions{})
+			Expect(err).To(MatchError(ContainSubstring("limit must be between 1 and 1000")))
+			Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+			Expect(results).To(BeNil())
+		}
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("rejects a negative offset without querying", func() {
+		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{Offset: -1})
+		Expect(err).To(MatchError(ContainSubstring("offset must be non-negative")))
+		Expect(results).To(BeNil())
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("filters runs to the requested lookback window", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{SinceDays: 7})
+		Expect(err).To(BeNil())
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("spec_runs.start_time >= NOW() - make_interval(days => $5)"))
+		Expect(args[4]).To(Equal(7))
+	})
+
+	It("rejects a negative lookback window", func() {
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{SinceDays: -3})
+		Expect(err).To(MatchError(ContainSubstring("sinceDays must be non-negative")))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("excludes specs below the minimum run count", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{MinRuns: 5})
+		Expect(err).To(BeNil())
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("HAVING COUNT(*) >= $8"))
+		Expect(args[7]).To(Equal(5))
+	})
+
+	It("rejects a negative minimum run count", func() {
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{MinRuns: -1})
+		Expect(err).To(MatchError("minRuns must be non-negative, got -1"))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	DescribeTable("accepts failure rate thresholds within [0, 1]",
+		func(threshold float64) {
+			fakeDB.QueryReturns(&fakeRows{}, nil)
+
+			_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{MinFailureRate: threshold})
+			Expect(err).To(BeNil())
+
+			_, sql, args := fakeDB.QueryArgsForCall(0)
+			Expect(sql).To(ContainSubstring("COUNT(*) >= $9"))
+			Expect(args[8]).To(Equal(threshold))
+		},
+		Entry("zero", 0.0),
+		Entry("half", 0.5),
+		Entry("one", 1.0),
+	)
+
+	DescribeTable("rejects failure rate thresholds outside [0, 1] without querying",
+		func(threshold float64, message string) {
+			_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{MinFailureRate: threshold})
+			Expect(err).To(MatchError(message))
+			Expect(fakeDB.QueryCallCount()).To(Equal(0))
+		},
+		Entry("negative", -0.1, "minFailureRate must be between 0 and 1, got -0.1"),
+		Entry("above one", 1.5, "minFailureRate must be between 0 and 1, got 1.5"),
+	)
+
+	It("excludes ignored statuses and counts only failures against passing runs", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).ToNot(ContainSubstring("'passed'"))
+		Expect(sql).To(ContainSubstring("FILTER (WHERE NOT spec_runs.status = ANY($6)) AS failure_count"))
+		Expect(sql).To(ContainSubstring("AND NOT spec_runs.status = ANY($7)"))
+		Expect(args[5]).To(ConsistOf("passed", "pass"))
+		Expect(args[6]).To(ConsistOf("skipped", "pending"))
+	})
+
+	It("uses custom statuses when configured", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+		repoInst = repo.NewFlakyTestRepo(fakeDB, repo.WithStatuses([]string{"ok"}, []string{"skipped", "pending", "todo"}))
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		_, _, args := fakeDB.QueryArgsForCall(0)
+		Expect(args[5]).To(Equal([]string{"ok"}))
+		Expect(args[6]).To(Equal([]string{"skipped", "pending", "todo"}))
+	})
+	It("logs query timing at debug level", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		var buf bytes.Buffer
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+		DeferCleanup(func() { slog.SetDefault(previous) })
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(buf.String()).To(ContainSubstring("query=flaky_tests"))
+		Expect(buf.String()).To(ContainSubstring("duration="))
+	})
+
+	It("records query counts in the context's query stats", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"login", 10, 6, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{}, nil)
+
+		statsCtx, stats := repo.WithQueryStats(ctx)
+		_, err := repoInst.GetFlakyTests(statsCtx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		count, total := stats.Totals()
+		Expect(count).To(Equal(3))
+		Expect(total).To(BeNumerically(">=", 0))
+	})
+	It("emits a span with the SQL statement and row count", func() {
+		exporter := tracetest.NewInMemoryExporter()
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+		DeferCleanup(func() { otel.SetTracerProvider(previous) })
+
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{"auth_invalid_token", 40, 12, time.Now()}}}, nil)
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		spans := exporter.GetSpans()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name).To(Equal("FlakyTestRepo.GetFlakyTests"))
+
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, kv := range spans[0].Attributes {
+			attrs[kv.Key] = kv.Value
+		}
+		Expect(attrs["db.statement"].AsString()).To(ContainSubstring("FROM spec_runs"))
+		Expect(attrs["db.rows"].AsInt64()).To(Equal(int64(1)))
+	})
+	It("cancels queries that exceed the query timeout", func() {
+		fakeDB.QueryStub = func(ctx context.Context, _ string, _ ...any) (pgx.Rows, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		repoInst = repo.NewFlakyTestRepo(fakeDB, repo.WithQueryTimeout(10*time.Millisecond))
+
+		start := time.Now()
+		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(results).To(BeNil())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+
+	It("reports a timeout raised while reading the rows", func() {
+		// pgx returns the rows before the query fails, so the timeout only
+		// surfaces through Err.
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{"auth_invalid_token", 40, 12, nil}}, err: context.DeadlineExceeded}, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(errors.Is(err, repo.ErrUnavailable)).To(BeTrue())
+		Expect(results).To(BeNil())
+		Expect(fakeDB.QueryCallCount()).To(Equal(1))
+	})
+
+	It("reports a timeout raised while reading the failure messages", func() {
+		fakeDB.QueryReturnsOnCall(0, &fakeRows{data: [][]any{{"auth_invalid_token", 40, 12, nil}}}, nil)
+		fakeDB.QueryReturnsOnCall(1, &fakeRows{err: context.DeadlineExceeded}, nil)
+		fakeDB.QueryReturnsOnCall(2, &fakeRows{}, nil)
+
+		results, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(errors.Is(err, repo.ErrUnavailable)).To(BeTrue())
+		Expect(results).To(BeNil())
+	})
+
+	It("bounds queries with the default timeout", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+
+		queryCtx, _, _ := fakeDB.QueryArgsForCall(0)
+		deadline, ok := queryCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(time.Until(deadline)).To(BeNumerically("~", repo.DefaultQueryTimeout, time.Second))
+		Expect(queryCtx.Err()).To(MatchError(context.Canceled))
+	})
+})
+
+var _ = Describe("FlakyTestRepo read replica", func() {
+	var (
+		ctx     context.Context
+		primary *fakes.FakePgxQuerier
+		replica *fakes.FakePgxQuerier
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		primary = &fakes.FakePgxQuerier{}
+		replica = &fakes.FakePgxQuerier{}
+		primary.QueryReturns(&fakeRows{}, nil)
+		replica.QueryStub = func(context.Context, string, ...any) (pgx.Rows, error) {
+			return &fakeRows{data: [][]any{{"login", 10, 6, nil}}}, nil
+		}
+	})
+
+	It("should send every read query to the replica", func() {
+		repoInst := repo.NewFlakyTestRepo(primary, repo.WithReadReplica(replica))
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(1))
+		_, err = repoInst.GetFlakyTestsBatch(ctx, []string{"demo"}, 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		_, _, err = repoInst.GetFlakyTestsPage(ctx, "demo", 5, nil)
+		Expect(err).To(BeNil())
+		_, err = repoInst.GetTopFailingTests(ctx, 5, 7)
+		Expect(err).To(BeNil())
+
+		Expect(replica.QueryCallCount()).To(BeNumerically(">=", 4))
+		Expect(primary.QueryCallCount()).To(BeZero())
+	})
+
+	It("should fall back to the primary without a replica", func() {
+		repoInst := repo.NewFlakyTestRepo(primary)
+
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(primary.QueryCallCount()).To(Equal(1))
+		Expect(replica.QueryCallCount()).To(BeZero())
+	})
 })
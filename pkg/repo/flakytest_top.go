@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_top_failing_test_provider.go . TopFailingTestProvider
+type TopFailingTestProvider interface {
+	GetTopFailingTests(ctx context.Context, limit int, sinceDays int) ([]*gql.FlakyTest, error)
+}
+
+// GetTopFailingTests returns the specs with the highest failure rate across
+// every project, each tagged with its ProjectName. Specs that never failed in
+// the window are left out. A zero sinceDays means DefaultSinceDays.
+func (r *FlakyTestRepo) GetTopFailingTests(ctx context.Context, limit int, sinceDays int) ([]*gql.FlakyTest, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+	if sinceDays < 0 {
+		return nil, InvalidArgumentf("sinceDays must be non-negative, got %d", sinceDays)
+	}
+	if sinceDays == 0 {
+		sinceDays = DefaultSinceDays
+	}
+
+	query := `
+    SELECT
+        spec_runs.spec_description AS test_name,
+        COUNT(*) AS total_runs,
+        COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($3)) AS failure_count,
+        MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($3)) AS last_failure,
+        array_agg(NOT spec_runs.status = ANY($3) ORDER BY spec_runs.start_time) AS outcomes,
+        (array_agg(test_runs.git_branch ORDER BY ` + lastFailureOrder + `)
+            FILTER (WHERE NOT spec_runs.status = ANY($3)))[1] AS last_failure_branch,
+        (array_agg(test_runs.git_sha ORDER BY ` + lastFailureOrder + `)
+            FILTER (WHERE NOT spec_runs.status = ANY($3)))[1] AS last_failure_sha,
+        project_details.name AS project_name
+    FROM spec_runs` + projectJoins + `
+    WHERE spec_runs.start_time >= NOW() - make_interval(days => $2)
+      AND NOT spec_runs.status = ANY($4)
+    GROUP BY project_details.id, project_details.name, spec_runs.spec_description
+    HAVING COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($3)) > 0
+    ORDER BY (COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($3)))::float / COUNT(*) DESC,
+        project_details.name, spec_runs.spec_description
+    LIMIT $1;
+	`
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "top_failing_tests", query, limit, sinceDays, r.successStatuses, r.ignoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*gql.FlakyTest
+
+	for rows.Next() {
+		var row flakyTestRow
+		var projectName string
+		if err := rows.Scan(append(row.columns(), &projectName)...); err != nil {
+			return nil, err
+		}
+
+		test := row.flakyTest()
+		test.ProjectName = &projectName
+		results = append(results, test)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,89 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestRepo top failing tests", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.TopFailingTestProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("tags each result with its project name", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{
+			{"login", 2, 2, nil, []bool{true, true}, "main", "abc123", "demo"},
+			{"invoice", 4, 1, nil, []bool{false, true, false, false}, "main", "def456", "billing"},
+		}}, nil)
+
+		results, err := repoInst.GetTopFailingTests(ctx, 10, 0)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].TestName).To(Equal("login"))
+		Expect(results[0].ProjectName).To(HaveValue(Equal("demo")))
+		Expect(results[0].FailureRate).To(Equal(1.0))
+		Expect(results[1].ProjectName).To(HaveValue(Equal("billing")))
+		Expect(results[1].FailureRate).To(Equal(0.25))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).ToNot(ContainSubstring("$1 OR"))
+		Expect(sql).To(ContainSubstring("GROUP BY project_details.id, project_details.name, spec_runs.spec_description"))
+		Expect(args[:2]).To(Equal([]any{10, repo.DefaultSinceDays}))
+	})
+
+	It("passes an explicit lookback window", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.GetTopFailingTests(ctx, 5, 7)
+		Expect(err).To(BeNil())
+
+		_, _, args := fakeDB.QueryArgsForCall(0)
+		Expect(args[1]).To(Equal(7))
+	})
+
+	It("rejects limits outside the allowed range without querying", func() {
+		for _, limit := range []int{0, -5, repo.MaxLimit + 1} {
+			results, err := repoInst.GetTopFailingTests(ctx, limit, 0)
+			Expect(err).To(MatchError(ContainSubstring("limit must be between 1 and 1000")))
+			Expect(err).To(MatchError(repo.ErrInvalidArgument))
+			Expect(results).To(BeNil())
+		}
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("rejects a negative lookback window without querying", func() {
+		_, err := repoInst.GetTopFailingTests(ctx, 5, -1)
+		Expect(err).To(MatchError(ContainSubstring("sinceDays must be non-negative")))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		results, err := repoInst.GetTopFailingTests(ctx, 5, 0)
+		Expect(err).To(MatchError("db down"))
+		Expect(results).To(BeNil())
+	})
+
+	It("propagates errors raised while reading rows", func() {
+		fakeDB.QueryReturns(&fakeRows{err: errors.New("connection reset")}, nil)
+
+		results, err := repoInst.GetTopFailingTests(ctx, 5, 0)
+		Expect(err).To(MatchError("connection reset"))
+		Expect(results).To(BeNil())
+	})
+})
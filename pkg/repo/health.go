@@ -0,0 +1,43 @@
+package repo
+
+import "context"
+
+//go:generate counterfeiter -o fakes/fake_pinger.go . Pinger
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+//go:generate counterfeiter -o fakes/fake_schema_version_reader.go . SchemaVersionReader
+type SchemaVersionReader interface {
+	SchemaVersion(ctx context.Context) (version int, dirty bool, err error)
+}
+
+// SchemaVersionRepo reads the fern-reporter schema version recorded by
+// golang-migrate, the same version `fern-mycelium migrate version` reports.
+type SchemaVersionRepo struct {
+	db PgxQuerier
+}
+
+func NewSchemaVersionRepo(db PgxQuerier) *SchemaVersionRepo {
+	return &SchemaVersionRepo{db: db}
+}
+
+// SchemaVersion returns the applied migration version and whether the last
+// migration failed part way. Version is zero when no migration has run.
+func (r *SchemaVersionRepo) SchemaVersion(ctx context.Context) (int, bool, error) {
+	rows, err := timedQuery(ctx, r.db, "schema_version", `SELECT version, dirty FROM schema_migrations LIMIT 1;`)
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return 0, false, rows.Err()
+	}
+	var version int
+	var dirty bool
+	if err := rows.Scan(&version, &dirty); err != nil {
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
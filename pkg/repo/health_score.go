@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/internal/score"
+)
+
+//go:generate counterfeiter -o fakes/fake_health_score_provider.go . HealthScoreProvider
+type HealthScoreProvider interface {
+	GetProjectHealthScore(ctx context.Context, projectID string) (*gql.HealthScore, error)
+}
+
+// GetProjectHealthScore scores a project's specs over the last
+// DefaultSinceDays with score.Compute and the default weights. A spec is
+// flaky when it both passed and failed, and slow when its average duration
+// exceeds score.DefaultSlowTestThreshold. It returns nil when the project
+// has no runs in the window, as there is nothing to score.
+func (r *FlakyTestRepo) GetProjectHealthScore(ctx context.Context, projectID string) (*gql.HealthScore, error) {
+	query := `
+    WITH specs AS (
+        SELECT
+            COUNT(*) AS run_count,
+            COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($3)) AS failure_count,
+            AVG(EXTRACT(EPOCH FROM (spec_runs.end_time - spec_runs.start_time)) * 1000) AS avg_duration_ms
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectMatch + `
+          AND spec_runs.start_time >= NOW() - make_interval(days => $2)
+          AND NOT spec_runs.status = ANY($4)
+        GROUP BY spec_runs.spec_description
+    )
+    SELECT
+        COUNT(*) AS test_count,
+        COUNT(*) FILTER (WHERE failure_count > 0 AND failure_count < run_count) AS flaky_test_count,
+        COALESCE(AVG(failure_count::float8 / run_count), 0) AS average_failure_rate,
+        COUNT(*) FILTER (WHERE avg_duration_ms > $5) AS slow_test_count
+    FROM specs;
+	`
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "project_health_score", query, projectID, DefaultSinceDays,
+		r.successStatuses, r.ignoredStatuses, score.DefaultSlowTestThreshold.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var in score.Inputs
+	if rows.Next() {
+		if err := rows.Scan(&in.TestCount, &in.FlakyTestCount, &in.AverageFailureRate, &in.SlowTestCount); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if in.TestCount == 0 {
+		return nil, nil
+	}
+
+	return &gql.HealthScore{
+		ProjectID:          projectID,
+		Score:              score.Compute(in, score.DefaultWeights),
+		SinceDays:          DefaultSinceDays,
+		TestCount:          in.TestCount,
+		FlakyTestCount:     in.FlakyTestCount,
+		AverageFailureRate: in.AverageFailureRate,
+		SlowTestCount:      in.SlowTestCount,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("FlakyTestRepo.GetProjectHealthScore", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.HealthScoreProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("scores a healthy project near 100", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{120, 1, 0.01, 0}}}, nil)
+
+		result, err := repoInst.GetProjectHealthScore(ctx, "demo")
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(&gql.HealthScore{
+			ProjectID:          "demo",
+			Score:              99.2,
+			SinceDays:          repo.DefaultSinceDays,
+			TestCount:          120,
+			FlakyTestCount:     1,
+			AverageFailureRate: 0.01,
+			SlowTestCount:      0,
+		}))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("GROUP BY spec_runs.spec_description"))
+		Expect(sql).To(ContainSubstring("avg_duration_ms > $5"))
+		Expect(args).To(Equal([]any{"demo", repo.DefaultSinceDays,
+			repo.DefaultSuccessStatuses, repo.DefaultIgnoredStatuses, int64(10000)}))
+	})
+
+	It("scores a degraded project low", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{10, 5, 0.6, 4}}}, nil)
+
+		result, err := repoInst.GetProjectHealthScore(ctx, "demo")
+		Expect(err).To(BeNil())
+		// 100 - 40*0.5 - 45*0.6 - 15*0.4
+		Expect(result.Score).To(Equal(47.0))
+		Expect(result.FlakyTestCount).To(Equal(5))
+		Expect(result.SlowTestCount).To(Equal(4))
+	})
+
+	It("returns nil for a project without runs in the window", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{0, 0, 0.0, 0}}}, nil)
+
+		result, err := repoInst.GetProjectHealthScore(ctx, "unknown")
+		Expect(err).To(BeNil())
+		Expect(result).To(BeNil())
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		_, err := repoInst.GetProjectHealthScore(ctx, "demo")
+		Expect(err).To(MatchError("db down"))
+	})
+})
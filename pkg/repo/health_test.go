@@ -0,0 +1,53 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SchemaVersionRepo", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.SchemaVersionRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewSchemaVersionRepo(fakeDB)
+	})
+
+	It("reads the version and dirty flag from schema_migrations", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{12, true}}}, nil)
+
+		version, dirty, err := repoInst.SchemaVersion(ctx)
+		Expect(err).To(BeNil())
+		Expect(version).To(Equal(12))
+		Expect(dirty).To(BeTrue())
+
+		_, sql, _ := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("FROM schema_migrations"))
+	})
+
+	It("reports version zero before any migration has run", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		version, dirty, err := repoInst.SchemaVersion(ctx)
+		Expect(err).To(BeNil())
+		Expect(version).To(BeZero())
+		Expect(dirty).To(BeFalse())
+	})
+
+	It("returns the query error", func() {
+		fakeDB.QueryReturns(nil, errors.New("boom"))
+
+		_, _, err := repoInst.SchemaVersion(ctx)
+		Expect(err).To(MatchError("boom"))
+	})
+})
@@ -0,0 +1,116 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/jackc/pgx/v5"
+)
+
+// PgxBeginner starts transactions; *pgxpool.Pool satisfies it.
+type PgxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+//go:generate counterfeiter -o fakes/fake_ingest_provider.go . IngestProvider
+type IngestProvider interface {
+	IngestTestRun(ctx context.Context, input *gql.TestRunInput) (*gql.IngestResult, error)
+}
+
+type IngestRepo struct {
+	db PgxBeginner
+}
+
+func NewIngestRepo(db PgxBeginner) *IngestRepo {
+	return &IngestRepo{db: db}
+}
+
+// IngestTestRun writes a test run with its suite and spec runs in a single
+// transaction, so a failure part way through stores nothing. Times are
+// RFC 3339 strings. It returns ErrProjectNotFound when input.ProjectID
+// matches no project, and the created IDs in input order otherwise.
+func (r *IngestRepo) IngestTestRun(ctx context.Context, input *gql.TestRunInput) (*gql.IngestResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, classifyQueryError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:all
+
+	var testSeed int
+	if input.TestSeed != nil {
+		testSeed = *input.TestSeed
+	}
+
+	testRunID, err := insertReturningID(ctx, tx, "ingest_test_run", `
+    INSERT INTO test_runs (project_id, test_project_name, test_seed, start_time, end_time,
+        git_branch, git_sha, build_trigger_actor, build_url)
+    SELECT project_details.id, project_details.name, $2, $3::timestamptz, $4::timestamptz, $5, $6, $7, $8
+    FROM project_details
+    WHERE `+projectMatch+`
+    ORDER BY project_details.id
+    LIMIT 1
+    RETURNING id;
+	`, input.ProjectID, testSeed, input.StartTime, input.EndTime,
+		input.GitBranch, input.GitSha, input.BuildTriggerActor, input.BuildURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &gql.IngestResult{TestRunID: strconv.FormatInt(testRunID, 10), SuiteRunIDs: []string{}, SpecRunIDs: []string{}}
+	for _, suite := range input.Suites {
+		suiteID, err := insertReturningID(ctx, tx, "ingest_suite_run", `
+    INSERT INTO suite_runs (test_run_id, test_run_seed, suite_name, start_time, end_time)
+    VALUES ($1, $2, $3, $4::timestamptz, $5::timestamptz)
+    RETURNING id;
+		`, testRunID, testSeed, suite.SuiteName, suite.StartTime, suite.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		result.SuiteRunIDs = append(result.SuiteRunIDs, strconv.FormatInt(suiteID, 10))
+
+		for _, spec := range suite.Specs {
+			specID, err := insertReturningID(ctx, tx, "ingest_spec_run", `
+    INSERT INTO spec_runs (suite_id, spec_description, status, message, start_time, end_time)
+    VALUES ($1, $2, $3, COALESCE($4, ''), $5::timestamptz, $6::timestamptz)
+    RETURNING id;
+			`, suiteID, spec.SpecDescription, spec.Status, spec.Message, spec.StartTime, spec.EndTime)
+			if err != nil {
+				return nil, err
+			}
+			result.SpecRunIDs = append(result.SpecRunIDs, strconv.FormatInt(specID, 10))
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, classifyQueryError(err)
+	}
+	return result, nil
+}
+
+// insertReturningID runs an INSERT ... RETURNING id and reads the new id. It
+// returns pgx.ErrNoRows when nothing was inserted.
+func insertReturningID(ctx context.Context, db PgxQuerier, name, query string, args ...any) (int64, error) {
+	rows, err := timedQuery(ctx, db, name, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, classifyQueryError(err)
+		}
+		return 0, pgx.ErrNoRows
+	}
+	var id int64
+	if err := rows.Scan(&id); err != nil {
+		return 0, err
+	}
+	rows.Close()
+	return id, classifyQueryError(rows.Err())
+}
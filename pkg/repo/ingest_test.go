@@ -0,0 +1,133 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/jackc/pgx/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeTx records the statements run in a transaction, answering each with
+// the next of results.
+type fakeTx struct {
+	pgx.Tx
+	results    []pgx.Rows
+	errAt      int
+	queries    []string
+	args       [][]any
+	committed  bool
+	rolledBack bool
+}
+
+func (t *fakeTx) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	t.queries = append(t.queries, sql)
+	t.args = append(t.args, args)
+	if len(t.queries) == t.errAt {
+		return nil, errors.New("insert failed")
+	}
+	rows := t.results[0]
+	t.results = t.results[1:]
+	return rows, nil
+}
+
+func (t *fakeTx) Commit(context.Context) error {
+	t.committed = true
+	return nil
+}
+
+func (t *fakeTx) Rollback(context.Context) error {
+	if !t.committed {
+		t.rolledBack = true
+	}
+	return nil
+}
+
+type fakeBeginner struct {
+	tx  *fakeTx
+	err error
+}
+
+func (b *fakeBeginner) Begin(context.Context) (pgx.Tx, error) {
+	return b.tx, b.err
+}
+
+var _ = Describe("IngestRepo", func() {
+	var (
+		ctx      context.Context
+		tx       *fakeTx
+		repoInst *repo.IngestRepo
+		input    *gql.TestRunInput
+	)
+
+	idRow := func(id int64) *fakeRows { return &fakeRows{data: [][]any{{id}}} }
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tx = &fakeTx{results: []pgx.Rows{idRow(10), idRow(20), idRow(30), idRow(31)}}
+		repoInst = repo.NewIngestRepo(&fakeBeginner{tx: tx})
+		seed := 42
+		message := "timeout"
+		input = &gql.TestRunInput{
+			ProjectID: "demo",
+			TestSeed:  &seed,
+			StartTime: "2026-10-01T12:00:00Z",
+			EndTime:   "2026-10-01T12:05:00Z",
+			Suites: []*gql.SuiteRunInput{{
+				SuiteName: "Auth Suite",
+				StartTime: "2026-10-01T12:00:00Z",
+				EndTime:   "2026-10-01T12:05:00Z",
+				Specs: []*gql.SpecRunInput{
+					{SpecDescription: "login", Status: "passed", StartTime: "2026-10-01T12:00:00Z", EndTime: "2026-10-01T12:01:00Z"},
+					{SpecDescription: "logout", Status: "failed", Message: &message, StartTime: "2026-10-01T12:01:00Z", EndTime: "2026-10-01T12:02:00Z"},
+				},
+			}},
+		}
+	})
+
+	It("writes the run, suites and specs in one transaction and returns their IDs", func() {
+		result, err := repoInst.IngestTestRun(ctx, input)
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(&gql.IngestResult{TestRunID: "10", SuiteRunIDs: []string{"20"}, SpecRunIDs: []string{"30", "31"}}))
+		Expect(tx.committed).To(BeTrue())
+
+		Expect(tx.queries).To(HaveLen(4))
+		Expect(tx.queries[0]).To(ContainSubstring("INSERT INTO test_runs"))
+		Expect(tx.args[0][:4]).To(Equal([]any{"demo", 42, "2026-10-01T12:00:00Z", "2026-10-01T12:05:00Z"}))
+		Expect(tx.queries[1]).To(ContainSubstring("INSERT INTO suite_runs"))
+		Expect(tx.args[1]).To(Equal([]any{int64(10), 42, "Auth Suite", "2026-10-01T12:00:00Z", "2026-10-01T12:05:00Z"}))
+		Expect(tx.queries[3]).To(ContainSubstring("INSERT INTO spec_runs"))
+		Expect(tx.args[3][:4]).To(Equal([]any{int64(20), "logout", "failed", input.Suites[0].Specs[1].Message}))
+	})
+
+	It("returns ErrProjectNotFound and rolls back when the project is unknown", func() {
+		tx.results = []pgx.Rows{&fakeRows{}}
+
+		result, err := repoInst.IngestTestRun(ctx, input)
+		Expect(err).To(MatchError(repo.ErrProjectNotFound))
+		Expect(errors.Is(err, repo.ErrNotFound)).To(BeTrue())
+		Expect(result).To(BeNil())
+		Expect(tx.committed).To(BeFalse())
+		Expect(tx.rolledBack).To(BeTrue())
+	})
+
+	It("rolls back when a later insert fails", func() {
+		tx.errAt = 3
+
+		result, err := repoInst.IngestTestRun(ctx, input)
+		Expect(err).To(MatchError("insert failed"))
+		Expect(result).To(BeNil())
+		Expect(tx.committed).To(BeFalse())
+		Expect(tx.rolledBack).To(BeTrue())
+	})
+
+	It("returns the error when a transaction cannot be started", func() {
+		repoInst = repo.NewIngestRepo(&fakeBeginner{err: errors.New("pool closed")})
+
+		_, err := repoInst.IngestTestRun(ctx, input)
+		Expect(err).To(MatchError("pool closed"))
+	})
+})
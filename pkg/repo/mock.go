@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// MockFlakyTestRepo is a FlakyTestProvider serving a fixed sample set, for
+// demos and the playground without a database. Every project returns the
+// same tests, ordered by failure rate; MinRuns, MinFailureRate, Offset and
+// the limit apply, while the suite, lookback and sort options are ignored.
+type MockFlakyTestRepo struct{}
+
+// NewMockFlakyTestRepo returns a provider of MockFlakyTests.
+func NewMockFlakyTestRepo() *MockFlakyTestRepo {
+	return &MockFlakyTestRepo{}
+}
+
+// MockFlakyTests returns the sample tests served by MockFlakyTestRepo, most
+// failing first. Each call returns fresh copies.
+func MockFlakyTests() []*gql.FlakyTest {
+	lastFailure := func(s string) *string { return &s }
+	return []*gql.FlakyTest{
+		{
+			TestID:         "LoginService handles expired tokens",
+			TestName:       "LoginService handles expired tokens",
+			PassRate:       0.72,
+			FailureRate:    0.28,
+			FlakinessScore: 0.45,
+			LastFailure:    lastFailure("2025-03-30T18:44:10Z"),
+			RunCount:       50,
+		},
+		{
+			TestID:         "CheckoutFlow retries declined payments",
+			TestName:       "CheckoutFlow retries declined payments",
+			PassRate:       0.8,
+			FailureRate:    0.2,
+			FlakinessScore: 0.35,
+			LastFailure:    lastFailure("2025-03-29T09:12:45Z"),
+			RunCount:       40,
+		},
+		{
+			TestID:         "SearchIndex refreshes after bulk import",
+			TestName:       "SearchIndex refreshes after bulk import",
+			PassRate:       0.9,
+			FailureRate:    0.1,
+			FlakinessScore: 0.18,
+			LastFailure:    lastFailure("2025-03-27T22:03:31Z"),
+			RunCount:       30,
+		},
+		{
+			TestID:         "ReportExporter streams large CSV files",
+			TestName:       "ReportExporter streams large CSV files",
+			PassRate:       0.95,
+			FailureRate:    0.05,
+			FlakinessScore: 0.1,
+			LastFailure:    lastFailure("2025-03-21T14:27:08Z"),
+			RunCount:       20,
+		},
+	}
+}
+
+// GetFlakyTests returns the sample tests for any project.
+func (r *MockFlakyTestRepo) GetFlakyTests(ctx context.Context, projectID string, limit int, opts FlakyTestOptions) ([]*gql.FlakyTest, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	results := []*gql.FlakyTest{}
+	for _, test := range MockFlakyTests() {
+		if test.RunCount >= opts.MinRuns && test.FailureRate >= opts.MinFailureRate {
+			results = append(results, test)
+		}
+	}
+	results = results[min(opts.Offset, len(results)):]
+	return results[:min(limit, len(results))], nil
+}
+
+// CountFlakyTests returns how many sample tests pass the MinRuns and
+// MinFailureRate filters.
+func (r *MockFlakyTestRepo) CountFlakyTests(ctx context.Context, projectID string, opts FlakyTestOptions) (int, error) {
+	tests, err := r.GetFlakyTests(ctx, projectID, MaxLimit, FlakyTestOptions{MinRuns: opts.MinRuns, MinFailureRate: opts.MinFailureRate})
+	return len(tests), err
+}
@@ -0,0 +1,50 @@
+package repo_test
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MockFlakyTestRepo", func() {
+	var mock *repo.MockFlakyTestRepo
+
+	BeforeEach(func() {
+		mock = repo.NewMockFlakyTestRepo()
+	})
+
+	It("should return the same sample tests for any project, most failing first", func() {
+		tests, err := mock.GetFlakyTests(context.Background(), "anything", 10, repo.FlakyTestOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tests).To(Equal(repo.MockFlakyTests()))
+		Expect(tests).To(HaveLen(4))
+		for i := 1; i < len(tests); i++ {
+			Expect(tests[i].FailureRate).To(BeNumerically("<", tests[i-1].FailureRate))
+		}
+	})
+
+	It("should apply the limit, offset and minimums", func() {
+		tests, err := mock.GetFlakyTests(context.Background(), "demo", 2, repo.FlakyTestOptions{Offset: 1})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tests).To(HaveLen(2))
+		Expect(tests[0].TestName).To(Equal("CheckoutFlow retries declined payments"))
+
+		tests, err = mock.GetFlakyTests(context.Background(), "demo", 10, repo.FlakyTestOptions{MinRuns: 30, MinFailureRate: 0.15})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tests).To(HaveLen(2))
+
+		tests, err = mock.GetFlakyTests(context.Background(), "demo", 10, repo.FlakyTestOptions{Offset: 10})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tests).To(BeEmpty())
+	})
+
+	It("should validate its arguments like the database repositories", func() {
+		_, err := mock.GetFlakyTests(context.Background(), "demo", 0, repo.FlakyTestOptions{})
+		Expect(err).To(MatchError(repo.ErrInvalidArgument))
+
+		_, err = mock.GetFlakyTests(context.Background(), "demo", 5, repo.FlakyTestOptions{Offset: -1})
+		Expect(err).To(MatchError(repo.ErrInvalidArgument))
+	})
+})
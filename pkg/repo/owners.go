@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Owners assigns teams to specs from a CODEOWNERS-style file. Each line holds
+// a pattern followed by the owning team, separated by whitespace:
+//
+//	# Comments and blank lines are ignored.
+//	*                       team-platform
+//	Auth Suite              team-identity
+//	LoginService handles *  team-login
+//
+// Patterns match a whole spec or suite name, with * standing for any run of
+// characters and ? for a single one. As names may contain spaces, the team is
+// the last field of the line and everything before it is the pattern. Like
+// CODEOWNERS, the last matching line wins, so specific rules go below
+// general ones.
+type Owners struct {
+	rules []ownerRule
+}
+
+type ownerRule struct {
+	pattern string
+	team    string
+	match   *regexp.Regexp
+}
+
+// LoadOwners reads the owners file at path. It returns nil, and no error,
+// when path is empty.
+func LoadOwners(path string) (*Owners, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open owners file: %w", err)
+	}
+	defer f.Close() //nolint:all
+
+	owners, err := ParseOwners(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return owners, nil
+}
+
+// ParseOwners reads owner rules in the format described on Owners.
+func ParseOwners(r io.Reader) (*Owners, error) {
+	owners := &Owners{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		split := strings.LastIndexAny(text, " \t")
+		if split < 0 {
+			return nil, fmt.Errorf("line %d: expected a pattern followed by a team, got %q", line, text)
+		}
+		pattern, team := strings.TrimSpace(text[:split]), text[split+1:]
+		owners.rules = append(owners.rules, ownerRule{pattern: pattern, team: team, match: globRegexp(pattern)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// Match returns the team and pattern of the last rule matching any of names.
+// A nil Owners matches nothing.
+func (o *Owners) Match(names ...string) (team, pattern string, ok bool) {
+	if o == nil {
+		return "", "", false
+	}
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		rule := o.rules[i]
+		for _, name := range names {
+			if rule.match.MatchString(name) {
+				return rule.team, rule.pattern, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// globRegexp compiles a pattern with * and ? wildcards into an anchored
+// regular expression. Unlike path.Match, * also crosses slashes, which spec
+// names often contain.
+func globRegexp(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
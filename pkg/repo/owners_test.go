@@ -0,0 +1,93 @@
+package repo_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+)
+
+var _ = Describe("Owners", func() {
+	const file = `
+# Everything else belongs to the platform team.
+*                          team-platform
+
+Auth Suite                 team-identity
+LoginService handles *     team-login
+Paging spec ?	           team-paging
+api/v1 [retries] (slow)    team-api
+`
+
+	var owners *repo.Owners
+
+	BeforeEach(func() {
+		var err error
+		owners, err = repo.ParseOwners(strings.NewReader(file))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("assigns names to the last matching rule",
+		func(names []string, team, pattern string) {
+			gotTeam, gotPattern, ok := owners.Match(names...)
+			Expect(ok).To(BeTrue())
+			Expect(gotTeam).To(Equal(team))
+			Expect(gotPattern).To(Equal(pattern))
+		},
+		Entry("a catch-all", []string{"Invoice rounds totals"}, "team-platform", "*"),
+		Entry("a suite", []string{"Token refresh", "Auth Suite"}, "team-identity", "Auth Suite"),
+		Entry("a wildcard spec name", []string{"LoginService handles expired tokens", "Auth Suite"}, "team-login", "LoginService handles *"),
+		Entry("a single-character wildcard", []string{"Paging spec B"}, "team-paging", "Paging spec ?"),
+		Entry("regexp characters taken literally", []string{"api/v1 [retries] (slow)"}, "team-api", "api/v1 [retries] (slow)"),
+	)
+
+	It("matches whole names only", func() {
+		owners, err := repo.ParseOwners(strings.NewReader("Auth Suite team-identity\nPaging spec ? team-paging"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, ok := owners.Match("Auth Suite extended", "Legacy Auth Suite", "Paging spec AB")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("matches nothing when there is no owners file", func() {
+		var none *repo.Owners
+		_, _, ok := none.Match("Auth Suite")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("rejects a line without a team", func() {
+		_, err := repo.ParseOwners(strings.NewReader("# owners\nAuthSuite\n"))
+		Expect(err).To(MatchError(`line 2: expected a pattern followed by a team, got "AuthSuite"`))
+	})
+
+	Describe("LoadOwners", func() {
+		It("returns no owners for an empty path", func() {
+			Expect(repo.LoadOwners("")).To(BeNil())
+		})
+
+		It("reads the rules of a file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "OWNERS")
+			Expect(os.WriteFile(path, []byte("Auth Suite team-identity\n"), 0o600)).To(Succeed())
+
+			owners, err := repo.LoadOwners(path)
+			Expect(err).NotTo(HaveOccurred())
+			team, _, ok := owners.Match("Auth Suite")
+			Expect(ok).To(BeTrue())
+			Expect(team).To(Equal("team-identity"))
+		})
+
+		It("names the file in its errors", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "OWNERS")
+			Expect(os.WriteFile(path, []byte("orphan\n"), 0o600)).To(Succeed())
+
+			_, err := repo.LoadOwners(path)
+			Expect(err).To(MatchError(path + `: line 1: expected a pattern followed by a team, got "orphan"`))
+
+			_, err = repo.LoadOwners(filepath.Join(GinkgoT().TempDir(), "missing"))
+			Expect(err).To(MatchError(ContainSubstring("failed to open owners file")))
+		})
+	})
+})
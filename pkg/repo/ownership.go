@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_ownership_provider.go . OwnershipProvider
+type OwnershipProvider interface {
+	GetTestOwner(ctx context.Context, projectID, testName string) (*gql.TestOwner, error)
+}
+
+type OwnershipRepo struct {
+	db     PgxQuerier
+	owners *Owners
+}
+
+// NewOwnershipRepo returns a repo that looks owners up in owners before
+// falling back to the team of the project. owners may be nil.
+func NewOwnershipRepo(db PgxQuerier, owners *Owners) *OwnershipRepo {
+	return &OwnershipRepo{db: db, owners: owners}
+}
+
+// GetTestOwner returns the team owning a spec: the team of the last owners
+// file rule matching the spec or one of the suites it ran in, and otherwise
+// the team_name of its project. An unknown project, or one without a team,
+// is not an error; the owner then has no team and source UNKNOWN, so alerts
+// can still be sent to a default route.
+func (r *OwnershipRepo) GetTestOwner(ctx context.Context, projectID, testName string) (*gql.TestOwner, error) {
+	query := `
+    SELECT
+        project_details.team_name,
+        ARRAY(
+            SELECT DISTINCT suite_runs.suite_name
+            FROM spec_runs
+            JOIN suite_runs ON spec_runs.suite_id = suite_runs.id
+            JOIN test_runs ON suite_runs.test_run_id = test_runs.id
+            WHERE test_runs.project_id = project_details.id
+              AND spec_runs.spec_description = $2
+              AND suite_runs.suite_name IS NOT NULL
+            ORDER BY suite_runs.suite_name
+        ) AS suite_names
+    FROM project_details
+    WHERE ` + projectMatch + `
+    ORDER BY project_details.id
+    LIMIT 1;
+	`
+	rows, err := timedQuery(ctx, r.db, "test_owner", query, projectID, testName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projectTeam *string
+	var suites []string
+	if rows.Next() {
+		if err := rows.Scan(&projectTeam, &suites); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	owner := &gql.TestOwner{ProjectID: projectID, TestName: testName, Source: gql.OwnershipSourceUnknown}
+	if team, pattern, ok := r.owners.Match(append([]string{testName}, suites...)...); ok {
+		owner.Team, owner.Rule, owner.Source = &team, &pattern, gql.OwnershipSourceOwnersFile
+	} else if projectTeam != nil && *projectTeam != "" {
+		owner.Team, owner.Source = projectTeam, gql.OwnershipSourceProject
+	}
+	return owner, nil
+}
@@ -0,0 +1,97 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("OwnershipRepo", func() {
+	var (
+		ctx    context.Context
+		fakeDB *fakes.FakePgxQuerier
+		owners *repo.Owners
+	)
+
+	ptr := func(s string) *string { return &s }
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		var err error
+		owners, err = repo.ParseOwners(strings.NewReader("Auth Suite team-identity\nLoginService handles * team-login\n"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("falls back to the team of the project", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{"team-a", []string{"Billing Suite"}}}}, nil)
+
+		owner, err := repo.NewOwnershipRepo(fakeDB, owners).GetTestOwner(ctx, "demo", "Invoice rounds totals")
+		Expect(err).To(BeNil())
+		Expect(owner).To(Equal(&gql.TestOwner{
+			ProjectID: "demo", TestName: "Invoice rounds totals", Team: ptr("team-a"), Source: gql.OwnershipSourceProject,
+		}))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("WHERE (project_details.name = $1 OR project_details.uuid::text = $1)"))
+		Expect(sql).To(ContainSubstring("spec_runs.spec_description = $2"))
+		Expect(args).To(Equal([]any{"demo", "Invoice rounds totals"}))
+	})
+
+	It("prefers an owners file rule matching one of the spec's suites", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{"team-a", []string{"Auth Suite", "Smoke Suite"}}}}, nil)
+
+		owner, err := repo.NewOwnershipRepo(fakeDB, owners).GetTestOwner(ctx, "demo", "Token refresh")
+		Expect(err).To(BeNil())
+		Expect(owner).To(Equal(&gql.TestOwner{
+			ProjectID: "demo", TestName: "Token refresh", Team: ptr("team-identity"), Source: gql.OwnershipSourceOwnersFile,
+			Rule: ptr("Auth Suite"),
+		}))
+	})
+
+	It("lets the last matching rule win", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{"team-a", []string{"Auth Suite"}}}}, nil)
+
+		owner, err := repo.NewOwnershipRepo(fakeDB, owners).GetTestOwner(ctx, "demo", "LoginService handles expired tokens")
+		Expect(err).To(BeNil())
+		Expect(owner.Team).To(Equal(ptr("team-login")))
+		Expect(owner.Rule).To(Equal(ptr("LoginService handles *")))
+	})
+
+	DescribeTable("reports an unknown owner without failing",
+		func(rows [][]any) {
+			fakeDB.QueryReturns(&fakeRows{data: rows}, nil)
+
+			owner, err := repo.NewOwnershipRepo(fakeDB, nil).GetTestOwner(ctx, "demo", "Token refresh")
+			Expect(err).To(BeNil())
+			Expect(owner).To(Equal(&gql.TestOwner{ProjectID: "demo", TestName: "Token refresh", Source: gql.OwnershipSourceUnknown}))
+		},
+		Entry("for an unknown project", nil),
+		Entry("for a project without a team", [][]any{{nil, []string{"Auth Suite"}}}),
+		Entry("for a project with a blank team", [][]any{{"", []string{}}}),
+	)
+
+	It("still applies the owners file to an unknown project", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		owner, err := repo.NewOwnershipRepo(fakeDB, owners).GetTestOwner(ctx, "nope", "LoginService handles expired tokens")
+		Expect(err).To(BeNil())
+		Expect(owner.Source).To(Equal(gql.OwnershipSourceOwnersFile))
+		Expect(owner.Team).To(Equal(ptr("team-login")))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		owner, err := repo.NewOwnershipRepo(fakeDB, owners).GetTestOwner(ctx, "demo", "Token refresh")
+		Expect(err).To(MatchError("db down"))
+		Expect(owner).To(BeNil())
+	})
+})
@@ -0,0 +1,52 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_project_provider.go . ProjectProvider
+type ProjectProvider interface {
+	ListProjects(ctx context.Context, teamName string) ([]*gql.Project, error)
+}
+
+type ProjectRepo struct {
+	db PgxQuerier
+}
+
+func NewProjectRepo(db PgxQuerier) *ProjectRepo {
+	return &ProjectRepo{db: db}
+}
+
+// ListProjects returns every known project ordered by name, restricted to a
+// single team when teamName is non-empty. Project IDs are the UUIDs accepted
+// by the other queries' projectID arguments.
+func (r *ProjectRepo) ListProjects(ctx context.Context, teamName string) ([]*gql.Project, error) {
+	query := `
+    SELECT uuid::text, name, team_name
+    FROM project_details
+    WHERE ($1 = '' OR team_name = $1)
+    ORDER BY name, id;
+	`
+	rows, err := timedQuery(ctx, r.db, "list_projects", query, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*gql.Project
+
+	for rows.Next() {
+		project := &gql.Project{}
+		if err := rows.Scan(&project.ID, &project.Name, &project.TeamName); err != nil {
+			return nil, err
+		}
+		results = append(results, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,73 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("ProjectRepo", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.ProjectProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewProjectRepo(fakeDB)
+	})
+
+	It("returns projects from fake rows", func() {
+		team := "team-a"
+		mockRows := &fakeRows{
+			data: [][]any{
+				{"0b8d6f1e-0000-5000-8000-000000000001", "billing", team},
+				{"0b8d6f1e-0000-5000-8000-000000000002", "demo", nil},
+			},
+		}
+
+		fakeDB.QueryReturns(mockRows, nil)
+
+		results, err := repoInst.ListProjects(ctx, "")
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal([]*gql.Project{
+			{ID: "0b8d6f1e-0000-5000-8000-000000000001", Name: "billing", TeamName: &team},
+			{ID: "0b8d6f1e-0000-5000-8000-000000000002", Name: "demo"},
+		}))
+	})
+
+	It("passes the team filter as a bound parameter", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.ListProjects(ctx, "team-b")
+		Expect(err).To(BeNil())
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("($1 = '' OR team_name = $1)"))
+		Expect(args).To(Equal([]any{"team-b"}))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		results, err := repoInst.ListProjects(ctx, "")
+		Expect(err).To(MatchError("db down"))
+		Expect(results).To(BeNil())
+	})
+
+	It("propagates errors raised while reading rows", func() {
+		fakeDB.QueryReturns(&fakeRows{err: errors.New("connection reset")}, nil)
+
+		results, err := repoInst.ListProjects(ctx, "")
+		Expect(err).To(MatchError("connection reset"))
+		Expect(results).To(BeNil())
+	})
+})
@@ -0,0 +1,200 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrProjectNotFound is returned when a projectID matches no project.
+var ErrProjectNotFound error = &kindError{kind: ErrNotFound, msg: "project not found"}
+
+// ErrAlreadyQuarantined is returned when a test already has an active quarantine.
+var ErrAlreadyQuarantined = errors.New("test is already quarantined")
+
+// PgxQueryBeginner runs queries and starts transactions; *pgxpool.Pool and
+// TenantRouter satisfy it.
+type PgxQueryBeginner interface {
+	PgxQuerier
+	PgxBeginner
+}
+
+//go:generate counterfeiter -o fakes/fake_quarantine_provider.go . QuarantineProvider
+type QuarantineProvider interface {
+	QuarantineTest(ctx context.Context, projectID, testName string, reason *string) (*gql.QuarantineResult, error)
+	ListQuarantinedTests(ctx context.Context, projectID string, includeInactive bool) ([]*gql.QuarantinedTest, error)
+}
+
+type QuarantineRepo struct {
+	db PgxQueryBeginner
+}
+
+func NewQuarantineRepo(db PgxQueryBeginner) *QuarantineRepo {
+	return &QuarantineRepo{db: db}
+}
+
+// activeQuarantine matches quarantines that have been neither lifted nor
+// reached their expiry.
+const activeQuarantine = `test_quarantines.lifted_at IS NULL
+      AND (test_quarantines.expires_at IS NULL OR test_quarantines.expires_at > NOW())`
+
+// QuarantineTest records a new quarantine for a test in the given project.
+// It returns ErrProjectNotFound when projectID matches no project, and
+// ErrAlreadyQuarantined when the test already has an active quarantine. The
+// quarantine is attributed to the actor of ctx, set with WithActor.
+//
+// The check and the insert run in one transaction holding an advisory lock
+// on the project and test, so concurrent calls for the same test cannot both
+// find no active quarantine and both insert one.
+func (r *QuarantineRepo) QuarantineTest(ctx context.Context, projectID, testName string, reason *string) (*gql.QuarantineResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, classifyQueryError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:all
+
+	projectKey, projectUUID, err := lockQuarantine(ctx, tx, projectID, testName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := timedQuery(ctx, tx, "quarantine_test", `
+    INSERT INTO test_quarantines (project_id, test_name, reason, quarantined_by)
+    SELECT $1, $2, $3, NULLIF($5, '')
+    WHERE NOT EXISTS (
+        SELECT 1 FROM test_quarantines
+        WHERE test_quarantines.project_id = $1
+          AND test_quarantines.test_name = $2
+          AND `+activeQuarantine+`
+    )
+    RETURNING id, $4::text, test_name, reason, quarantined_at;
+	`, projectKey, testName, reason, projectUUID, ActorFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, classifyQueryError(err)
+		}
+		return nil, fmt.Errorf("%w: %q", ErrAlreadyQuarantined, testName)
+	}
+	result, err := scanQuarantineResult(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, classifyQueryError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, classifyQueryError(err)
+	}
+	return result, nil
+}
+
+// lockQuarantine resolves projectID and takes a transaction-scoped advisory
+// lock on the project and test, returning the project's id and uuid.
+func lockQuarantine(ctx context.Context, tx PgxQuerier, projectID, testName string) (int32, string, error) {
+	rows, err := timedQuery(ctx, tx, "quarantine_lock", `
+    WITH project AS (
+        SELECT project_details.id, project_details.uuid::text AS uuid
+        FROM project_details
+        WHERE `+projectMatch+`
+        ORDER BY project_details.id
+        LIMIT 1
+    ), locked AS (
+        SELECT pg_advisory_xact_lock(project.id, hashtext($2)) FROM project
+    )
+    SELECT project.id, project.uuid FROM project, locked;
+	`, projectID, testName)
+	if err != nil {
+		return 0, "", err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, "", classifyQueryError(err)
+		}
+		return 0, "", ErrProjectNotFound
+	}
+	var id int32
+	var uuid string
+	if err := rows.Scan(&id, &uuid); err != nil {
+		return 0, "", err
+	}
+	rows.Close()
+	return id, uuid, classifyQueryError(rows.Err())
+}
+
+// ListQuarantinedTests returns a project's quarantines, newest first. Only
+// active quarantines are returned unless includeInactive is set, in which case
+// lifted and expired ones are included too.
+func (r *QuarantineRepo) ListQuarantinedTests(ctx context.Context, projectID string, includeInactive bool) ([]*gql.QuarantinedTest, error) {
+	query := `
+    SELECT
+        test_quarantines.test_name,
+        test_quarantines.reason,
+        test_quarantines.quarantined_at,
+        test_quarantines.quarantined_by,
+        test_quarantines.expires_at,
+        test_quarantines.lifted_at,
+        (` + activeQuarantine + `) AS active
+    FROM test_quarantines
+    JOIN project_details ON test_quarantines.project_id = project_details.id
+    WHERE ` + projectMatch + `
+      AND ($2 OR (` + activeQuarantine + `))
+    ORDER BY test_quarantines.quarantined_at DESC, test_quarantines.id DESC;
+	`
+	rows, err := timedQuery(ctx, r.db, "quarantined_tests", query, projectID, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*gql.QuarantinedTest
+
+	for rows.Next() {
+		var quarantinedAt time.Time
+		var expiresAt, liftedAt *time.Time
+		test := &gql.QuarantinedTest{}
+
+		if err := rows.Scan(&test.TestName, &test.Reason, &quarantinedAt, &test.QuarantinedBy,
+			&expiresAt, &liftedAt, &test.Active); err != nil {
+			return nil, err
+		}
+
+		test.QuarantinedAt = quarantinedAt.Format(time.RFC3339)
+		test.ExpiresAt = formatTime(expiresAt)
+		test.LiftedAt = formatTime(liftedAt)
+		results = append(results, test)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// scanQuarantineResult reads a row of (id, project_uuid, test_name, reason, quarantined_at).
+func scanQuarantineResult(rows pgx.Rows) (*gql.QuarantineResult, error) {
+	var id int64
+	var quarantinedAt time.Time
+	result := &gql.QuarantineResult{}
+
+	if err := rows.Scan(&id, &result.ProjectID, &result.TestName, &result.Reason, &quarantinedAt); err != nil {
+		return nil, err
+	}
+
+	result.ID = strconv.FormatInt(id, 10)
+	result.QuarantinedAt = quarantinedAt.Format(time.RFC3339)
+	return result, nil
+}
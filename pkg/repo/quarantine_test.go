@@ -0,0 +1,165 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	"github.com/jackc/pgx/v5"
+)
+
+// quarantineDB answers queries with a FakePgxQuerier and starts transactions
+// with a fakeBeginner.
+type quarantineDB struct {
+	*fakes.FakePgxQuerier
+	*fakeBeginner
+}
+
+var _ = Describe("QuarantineRepo", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		tx       *fakeTx
+		beginner *fakeBeginner
+		repoInst repo.QuarantineProvider
+		at       time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		tx = &fakeTx{}
+		beginner = &fakeBeginner{tx: tx}
+		repoInst = repo.NewQuarantineRepo(quarantineDB{fakeDB, beginner})
+		at = time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	})
+
+	It("locks the test and inserts the quarantine in one transaction", func() {
+		reason := "fails on slow runners"
+		tx.results = []pgx.Rows{
+			&fakeRows{data: [][]any{{int32(3), "uuid-1"}}},
+			&fakeRows{data: [][]any{{int64(7), "uuid-1", "login", reason, at}}},
+		}
+
+		result, err := repoInst.QuarantineTest(ctx, "demo", "login", &reason)
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(&gql.QuarantineResult{
+			ID: "7", ProjectID: "uuid-1", TestName: "login", Reason: &reason, QuarantinedAt: "2025-04-01T10:00:00Z",
+		}))
+		Expect(tx.committed).To(BeTrue())
+
+		Expect(tx.queries).To(HaveLen(2))
+		Expect(tx.queries[0]).To(ContainSubstring("pg_advisory_xact_lock(project.id, hashtext($2))"))
+		Expect(tx.args[0]).To(Equal([]any{"demo", "login"}))
+		Expect(tx.queries[1]).To(ContainSubstring("INSERT INTO test_quarantines (project_id, test_name, reason, quarantined_by)"))
+		Expect(tx.queries[1]).To(ContainSubstring("WHERE NOT EXISTS"))
+		Expect(tx.queries[1]).To(ContainSubstring("test_quarantines.lifted_at IS NULL"))
+		Expect(tx.args[1]).To(Equal([]any{int32(3), "login", &reason, "uuid-1", ""}))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("reports an active quarantine when nothing was inserted", func() {
+		tx.results = []pgx.Rows{&fakeRows{data: [][]any{{int32(3), "uuid-1"}}}, &fakeRows{}}
+
+		result, err := repoInst.QuarantineTest(ctx, "demo", "login", nil)
+		Expect(err).To(MatchError(repo.ErrAlreadyQuarantined))
+		Expect(result).To(BeNil())
+		Expect(tx.committed).To(BeFalse())
+		Expect(tx.rolledBack).To(BeTrue())
+	})
+
+	It("reports an unknown project", func() {
+		tx.results = []pgx.Rows{&fakeRows{}}
+
+		result, err := repoInst.QuarantineTest(ctx, "missing", "login", nil)
+		Expect(err).To(MatchError(repo.ErrProjectNotFound))
+		Expect(result).To(BeNil())
+		Expect(tx.queries).To(HaveLen(1))
+		Expect(tx.rolledBack).To(BeTrue())
+	})
+
+	It("classifies a failure to begin the transaction", func() {
+		beginner.err = context.DeadlineExceeded
+
+		_, err := repoInst.QuarantineTest(ctx, "demo", "login", nil)
+		Expect(err).To(MatchError(repo.ErrUnavailable))
+	})
+
+	It("attributes the quarantine to the actor of the context", func() {
+		tx.results = []pgx.Rows{
+			&fakeRows{data: [][]any{{int32(3), "uuid-1"}}},
+			&fakeRows{data: [][]any{{int64(7), "uuid-1", "login", nil, at}}},
+		}
+
+		_, err := repoInst.QuarantineTest(repo.WithActor(ctx, "api-key:0123abcd"), "demo", "login", nil)
+		Expect(err).To(BeNil())
+		Expect(tx.queries[1]).To(ContainSubstring("NULLIF($5, '')"))
+		Expect(tx.args[1]).To(Equal([]any{int32(3), "login", (*string)(nil), "uuid-1", "api-key:0123abcd"}))
+	})
+})
+
+var _ = Describe("QuarantineRepo listing", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.QuarantineProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewQuarantineRepo(quarantineDB{fakeDB, &fakeBeginner{}})
+	})
+
+	It("lists active quarantines only by default", func() {
+		at := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{
+			{"login", "intermittent timeout", at, "alice", nil, nil, true},
+		}}, nil)
+
+		results, err := repoInst.ListQuarantinedTests(ctx, "demo", false)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].TestName).To(Equal("login"))
+		Expect(results[0].QuarantinedAt).To(Equal("2025-04-01T10:00:00Z"))
+		Expect(results[0].QuarantinedBy).To(HaveValue(Equal("alice")))
+		Expect(results[0].Active).To(BeTrue())
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("AND ($2 OR (test_quarantines.lifted_at IS NULL"))
+		Expect(args).To(Equal([]any{"demo", false}))
+	})
+
+	It("includes lifted and expired quarantines when asked", func() {
+		at := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{
+			{"login", nil, at, nil, nil, nil, true},
+			{"logout", "fixed", at.Add(-48 * time.Hour), "bob", nil, at.Add(-time.Hour), false},
+			{"signup", nil, at.Add(-72 * time.Hour), nil, at.Add(-24 * time.Hour), nil, false},
+		}}, nil)
+
+		results, err := repoInst.ListQuarantinedTests(ctx, "demo", true)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(3))
+		Expect(results[1].Active).To(BeFalse())
+		Expect(results[1].LiftedAt).To(HaveValue(Equal("2025-04-01T09:00:00Z")))
+		Expect(results[2].ExpiresAt).To(HaveValue(Equal("2025-03-31T10:00:00Z")))
+
+		_, _, args := fakeDB.QueryArgsForCall(0)
+		Expect(args).To(Equal([]any{"demo", true}))
+	})
+
+	It("propagates errors raised while reading rows", func() {
+		fakeDB.QueryReturns(&fakeRows{err: errors.New("connection reset")}, nil)
+
+		results, err := repoInst.ListQuarantinedTests(ctx, "demo", false)
+		Expect(err).To(MatchError("connection reset"))
+		Expect(results).To(BeNil())
+	})
+})
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// timedQuery runs sql against db and logs how long it took at debug level.
+// The time is also added to the QueryStats carried by ctx, if any.
+// Connection failures are returned as ErrUnavailable.
+func timedQuery(ctx context.Context, db PgxQuerier, name, sql string, args ...any) (pgx.Rows, error) {
+	start := time.Now()
+	rows, err := db.Query(ctx, sql, args...)
+	elapsed := time.Since(start)
+	slog.DebugContext(ctx, "sql query", "query", name, "duration", elapsed, "error", err)
+	if stats, ok := ctx.Value(queryStatsKey{}).(*QueryStats); ok {
+		stats.add(elapsed)
+	}
+	return rows, classifyQueryError(err)
+}
+
+// QueryStats totals the database queries issued on behalf of one request.
+// It is safe for concurrent use, as resolvers run in parallel.
+type QueryStats struct {
+	mu       sync.Mutex
+	count    int
+	duration time.Duration
+}
+
+type queryStatsKey struct{}
+
+// WithQueryStats returns a context whose repository queries are recorded
+// in the returned QueryStats.
+func WithQueryStats(ctx context.Context) (context.Context, *QueryStats) {
+	stats := &QueryStats{}
+	return context.WithValue(ctx, queryStatsKey{}, stats), stats
+}
+
+func (s *QueryStats) add(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	s.duration += d
+}
+
+// Totals returns the number of queries recorded and their combined duration.
+func (s *QueryStats) Totals() (int, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count, s.duration
+}
+
+// queryLogger configures the statement logging enabled by WithQueryLogging.
+type queryLogger struct {
+	slow time.Duration
+}
+
+// wrap returns db with its queries logged.
+func (l *queryLogger) wrap(db PgxQuerier) PgxQuerier {
+	return &loggingQuerier{PgxQuerier: db, slow: l.slow}
+}
+
+// loggingQuerier logs the statement, redacted arguments and duration of
+// every query it runs.
+type loggingQuerier struct {
+	PgxQuerier
+	slow time.Duration
+}
+
+func (q *loggingQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	start := time.Now()
+	rows, err := q.PgxQuerier.Query(ctx, sql, args...)
+	elapsed := time.Since(start)
+
+	level, msg := slog.LevelDebug, "sql statement"
+	if q.slow > 0 && elapsed > q.slow {
+		level, msg = slog.LevelWarn, "slow sql query"
+	}
+	slog.Log(ctx, level, msg,
+		"statement", strings.Join(strings.Fields(sql), " "),
+		"args", redactArgs(args),
+		"duration", elapsed,
+		"threshold", q.slow,
+		"error", err)
+	return rows, err
+}
+
+// redactArgs describes query arguments by type only, so project names and
+// other values from requests never reach the logs.
+func redactArgs(args []any) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = fmt.Sprintf("$%d=<%T>", i+1, arg)
+	}
+	return redacted
+}
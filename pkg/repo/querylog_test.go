@@ -0,0 +1,77 @@
+package repo_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	"github.com/jackc/pgx/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakyTestRepo query logging", func() {
+	var (
+		ctx    context.Context
+		fakeDB *fakes.FakePgxQuerier
+		logs   *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		fakeDB.QueryStub = func(context.Context, string, ...any) (pgx.Rows, error) {
+			time.Sleep(20 * time.Millisecond)
+			return &fakeRows{}, nil
+		}
+
+		logs = &bytes.Buffer{}
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+		DeferCleanup(func() { slog.SetDefault(previous) })
+	})
+
+	It("warns about queries slower than the threshold", func() {
+		repoInst := repo.NewFlakyTestRepo(fakeDB, repo.WithQueryLogging(5*time.Millisecond))
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(logs.String()).To(ContainSubstring(`level=WARN msg="slow sql query"`))
+		Expect(logs.String()).To(ContainSubstring(`statement="SELECT spec_runs.spec_description AS test_name,`))
+		Expect(logs.String()).To(ContainSubstring("threshold=5ms"))
+	})
+
+	It("logs fast queries at debug level with their arguments redacted", func() {
+		repoInst := repo.NewFlakyTestRepo(fakeDB, repo.WithQueryLogging(time.Minute))
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(logs.String()).To(ContainSubstring(`level=DEBUG msg="sql statement"`))
+		Expect(logs.String()).To(ContainSubstring(`args="[$1=<string> $2=<int>`))
+		Expect(logs.String()).NotTo(ContainSubstring("policy-admin-ui"))
+		Expect(logs.String()).NotTo(ContainSubstring("slow sql query"))
+	})
+
+	It("logs replica queries too", func() {
+		replica := &fakes.FakePgxQuerier{}
+		replica.QueryStub = fakeDB.QueryStub
+		repoInst := repo.NewFlakyTestRepo(fakeDB, repo.WithQueryLogging(5*time.Millisecond), repo.WithReadReplica(replica))
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(replica.QueryCallCount()).To(Equal(1))
+		Expect(logs.String()).To(ContainSubstring("slow sql query"))
+	})
+
+	It("logs nothing extra unless enabled", func() {
+		repoInst := repo.NewFlakyTestRepo(fakeDB)
+
+		_, err := repoInst.GetFlakyTests(ctx, "policy-admin-ui", 20, repo.FlakyTestOptions{})
+		Expect(err).To(BeNil())
+		Expect(logs.String()).NotTo(ContainSubstring("sql statement"))
+		Expect(logs.String()).NotTo(ContainSubstring("slow sql query"))
+	})
+})
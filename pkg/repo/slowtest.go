@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_slow_test_provider.go . SlowTestProvider
+type SlowTestProvider interface {
+	GetSlowestTests(ctx context.Context, projectID string, limit int) ([]*gql.SlowTest, error)
+}
+
+type SlowTestRepo struct {
+	db PgxQuerier
+}
+
+func NewSlowTestRepo(db PgxQuerier) *SlowTestRepo {
+	return &SlowTestRepo{db: db}
+}
+
+// GetSlowestTests returns the specs with the highest average duration for a
+// project, with the p50, p95 and p99 of their run durations interpolated by
+// percentile_cont. A spec with a single run reports that run's duration for
+// every percentile. Runs without both a start and end time are ignored.
+func (r *SlowTestRepo) GetSlowestTests(ctx context.Context, projectID string, limit int) ([]*gql.SlowTest, error) {
+	query := `
+    SELECT
+        test_name,
+        AVG(duration_ms) AS avg_duration_ms,
+        MAX(duration_ms) AS max_duration_ms,
+        COUNT(*) AS run_count,
+        percentile_cont(0.5) WITHIN GROUP (ORDER BY duration_ms) AS p50_ms,
+        percentile_cont(0.95) WITHIN GROUP (ORDER BY duration_ms) AS p95_ms,
+        percentile_cont(0.99) WITHIN GROUP (ORDER BY duration_ms) AS p99_ms
+    FROM (
+        SELECT
+            spec_runs.spec_description AS test_name,
+            (EXTRACT(EPOCH FROM (spec_runs.end_time - spec_runs.start_time)) * 1000)::float8 AS duration_ms
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectMatch + `
+          AND spec_runs.start_time IS NOT NULL
+          AND spec_runs.end_time IS NOT NULL
+    ) runs
+    GROUP BY test_name
+    ORDER BY avg_duration_ms DESC
+    LIMIT $2;
+	`
+	rows, err := timedQuery(ctx, r.db, "slowest_tests", query, projectID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*gql.SlowTest
+
+	for rows.Next() {
+		test := &gql.SlowTest{Percentiles: &gql.DurationPercentiles{}}
+		if err := rows.Scan(&test.TestName, &test.AvgDurationMs, &test.MaxDurationMs, &test.RunCount,
+			&test.Percentiles.P50Ms, &test.Percentiles.P95Ms, &test.Percentiles.P99Ms); err != nil {
+			return nil, err
+		}
+		results = append(results, test)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,90 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("SlowTestRepo", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.SlowTestProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewSlowTestRepo(fakeDB)
+	})
+
+	It("returns slow test results from fake rows", func() {
+		mockRows := &fakeRows{
+			data: [][]any{
+				{"ReportExporter renders large PDFs", 5400.5, 9100.0, 12, 5200.0, 8800.0, 9040.0},
+				{"LoginService handles expired tokens", 120.0, 180.0, 40, 115.0, 170.0, 178.0},
+			},
+		}
+
+		fakeDB.QueryReturns(mockRows, nil)
+
+		results, err := repoInst.GetSlowestTests(ctx, "policy-admin-ui", 2)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].TestName).To(Equal("ReportExporter renders large PDFs"))
+		Expect(results[0].AvgDurationMs).To(BeNumerically("~", 5400.5, 0.01))
+		Expect(results[0].MaxDurationMs).To(BeNumerically("~", 9100.0, 0.01))
+		Expect(results[0].RunCount).To(Equal(12))
+		Expect(results[0].Percentiles).To(Equal(&gql.DurationPercentiles{P50Ms: 5200, P95Ms: 8800, P99Ms: 9040}))
+		Expect(results[1].Percentiles).To(Equal(&gql.DurationPercentiles{P50Ms: 115, P95Ms: 170, P99Ms: 178}))
+
+		_, _, args := fakeDB.QueryArgsForCall(0)
+		Expect(args).To(Equal([]any{"policy-admin-ui", 2}))
+	})
+
+	It("interpolates the p50, p95 and p99 durations of each spec", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.GetSlowestTests(ctx, "policy-admin-ui", 2)
+		Expect(err).To(BeNil())
+
+		_, sql, _ := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("percentile_cont(0.5) WITHIN GROUP (ORDER BY duration_ms) AS p50_ms"))
+		Expect(sql).To(ContainSubstring("percentile_cont(0.95) WITHIN GROUP (ORDER BY duration_ms) AS p95_ms"))
+		Expect(sql).To(ContainSubstring("percentile_cont(0.99) WITHIN GROUP (ORDER BY duration_ms) AS p99_ms"))
+	})
+
+	It("reports the only duration of a single-run spec for every percentile", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{
+			{"Once-only spec", 250.0, 250.0, 1, 250.0, 250.0, 250.0},
+		}}, nil)
+
+		results, err := repoInst.GetSlowestTests(ctx, "policy-admin-ui", 1)
+		Expect(err).To(BeNil())
+		Expect(results[0].RunCount).To(Equal(1))
+		Expect(results[0].Percentiles).To(Equal(&gql.DurationPercentiles{P50Ms: 250, P95Ms: 250, P99Ms: 250}))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		results, err := repoInst.GetSlowestTests(ctx, "policy-admin-ui", 2)
+		Expect(err).To(MatchError("db down"))
+		Expect(results).To(BeNil())
+	})
+
+	It("propagates errors raised while reading rows", func() {
+		fakeDB.QueryReturns(&fakeRows{err: errors.New("connection reset")}, nil)
+
+		results, err := repoInst.GetSlowestTests(ctx, "policy-admin-ui", 2)
+		Expect(err).To(MatchError("connection reset"))
+		Expect(results).To(BeNil())
+	})
+})
@@ -0,0 +1,190 @@
+package repo_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// adversarialInputs are strings that would change a query's meaning if they
+// were ever spliced into its text instead of bound as parameters.
+var adversarialInputs = []string{
+	`demo'; DROP TABLE spec_runs; --`,
+	`demo' OR '1'='1`,
+	`demo" OR "1"="1`,
+	`demo/* comment */`,
+	`*/ SELECT pg_sleep(10) /*`,
+	`demo\'; TRUNCATE project_details; --`,
+}
+
+var _ = Describe("SQL injection hardening", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	// lastQuery returns the text and arguments of the most recent query.
+	lastQuery := func() (string, []any) {
+		Expect(fakeDB.QueryCallCount()).To(BeNumerically(">", 0))
+		_, sql, args := fakeDB.QueryArgsForCall(fakeDB.QueryCallCount() - 1)
+		return sql, args
+	}
+
+	// Each entry runs a query with input in one user-controlled argument,
+	// and names the position it must be bound at.
+	type boundArgument struct {
+		name     string
+		run      func(input string) error
+		position int
+		value    func(input string) any
+	}
+	verbatim := func(input string) any { return input }
+
+	cases := []boundArgument{
+		{
+			name: "the GetFlakyTests project",
+			run: func(input string) error {
+				_, err := repoInst.GetFlakyTests(ctx, input, 5, repo.FlakyTestOptions{})
+				return err
+			},
+			position: 0, value: verbatim,
+		},
+		{
+			name: "the GetFlakyTests suite name",
+			run: func(input string) error {
+				_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SuiteName: input})
+				return err
+			},
+			position: 2, value: verbatim,
+		},
+		{
+			name: "the GetFlakyTestsBatch projects",
+			run: func(input string) error {
+				_, err := repoInst.GetFlakyTestsBatch(ctx, []string{"demo", input}, 5, repo.FlakyTestOptions{})
+				return err
+			},
+			position: 0, value: func(input string) any { return []string{"demo", input} },
+		},
+		{
+			name: "the CountFlakyTests suite name",
+			run: func(input string) error {
+				_, err := repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{SuiteName: input})
+				return err
+			},
+			position: 1, value: verbatim,
+		},
+		{
+			name: "the GetFailureActors test name",
+			run: func(input string) error {
+				_, err := repoInst.GetFailureActors(ctx, "demo", input, 7)
+				return err
+			},
+			position: 1, value: verbatim,
+		},
+		{
+			name: "the GetCoFailingTests project",
+			run: func(input string) error {
+				_, err := repoInst.GetCoFailingTests(ctx, input, 5)
+				return err
+			},
+			position: 0, value: verbatim,
+		},
+	}
+
+	for _, c := range cases {
+		It("binds "+c.name+" as a parameter", func() {
+			Expect(c.run("demo")).To(Succeed())
+			baseline, _ := lastQuery()
+
+			for _, input := range adversarialInputs {
+				Expect(c.run(input)).To(Succeed())
+				query, args := lastQuery()
+
+				Expect(query).To(Equal(baseline), "the query text changed for %q", input)
+				Expect(query).NotTo(ContainSubstring(input))
+				Expect(args[c.position]).To(Equal(c.value(input)))
+			}
+		})
+	}
+
+	It("binds the GetPassRateTrend test name and bucket as parameters", func() {
+		trendRepo := repo.NewTrendRepo(fakeDB)
+		for _, input := range adversarialInputs {
+			_, err := trendRepo.GetPassRateTrend(ctx, "demo", input, "day")
+			Expect(err).To(Succeed())
+			query, args := lastQuery()
+			Expect(query).NotTo(ContainSubstring(input))
+			Expect(args[:3]).To(Equal([]any{"demo", input, "day"}))
+		}
+	})
+
+	It("rejects trend buckets outside the whitelist without querying", func() {
+		for _, input := range append(adversarialInputs, "month", "DAY") {
+			_, err := repo.NewTrendRepo(fakeDB).GetPassRateTrend(ctx, "demo", "login", input)
+			Expect(err).To(MatchError(repo.ErrInvalidArgument))
+		}
+		Expect(fakeDB.QueryCallCount()).To(BeZero())
+	})
+
+	DescribeTable("rejects sort fields that are not whitelisted without querying",
+		func(sortBy gql.FlakyTestSortField) {
+			_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SortBy: sortBy})
+			Expect(err).To(MatchError(repo.ErrInvalidArgument))
+
+			_, err = repoInst.GetFlakyTestsBatch(ctx, []string{"demo"}, 5, repo.FlakyTestOptions{SortBy: sortBy})
+			Expect(err).To(MatchError(repo.ErrInvalidArgument))
+
+			Expect(fakeDB.QueryCallCount()).To(BeZero())
+		},
+		Entry("a raw column name", gql.FlakyTestSortField("spec_runs.spec_description")),
+		Entry("a result alias", gql.FlakyTestSortField("failure_count")),
+		Entry("a lower-case enum value", gql.FlakyTestSortField("failure_rate")),
+		Entry("an enum value with a direction", gql.FlakyTestSortField("RUN_COUNT DESC")),
+		Entry("a stacked statement", gql.FlakyTestSortField("RUN_COUNT; DROP TABLE spec_runs")),
+		Entry("a subquery", gql.FlakyTestSortField("(SELECT 1)")),
+	)
+
+	DescribeTable("rejects sort orders that are not whitelisted without querying",
+		func(sortOrder gql.SortOrder) {
+			_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SortOrder: sortOrder})
+			Expect(err).To(MatchError(repo.ErrInvalidArgument))
+			Expect(fakeDB.QueryCallCount()).To(BeZero())
+		},
+		Entry("a lower-case direction", gql.SortOrder("asc")),
+		Entry("a trailing comment", gql.SortOrder("DESC --")),
+		Entry("a NULLS clause", gql.SortOrder("ASC NULLS FIRST")),
+		Entry("a stacked statement", gql.SortOrder("DESC; DROP TABLE spec_runs")),
+	)
+
+	It("binds SQLite backend arguments as named parameters", func() {
+		db, err := sql.Open("sqlite-stub", ":memory:")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(db.Close)
+		sqliteStub.columns = []string{"test_name", "total_runs", "failure_count", "last_failure", "outcomes"}
+		sqliteStub.rows = nil
+		sqliteRepo := repo.NewSQLiteFlakyTestRepo(db)
+
+		for _, input := range adversarialInputs {
+			_, err := sqliteRepo.GetFlakyTests(ctx, input, 5, repo.FlakyTestOptions{SuiteName: input})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sqliteStub.query).NotTo(ContainSubstring(input))
+			Expect(sqliteStub.args).To(ContainElement(input))
+		}
+
+		_, err = sqliteRepo.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SortBy: "test_name; DROP TABLE spec_runs"})
+		Expect(err).To(MatchError(repo.ErrInvalidArgument))
+	})
+})
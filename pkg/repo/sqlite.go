@@ -0,0 +1,170 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// sqliteSortColumns are the SQLite forms of flakyTestSortColumns.
+var sqliteSortColumns = map[gql.FlakyTestSortField]string{
+	gql.FlakyTestSortFieldFailureRate: `CAST(SUM(` + sqliteFailed + `) AS REAL) / COUNT(*)`,
+	gql.FlakyTestSortFieldRunCount:    `COUNT(*)`,
+	gql.FlakyTestSortFieldLastFailure: `MAX(CASE WHEN ` + sqliteFailed + ` = 1 THEN spec_runs.end_time END)`,
+}
+
+// sqliteFailed is 1 for a failed run and 0 for a passing one.
+const sqliteFailed = `(spec_runs.status NOT IN (SELECT value FROM json_each(:success)))`
+
+// sqliteTimeLayouts are the timestamp formats SQLite drivers hand back as text.
+var sqliteTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05"}
+
+// SQLiteFlakyTestRepo is a FlakyTestProvider and FlakyTestCounter reading
+// the fern-reporter tables from a SQLite database through database/sql. It
+// supports every FlakyTestOptions filter except NamePattern, and leaves
+// LastFailureBranch, LastFailureSha and TopFailureMessages unset.
+type SQLiteFlakyTestRepo struct {
+	db              *sql.DB
+	successStatuses []string
+	ignoredStatuses []string
+}
+
+// NewSQLiteFlakyTestRepo reads flaky tests from db, which must be opened
+// with a SQLite driver.
+func NewSQLiteFlakyTestRepo(db *sql.DB) *SQLiteFlakyTestRepo {
+	return &SQLiteFlakyTestRepo{
+		db:              db,
+		successStatuses: DefaultSuccessStatuses,
+		ignoredStatuses: DefaultIgnoredStatuses,
+	}
+}
+
+// sqliteFlakyGroups groups a project's spec runs by spec, keeping the specs
+// that pass the FlakyTestOptions filters.
+const sqliteFlakyGroups = `
+    FROM spec_runs` + projectJoins + `
+    WHERE (project_details.name = :project OR project_details.uuid = :project)
+      AND (:suite = '' OR suite_runs.suite_name = :suite)
+      AND spec_runs.start_time >= datetime('now', '-' || :since_days || ' days')
+      AND spec_runs.status NOT IN (SELECT value FROM json_each(:ignored))
+    GROUP BY spec_runs.spec_description
+    HAVING COUNT(*) >= :min_runs
+      AND CAST(SUM(` + sqliteFailed + `) AS REAL) / COUNT(*) >= :min_failure_rate`
+
+// GetFlakyTests returns the specs with the highest failure rate for a
+// project, matched by name or UUID.
+func (r *SQLiteFlakyTestRepo) GetFlakyTests(ctx context.Context, projectID string, limit int, opts FlakyTestOptions) ([]*gql.FlakyTest, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+	args, err := r.filterArgs(projectID, &opts)
+	if err != nil {
+		return nil, err
+	}
+	orderBy, err := opts.orderByColumns(sqliteSortColumns, rawTestName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+    SELECT
+        spec_runs.spec_description AS test_name,
+        COUNT(*) AS total_runs,
+        SUM(` + sqliteFailed + `) AS failure_count,
+        MAX(CASE WHEN ` + sqliteFailed + ` = 1 THEN spec_runs.end_time END) AS last_failure,
+        group_concat(` + sqliteFailed + `, '' ORDER BY spec_runs.start_time) AS outcomes` + sqliteFlakyGroups + `
+    ORDER BY ` + orderBy + `
+    LIMIT :limit OFFSET :offset;
+	`
+	args = append(args, sql.Named("limit", limit), sql.Named("offset", opts.Offset))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*gql.FlakyTest
+	for rows.Next() {
+		var row flakyTestRow
+		var lastFailure sql.NullString
+		var outcomes string
+		if err := rows.Scan(&row.testName, &row.runCount, &row.failureCount, &lastFailure, &outcomes); err != nil {
+			return nil, err
+		}
+		if lastFailure.Valid {
+			t, err := parseSQLiteTime(lastFailure.String)
+			if err != nil {
+				return nil, err
+			}
+			row.lastFailure = &t
+		}
+		row.outcomes = make([]bool, len(outcomes))
+		for i := range outcomes {
+			row.outcomes[i] = outcomes[i] == '1'
+		}
+		results = append(results, row.flakyTest())
+	}
+	return results, rows.Err()
+}
+
+// CountFlakyTests returns how many specs GetFlakyTests would rank for the
+// same project and filters, ignoring the offset and limit.
+func (r *SQLiteFlakyTestRepo) CountFlakyTests(ctx context.Context, projectID string, opts FlakyTestOptions) (int, error) {
+	args, err := r.filterArgs(projectID, &opts)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+    SELECT COUNT(*) FROM (
+        SELECT spec_runs.spec_description` + sqliteFlakyGroups + `
+    );
+	`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// filterArgs normalizes opts and returns the named arguments of
+// sqliteFlakyGroups.
+func (r *SQLiteFlakyTestRepo) filterArgs(projectID string, opts *FlakyTestOptions) ([]any, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	if opts.NamePattern != "" {
+		return nil, InvalidArgumentf("namePattern is not supported by the SQLite backend")
+	}
+	success, err := json.Marshal(r.successStatuses)
+	if err != nil {
+		return nil, err
+	}
+	ignored, err := json.Marshal(r.ignoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	return []any{
+		sql.Named("project", projectID),
+		sql.Named("suite", opts.SuiteName),
+		sql.Named("since_days", opts.SinceDays),
+		sql.Named("success", string(success)),
+		sql.Named("ignored", string(ignored)),
+		sql.Named("min_runs", opts.MinRuns),
+		sql.Named("min_failure_rate", opts.MinFailureRate),
+	}, nil
+}
+
+func parseSQLiteTime(raw string) (time.Time, error) {
+	var err error
+	for _, layout := range sqliteTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,280 @@
+package repo_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	_ "modernc.org/sqlite"
+)
+
+// stubDriver is a database/sql driver that records each query and answers
+// with canned rows, standing in for a SQLite driver.
+type stubDriver struct {
+	mu      sync.Mutex
+	query   string
+	args    map[string]any
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (d *stubDriver) Open(string) (driver.Conn, error) { return stubConn{d}, nil }
+
+type stubConn struct{ d *stubDriver }
+
+func (stubConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (stubConn) Close() error                        { return nil }
+func (stubConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+func (stubConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c stubConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.query = query
+	c.d.args = map[string]any{}
+	for _, arg := range args {
+		c.d.args[arg.Name] = arg.Value
+	}
+	return &stubRows{columns: c.d.columns, rows: c.d.rows}, nil
+}
+
+type stubRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string { return r.columns }
+func (r *stubRows) Close() error      { return nil }
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var sqliteStub = &stubDriver{}
+
+func init() {
+	sql.Register("sqlite-stub", sqliteStub)
+}
+
+var _ = Describe("SQLiteFlakyTestRepo", func() {
+	var (
+		ctx      context.Context
+		repoInst *repo.SQLiteFlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		db, err := sql.Open("sqlite-stub", ":memory:")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(db.Close)
+		repoInst = repo.NewSQLiteFlakyTestRepo(db)
+
+		sqliteStub.columns = []string{"test_name", "total_runs", "failure_count", "last_failure", "outcomes"}
+		sqliteStub.rows = nil
+	})
+
+	It("should compute the flaky test statistics from the aggregated rows", func() {
+		sqliteStub.rows = [][]driver.Value{
+			{"login", int64(4), int64(2), "2026-10-01 12:00:00", "0101"},
+			{"logout", int64(3), int64(0), nil, "000"},
+		}
+
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		lastFailure := "2026-10-01T12:00:00Z"
+		Expect(results).To(Equal([]*gql.FlakyTest{
+			{TestID: "login", TestName: "login", PassRate: 0.5, FailureRate: 0.5, FlakinessScore: 1, LastFailure: &lastFailure, RunCount: 4},
+			{TestID: "logout", TestName: "logout", PassRate: 1, FailureRate: 0, FlakinessScore: 0, RunCount: 3},
+		}))
+	})
+
+	It("should pass the project, filters and statuses as named parameters", func() {
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{
+			SuiteName: "Auth Suite", Offset: 10, MinRuns: 3, MinFailureRate: 0.25,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(sqliteStub.args).To(Equal(map[string]any{
+			"project":          "demo",
+			"suite":            "Auth Suite",
+			"since_days":       repo.DefaultSinceDays,
+			"success":          `["passed","pass"]`,
+			"ignored":          `["skipped","pending"]`,
+			"min_runs":         3,
+			"min_failure_rate": 0.25,
+			"limit":            5,
+			"offset":           10,
+		}))
+		Expect(sqliteStub.query).To(ContainSubstring("json_each(:success)"))
+		Expect(sqliteStub.query).To(ContainSubstring("ORDER BY CAST(SUM("))
+		Expect(sqliteStub.query).To(ContainSubstring("DESC NULLS LAST, spec_runs.spec_description"))
+	})
+
+	It("should sort by the requested field", func() {
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{
+			SortBy: gql.FlakyTestSortFieldRunCount, SortOrder: gql.SortOrderAsc,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sqliteStub.query).To(ContainSubstring("ORDER BY COUNT(*) ASC NULLS LAST"))
+	})
+
+	It("should reject invalid options before querying", func() {
+		sqliteStub.query = ""
+
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{MinFailureRate: 2})
+		Expect(err).To(MatchError(ContainSubstring("minFailureRate must be between 0 and 1")))
+		Expect(sqliteStub.query).To(BeEmpty())
+	})
+
+	It("should return an error for unparseable timestamps", func() {
+		sqliteStub.rows = [][]driver.Value{{"login", int64(1), int64(1), "yesterday", "1"}}
+
+		_, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// sqliteSchema is the part of the fern-reporter schema that
+// SQLiteFlakyTestRepo reads, with rows covering its filters: skipped and old
+// runs, another project and a second suite.
+var sqliteSchema = []string{
+	`CREATE TABLE project_details (id INTEGER PRIMARY KEY, uuid TEXT NOT NULL, name TEXT NOT NULL)`,
+	`CREATE TABLE test_runs (id INTEGER PRIMARY KEY, project_id INTEGER NOT NULL REFERENCES project_details(id))`,
+	`CREATE TABLE suite_runs (id INTEGER PRIMARY KEY, test_run_id INTEGER NOT NULL REFERENCES test_runs(id), suite_name TEXT)`,
+	`CREATE TABLE spec_runs (
+		id INTEGER PRIMARY KEY,
+		suite_id INTEGER NOT NULL REFERENCES suite_runs(id),
+		spec_description TEXT NOT NULL,
+		status TEXT NOT NULL,
+		start_time TIMESTAMP NOT NULL,
+		end_time TIMESTAMP
+	)`,
+	`INSERT INTO project_details VALUES (1, 'uuid-demo', 'demo'), (2, 'uuid-other', 'other')`,
+	`INSERT INTO test_runs VALUES (1, 1), (2, 2)`,
+	`INSERT INTO suite_runs VALUES (1, 1, 'Auth Suite'), (2, 1, 'Billing Suite'), (3, 2, 'Auth Suite')`,
+	`INSERT INTO spec_runs (suite_id, spec_description, status, start_time, end_time) VALUES
+		(1, 'login', 'passed', datetime('now', '-4 hours'), datetime('now', '-4 hours')),
+		(1, 'login', 'failed', datetime('now', '-3 hours'), datetime('now', '-3 hours')),
+		(1, 'login', 'passed', datetime('now', '-2 hours'), datetime('now', '-2 hours')),
+		(1, 'login', 'failed', datetime('now', '-1 hours'), datetime('now', '-1 hours')),
+		(1, 'login', 'skipped', datetime('now'), datetime('now')),
+		(1, 'logout', 'pass', datetime('now', '-2 hours'), datetime('now', '-2 hours')),
+		(1, 'logout', 'passed', datetime('now', '-1 hours'), datetime('now', '-1 hours')),
+		(1, 'logout', 'failed', datetime('now', '-60 days'), datetime('now', '-60 days')),
+		(2, 'invoice', 'failed', datetime('now', '-3 hours'), datetime('now', '-3 hours')),
+		(2, 'invoice', 'failed', datetime('now', '-2 hours'), datetime('now', '-2 hours')),
+		(2, 'invoice', 'passed', datetime('now'), datetime('now')),
+		(3, 'login', 'failed', datetime('now'), datetime('now'))`,
+}
+
+var _ = Describe("SQLiteFlakyTestRepo against SQLite", func() {
+	var (
+		ctx      context.Context
+		repoInst *repo.SQLiteFlakyTestRepo
+	)
+
+	testNames := func(results []*gql.FlakyTest) []string {
+		var names []string
+		for _, result := range results {
+			names = append(names, result.TestName)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		db, err := sql.Open("sqlite", ":memory:")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(db.Close)
+		// Every connection to :memory: opens a database of its own.
+		db.SetMaxOpenConns(1)
+		for _, stmt := range sqliteSchema {
+			_, err := db.ExecContext(ctx, stmt)
+			Expect(err).NotTo(HaveOccurred(), stmt)
+		}
+		repoInst = repo.NewSQLiteFlakyTestRepo(db)
+	})
+
+	It("should compute the statistics of a project's recent runs", func() {
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testNames(results)).To(Equal([]string{"invoice", "login", "logout"}))
+
+		invoice, login, logout := results[0], results[1], results[2]
+		Expect(invoice.RunCount).To(Equal(3))
+		Expect(invoice.FailureRate).To(BeNumerically("~", 2.0/3.0, 0.0001))
+		Expect(invoice.FlakinessScore).To(Equal(0.5))
+
+		// The skipped run is ignored and the runs alternate.
+		Expect(login.RunCount).To(Equal(4))
+		Expect(login.FailureRate).To(Equal(0.5))
+		Expect(login.FlakinessScore).To(Equal(1.0))
+		Expect(login.LastFailure).NotTo(BeNil())
+
+		// The failure 60 days ago is outside the lookback window.
+		Expect(logout.RunCount).To(Equal(2))
+		Expect(logout.FailureRate).To(BeZero())
+		Expect(logout.LastFailure).To(BeNil())
+	})
+
+	It("should match the project by UUID and filter by suite", func() {
+		results, err := repoInst.GetFlakyTests(ctx, "uuid-demo", 5, repo.FlakyTestOptions{SuiteName: "Billing Suite"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testNames(results)).To(Equal([]string{"invoice"}))
+	})
+
+	It("should apply the run, rate and paging filters", func() {
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{MinRuns: 3, MinFailureRate: 0.1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testNames(results)).To(Equal([]string{"invoice", "login"}))
+
+		results, err = repoInst.GetFlakyTests(ctx, "demo", 1, repo.FlakyTestOptions{Offset: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testNames(results)).To(Equal([]string{"login"}))
+
+		results, err = repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SinceDays: 90})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[2].RunCount).To(Equal(3))
+	})
+
+	It("should sort by the requested field", func() {
+		results, err := repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{
+			SortBy: gql.FlakyTestSortFieldRunCount, SortOrder: gql.SortOrderAsc,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testNames(results)).To(Equal([]string{"logout", "invoice", "login"}))
+
+		results, err = repoInst.GetFlakyTests(ctx, "demo", 5, repo.FlakyTestOptions{SortBy: gql.FlakyTestSortFieldLastFailure})
+		Expect(err).NotTo(HaveOccurred())
+		// login failed an hour after invoice; logout has no recent failure.
+		Expect(testNames(results)).To(Equal([]string{"login", "invoice", "logout"}))
+	})
+
+	It("should return no tests for an unknown project", func() {
+		results, err := repoInst.GetFlakyTests(ctx, "missing", 5, repo.FlakyTestOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeEmpty())
+	})
+
+	It("should count the tests it would rank, ignoring paging", func() {
+		Expect(repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{Offset: 2})).To(Equal(3))
+		Expect(repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{MinRuns: 3, MinFailureRate: 0.1})).To(Equal(2))
+		Expect(repoInst.CountFlakyTests(ctx, "uuid-demo", repo.FlakyTestOptions{SuiteName: "Billing Suite"})).To(Equal(1))
+		Expect(repoInst.CountFlakyTests(ctx, "missing", repo.FlakyTestOptions{})).To(BeZero())
+
+		_, err := repoInst.CountFlakyTests(ctx, "demo", repo.FlakyTestOptions{NamePattern: `\d+`})
+		Expect(err).To(MatchError(repo.ErrInvalidArgument))
+	})
+})
@@ -0,0 +1,18 @@
+package repo
+
+// DefaultSuccessStatuses are the spec_runs.status values counted as passing.
+var DefaultSuccessStatuses = []string{"passed", "pass"}
+
+// DefaultIgnoredStatuses are the spec_runs.status values excluded from pass and
+// failure counts entirely, since the spec never actually ran.
+var DefaultIgnoredStatuses = []string{"skipped", "pending"}
+
+// DefaultFailureStatuses are the spec_runs.status values fern-reporter records
+// for failing specs. Any status that is neither passing nor ignored counts as
+// a failure; these are the ones accepted when ingesting results.
+var DefaultFailureStatuses = []string{"failed", "fail"}
+
+// DefaultErrorStatuses are the spec_runs.status values of specs that crashed
+// or timed out rather than failing an assertion. They still count as
+// failures; only the status breakdown of flaky tests tells them apart.
+var DefaultErrorStatuses = []string{"error", "errored", "timeout", "timedout"}
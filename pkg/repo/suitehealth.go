@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_suite_health_provider.go . SuiteHealthProvider
+type SuiteHealthProvider interface {
+	GetSuiteHealth(ctx context.Context, projectID string) ([]*gql.SuiteHealth, error)
+}
+
+type SuiteHealthRepo struct {
+	db PgxQuerier
+}
+
+func NewSuiteHealthRepo(db PgxQuerier) *SuiteHealthRepo {
+	return &SuiteHealthRepo{db: db}
+}
+
+// GetSuiteHealth rolls up a project's spec runs per suite, ordered by suite
+// name. A spec counts towards flakyTestCount when it has both passed and
+// failed within the suite.
+func (r *SuiteHealthRepo) GetSuiteHealth(ctx context.Context, projectID string) ([]*gql.SuiteHealth, error) {
+	query := `
+    SELECT
+        suite_name,
+        SUM(run_count)::bigint AS total_runs,
+        SUM(failure_count)::bigint AS failure_count,
+        COUNT(*) FILTER (WHERE failure_count > 0 AND failure_count < run_count) AS flaky_test_count
+    FROM (
+        SELECT
+            suite_runs.suite_name,
+            spec_runs.spec_description,
+            COUNT(*) AS run_count,
+            COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($2)) AS failure_count
+        FROM spec_runs` + projectJoins + `
+        WHERE ` + projectMatch + `
+          AND NOT spec_runs.status = ANY($3)
+        GROUP BY suite_runs.suite_name, spec_runs.spec_description
+    ) specs
+    GROUP BY suite_name
+    ORDER BY suite_name;
+	`
+	rows, err := timedQuery(ctx, r.db, "suite_health", query, projectID, DefaultSuccessStatuses, DefaultIgnoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*gql.SuiteHealth
+
+	for rows.Next() {
+		suite := &gql.SuiteHealth{}
+		if err := rows.Scan(&suite.SuiteName, &suite.TotalRuns, &suite.FailureCount, &suite.FlakyTestCount); err != nil {
+			return nil, err
+		}
+		suite.PassRate = ratio(suite.TotalRuns-suite.FailureCount, suite.TotalRuns)
+		results = append(results, suite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
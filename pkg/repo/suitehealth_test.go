@@ -0,0 +1,67 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("SuiteHealthRepo", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.SuiteHealthProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewSuiteHealthRepo(fakeDB)
+	})
+
+	It("rolls up each suite with its pass rate", func() {
+		mockRows := &fakeRows{
+			data: [][]any{
+				{"Auth Suite", 40, 10, 3},
+				{"Billing Suite", 12, 0, 0},
+				{"Empty Suite", 0, 0, 0},
+			},
+		}
+
+		fakeDB.QueryReturns(mockRows, nil)
+
+		results, err := repoInst.GetSuiteHealth(ctx, "policy-admin-ui")
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal([]*gql.SuiteHealth{
+			{SuiteName: "Auth Suite", TotalRuns: 40, FailureCount: 10, PassRate: 0.75, FlakyTestCount: 3},
+			{SuiteName: "Billing Suite", TotalRuns: 12, FailureCount: 0, PassRate: 1, FlakyTestCount: 0},
+			{SuiteName: "Empty Suite"},
+		}))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("GROUP BY suite_runs.suite_name, spec_runs.spec_description"))
+		Expect(args).To(Equal([]any{"policy-admin-ui", repo.DefaultSuccessStatuses, repo.DefaultIgnoredStatuses}))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		results, err := repoInst.GetSuiteHealth(ctx, "policy-admin-ui")
+		Expect(err).To(MatchError("db down"))
+		Expect(results).To(BeNil())
+	})
+
+	It("propagates errors raised while reading rows", func() {
+		fakeDB.QueryReturns(&fakeRows{err: errors.New("connection reset")}, nil)
+
+		results, err := repoInst.GetSuiteHealth(ctx, "suites")
+		Expect(err).To(MatchError("connection reset"))
+		Expect(results).To(BeNil())
+	})
+})
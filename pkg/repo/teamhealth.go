@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_team_health_provider.go . TeamHealthProvider
+type TeamHealthProvider interface {
+	GetTeamHealth(ctx context.Context, teamName string) (*gql.TeamHealth, error)
+}
+
+type TeamHealthRepo struct {
+	db PgxQuerier
+}
+
+func NewTeamHealthRepo(db PgxQuerier) *TeamHealthRepo {
+	return &TeamHealthRepo{db: db}
+}
+
+// GetTeamHealth rolls up the spec runs of every project whose team_name is
+// teamName. A spec counts towards flakyTestCount when it has both passed
+// and failed within its project, and averageFailureRate is the mean of the
+// projects' failure rates, so a busy project does not outweigh the rest.
+// A team without projects or runs gets a zeroed result.
+func (r *TeamHealthRepo) GetTeamHealth(ctx context.Context, teamName string) (*gql.TeamHealth, error) {
+	query := `
+    WITH specs AS (
+        SELECT
+            project_details.id AS project_id,
+            spec_runs.spec_description,
+            COUNT(*) AS run_count,
+            COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($2)) AS failure_count
+        FROM spec_runs` + projectJoins + `
+        WHERE project_details.team_name = $1
+          AND NOT spec_runs.status = ANY($3)
+        GROUP BY project_details.id, spec_runs.spec_description
+    ), projects AS (
+        SELECT project_id, SUM(failure_count)::float8 / SUM(run_count) AS failure_rate
+        FROM specs
+        GROUP BY project_id
+    )
+    SELECT
+        (SELECT COUNT(*) FROM project_details WHERE team_name = $1) AS project_count,
+        (SELECT COALESCE(SUM(run_count), 0)::bigint FROM specs) AS total_runs,
+        (SELECT COUNT(*) FROM specs WHERE failure_count > 0 AND failure_count < run_count) AS flaky_test_count,
+        (SELECT COALESCE(AVG(failure_rate), 0) FROM projects) AS average_failure_rate;
+	`
+	rows, err := timedQuery(ctx, r.db, "team_health", query, teamName, DefaultSuccessStatuses, DefaultIgnoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	team := &gql.TeamHealth{TeamName: teamName}
+	if rows.Next() {
+		if err := rows.Scan(&team.ProjectCount, &team.TotalRuns, &team.FlakyTestCount, &team.AverageFailureRate); err != nil {
+			return nil, err
+		}
+	}
+	return team, rows.Err()
+}
@@ -0,0 +1,58 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("TeamHealthRepo", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.TeamHealthProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewTeamHealthRepo(fakeDB)
+	})
+
+	It("rolls up the team's projects", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{2, 9, 2, 0.58}}}, nil)
+
+		result, err := repoInst.GetTeamHealth(ctx, "team-d")
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(&gql.TeamHealth{
+			TeamName: "team-d", ProjectCount: 2, TotalRuns: 9, FlakyTestCount: 2, AverageFailureRate: 0.58,
+		}))
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("WHERE project_details.team_name = $1"))
+		Expect(sql).To(ContainSubstring("AVG(failure_rate)"))
+		Expect(args).To(Equal([]any{"team-d", repo.DefaultSuccessStatuses, repo.DefaultIgnoredStatuses}))
+	})
+
+	It("returns a zeroed result for a team without projects", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{{0, 0, 0, 0.0}}}, nil)
+
+		result, err := repoInst.GetTeamHealth(ctx, "nobody")
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(&gql.TeamHealth{TeamName: "nobody"}))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		result, err := repoInst.GetTeamHealth(ctx, "team-d")
+		Expect(err).To(MatchError("db down"))
+		Expect(result).To(BeNil())
+	})
+})
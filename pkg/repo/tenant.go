@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type tenantKey struct{}
+
+// WithTenant returns a context whose queries a TenantRouter sends to the
+// database of tenant. An empty tenant selects the default database.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set by WithTenant, or "" for none.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// TenantRouter is a PgxQuerier sending each query to the database of the
+// tenant carried by its context, so one set of repositories serves several
+// fern-reporter databases. Queries without a tenant go to the fallback.
+type TenantRouter struct {
+	fallback PgxQuerier
+	tenants  map[string]PgxQuerier
+}
+
+// NewTenantRouter routes queries for the tenants named in tenants to their
+// querier and the rest to fallback.
+func NewTenantRouter(fallback PgxQuerier, tenants map[string]PgxQuerier) *TenantRouter {
+	return &TenantRouter{fallback: fallback, tenants: tenants}
+}
+
+// Querier returns the querier for the tenant of ctx. An unknown tenant is an
+// ErrInvalidArgument error rather than a silent fallback, so a typo cannot
+// read another environment's data.
+func (r *TenantRouter) Querier(ctx context.Context) (PgxQuerier, error) {
+	tenant := TenantFromContext(ctx)
+	if tenant == "" {
+		return r.fallback, nil
+	}
+	q, ok := r.tenants[tenant]
+	if !ok {
+		return nil, InvalidArgumentf("unknown tenant %q", tenant)
+	}
+	return q, nil
+}
+
+func (r *TenantRouter) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	q, err := r.Querier(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return q.Query(ctx, sql, args...)
+}
+
+// Begin starts a transaction on the tenant's database, for repositories
+// that write. It fails when that querier cannot start transactions.
+func (r *TenantRouter) Begin(ctx context.Context) (pgx.Tx, error) {
+	q, err := r.Querier(ctx)
+	if err != nil {
+		return nil, err
+	}
+	beginner, ok := q.(PgxBeginner)
+	if !ok {
+		return nil, fmt.Errorf("database of tenant %q does not support transactions", TenantFromContext(ctx))
+	}
+	return beginner.Begin(ctx)
+}
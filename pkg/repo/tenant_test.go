@@ -0,0 +1,66 @@
+package repo_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("TenantRouter", func() {
+	var (
+		ctx      context.Context
+		fallback *fakes.FakePgxQuerier
+		staging  *fakes.FakePgxQuerier
+		prod     *fakes.FakePgxQuerier
+		router   *repo.TenantRouter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fallback = &fakes.FakePgxQuerier{}
+		staging = &fakes.FakePgxQuerier{}
+		prod = &fakes.FakePgxQuerier{}
+		for _, db := range []*fakes.FakePgxQuerier{fallback, staging, prod} {
+			db.QueryReturns(&fakeRows{}, nil)
+		}
+		router = repo.NewTenantRouter(fallback, map[string]repo.PgxQuerier{"staging": staging, "prod": prod})
+	})
+
+	It("sends a repository's queries to the database of the request's tenant", func() {
+		flakyRepo := repo.NewFlakyTestRepo(router)
+
+		_, err := flakyRepo.GetFlakyTests(repo.WithTenant(ctx, "prod"), "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(prod.QueryCallCount()).To(Equal(1))
+		Expect(staging.QueryCallCount()).To(Equal(0))
+		Expect(fallback.QueryCallCount()).To(Equal(0))
+
+		_, err = flakyRepo.GetFlakyTests(repo.WithTenant(ctx, "staging"), "demo", 5, repo.FlakyTestOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(staging.QueryCallCount()).To(Equal(1))
+		Expect(prod.QueryCallCount()).To(Equal(1))
+	})
+
+	It("uses the fallback for requests without a tenant", func() {
+		_, err := repo.NewProjectRepo(router).ListProjects(ctx, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallback.QueryCallCount()).To(Equal(1))
+		Expect(repo.TenantFromContext(ctx)).To(BeEmpty())
+	})
+
+	It("rejects an unknown tenant without querying any database", func() {
+		_, err := router.Query(repo.WithTenant(ctx, "qa"), "SELECT 1")
+		Expect(err).To(MatchError(repo.ErrInvalidArgument))
+		Expect(err).To(MatchError(`unknown tenant "qa"`))
+		Expect(fallback.QueryCallCount() + staging.QueryCallCount() + prod.QueryCallCount()).To(Equal(0))
+	})
+
+	It("fails to begin a transaction on a database that cannot start one", func() {
+		_, err := router.Begin(repo.WithTenant(ctx, "prod"))
+		Expect(err).To(MatchError(ContainSubstring(`database of tenant "prod" does not support transactions`)))
+	})
+})
@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_test_history_provider.go . TestHistoryProvider
+type TestHistoryProvider interface {
+	GetTestHistory(ctx context.Context, projectID, testName string, limit int) ([]*gql.TestRunResult, error)
+}
+
+// GetTestHistory returns the latest limit runs of one spec, oldest first,
+// with the branch and commit of the test run each belongs to. Skipped and
+// other ignored runs are left out, so every result is a pass or a failure.
+func (r *TestRunRepo) GetTestHistory(ctx context.Context, projectID, testName string, limit int) ([]*gql.TestRunResult, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	query := `
+    SELECT
+        spec_runs.id,
+        test_runs.id,
+        spec_runs.status,
+        spec_runs.status = ANY($4) AS passed,
+        NULLIF(spec_runs.message, ''),
+        spec_runs.start_time,
+        spec_runs.end_time,
+        test_runs.git_branch,
+        test_runs.git_sha
+    FROM spec_runs` + projectJoins + `
+    WHERE ` + projectMatch + `
+      AND spec_runs.spec_description = $2
+      AND NOT spec_runs.status = ANY($5)
+    ORDER BY spec_runs.start_time DESC NULLS LAST, spec_runs.id DESC
+    LIMIT $3;
+	`
+	rows, err := timedQuery(ctx, r.db, "test_history", query, projectID, testName, limit,
+		DefaultSuccessStatuses, DefaultIgnoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*gql.TestRunResult{}
+	for rows.Next() {
+		var specRunID, testRunID int64
+		var startTime, endTime *time.Time
+		result := &gql.TestRunResult{}
+
+		if err := rows.Scan(&specRunID, &testRunID, &result.Status, &result.Passed, &result.Message,
+			&startTime, &endTime, &result.GitBranch, &result.GitSha); err != nil {
+			return nil, err
+		}
+
+		result.SpecRunID = strconv.FormatInt(specRunID, 10)
+		result.TestRunID = strconv.FormatInt(testRunID, 10)
+		result.StartTime = formatTime(startTime)
+		result.EndTime = formatTime(endTime)
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// The query picks the latest runs; present them in the order they ran.
+	slices.Reverse(results)
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("TestRunRepo.GetTestHistory", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.TestHistoryProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewTestRunRepo(fakeDB)
+	})
+
+	It("returns the latest runs of the spec oldest first", func() {
+		start := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+		message := "timeout"
+		branch := "main"
+		fakeDB.QueryReturns(&fakeRows{
+			data: [][]any{
+				{int64(12), int64(3), "passed", true, nil, start.Add(2 * time.Hour), start.Add(2*time.Hour + time.Second), &branch, nil},
+				{int64(11), int64(2), "failed", false, &message, start, start.Add(time.Second), &branch, nil},
+			},
+		}, nil)
+
+		results, err := repoInst.GetTestHistory(ctx, "demo", "LoginService", 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].SpecRunID).To(Equal("11"))
+		Expect(results[0].TestRunID).To(Equal("2"))
+		Expect(results[0].Status).To(Equal("failed"))
+		Expect(results[0].Passed).To(BeFalse())
+		Expect(results[0].Message).To(HaveValue(Equal("timeout")))
+		Expect(results[0].StartTime).To(HaveValue(Equal("2025-04-01T10:00:00Z")))
+		Expect(results[0].GitBranch).To(HaveValue(Equal("main")))
+		Expect(results[0].GitSha).To(BeNil())
+		Expect(results[1].SpecRunID).To(Equal("12"))
+		Expect(results[1].Passed).To(BeTrue())
+		Expect(results[1].Message).To(BeNil())
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("spec_runs.spec_description = $2"))
+		Expect(sql).To(ContainSubstring("ORDER BY spec_runs.start_time DESC"))
+		Expect(args).To(Equal([]any{"demo", "LoginService", 2,
+			repo.DefaultSuccessStatuses, repo.DefaultIgnoredStatuses}))
+	})
+
+	It("returns an empty slice when the spec never ran", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		results, err := repoInst.GetTestHistory(ctx, "demo", "missing", 5)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).ToNot(BeNil())
+		Expect(results).To(BeEmpty())
+	})
+
+	It("rejects an out-of-range limit without querying", func() {
+		_, err := repoInst.GetTestHistory(ctx, "demo", "LoginService", 0)
+		Expect(err).To(MatchError(repo.ErrInvalidArgument))
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		results, err := repoInst.GetTestHistory(ctx, "demo", "LoginService", 5)
+		Expect(err).To(MatchError("db down"))
+		Expect(results).To(BeNil())
+	})
+})
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// FileTagPrefix marks the spec run tags that name a source file exercised by
+// the spec, e.g. "file:pkg/auth/login.go".
+const FileTagPrefix = "file:"
+
+// MaxImpactFiles caps how many files a single test impact lookup may list.
+const MaxImpactFiles = 1000
+
+//go:generate counterfeiter -o fakes/fake_test_impact_provider.go . TestImpactProvider
+type TestImpactProvider interface {
+	GetTestsForFiles(ctx context.Context, projectID string, files []string) ([]*gql.FlakyTest, error)
+}
+
+// GetTestsForFiles returns the specs of a project mapped to any of files,
+// with their statistics over the last DefaultSinceDays, most failing first.
+// A spec is mapped to a file when one of its runs is tagged with
+// FileTagPrefix followed by the file path. Files without a mapping are
+// skipped, so an unknown change yields no tests rather than an error.
+func (r *FlakyTestRepo) GetTestsForFiles(ctx context.Context, projectID string, files []string) ([]*gql.FlakyTest, error) {
+	if len(files) > MaxImpactFiles {
+		return nil, InvalidArgumentf("at most %d files may be given, got %d", MaxImpactFiles, len(files))
+	}
+	tags := fileTags(files)
+	if len(tags) == 0 {
+		return []*gql.FlakyTest{}, nil
+	}
+
+	query := `
+    WITH mapped AS (
+        SELECT DISTINCT spec_runs.spec_description
+        FROM spec_runs` + projectJoins + `
+        JOIN spec_run_tags ON spec_run_tags.spec_run_id = spec_runs.id
+        JOIN tags ON tags.id = spec_run_tags.tag_id
+        WHERE ` + projectMatch + `
+          AND tags.name = ANY($2)
+    )
+    SELECT
+        spec_runs.spec_description AS test_name,
+        COUNT(*) AS total_runs,
+        COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($4)) AS failure_count,
+        MAX(spec_runs.end_time) FILTER (WHERE NOT spec_runs.status = ANY($4)) AS last_failure,
+        array_agg(NOT spec_runs.status = ANY($4) ORDER BY spec_runs.start_time) AS outcomes,
+        (array_agg(test_runs.git_branch ORDER BY ` + lastFailureOrder + `)
+            FILTER (WHERE NOT spec_runs.status = ANY($4)))[1] AS last_failure_branch,
+        (array_agg(test_runs.git_sha ORDER BY ` + lastFailureOrder + `)
+            FILTER (WHERE NOT spec_runs.status = ANY($4)))[1] AS last_failure_sha
+    FROM spec_runs` + projectJoins + `
+    WHERE ` + projectMatch + `
+      AND spec_runs.spec_description IN (SELECT spec_description FROM mapped)
+      AND spec_runs.start_time >= NOW() - make_interval(days => $3)
+      AND NOT spec_runs.status = ANY($5)
+    GROUP BY spec_runs.spec_description
+    ORDER BY (COUNT(*) FILTER (WHERE NOT spec_runs.status = ANY($4)))::float / COUNT(*) DESC,
+        spec_runs.spec_description;
+	`
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := timedQuery(ctx, r.reader(), "tests_for_files", query, projectID, tags, DefaultSinceDays,
+		r.successStatuses, r.ignoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, err := scanFlakyTests(rows)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []*gql.FlakyTest{}
+	}
+	return results, rows.Err()
+}
+
+// fileTags turns file paths into the tag names that map specs to them,
+// dropping blanks and duplicates. A leading "./" is ignored so paths match
+// however the diff tool prints them.
+func fileTags(files []string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, file := range files {
+		file = strings.TrimPrefix(strings.TrimSpace(file), "./")
+		if file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		tags = append(tags, FileTagPrefix+file)
+	}
+	return tags
+}
@@ -0,0 +1,87 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakyTestRepo.GetTestsForFiles", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst *repo.FlakyTestRepo
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewFlakyTestRepo(fakeDB)
+	})
+
+	It("returns the specs tagged with the changed files", func() {
+		fakeDB.QueryReturns(&fakeRows{data: [][]any{
+			{"LoginService handles expired tokens", 10, 4, nil},
+			{"LoginService accepts valid tokens", 8, 0, nil},
+		}}, nil)
+
+		results, err := repoInst.GetTestsForFiles(ctx, "demo", []string{"pkg/auth/login.go", "pkg/auth/token.go"})
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].TestName).To(Equal("LoginService handles expired tokens"))
+		Expect(results[0].FailureRate).To(BeNumerically("~", 0.4, 0.001))
+		Expect(results[1].RunCount).To(Equal(8))
+
+		Expect(fakeDB.QueryCallCount()).To(Equal(1))
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("JOIN spec_run_tags ON spec_run_tags.spec_run_id = spec_runs.id"))
+		Expect(sql).To(ContainSubstring("tags.name = ANY($2)"))
+		Expect(args[:3]).To(Equal([]any{"demo", []string{"file:pkg/auth/login.go", "file:pkg/auth/token.go"}, repo.DefaultSinceDays}))
+	})
+
+	It("normalizes the file paths before matching tags", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		_, err := repoInst.GetTestsForFiles(ctx, "demo", []string{" ./pkg/auth/login.go", "pkg/auth/login.go", ""})
+		Expect(err).To(BeNil())
+
+		_, _, args := fakeDB.QueryArgsForCall(0)
+		Expect(args[1]).To(Equal([]string{"file:pkg/auth/login.go"}))
+	})
+
+	It("returns an empty list when no spec is mapped to the files", func() {
+		fakeDB.QueryReturns(&fakeRows{}, nil)
+
+		results, err := repoInst.GetTestsForFiles(ctx, "demo", []string{"README.md"})
+		Expect(err).To(BeNil())
+		Expect(results).NotTo(BeNil())
+		Expect(results).To(BeEmpty())
+	})
+
+	It("skips the query when no files are given", func() {
+		results, err := repoInst.GetTestsForFiles(ctx, "demo", []string{" "})
+		Expect(err).To(BeNil())
+		Expect(results).To(BeEmpty())
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("rejects too many files without querying", func() {
+		files := make([]string, repo.MaxImpactFiles+1)
+		_, err := repoInst.GetTestsForFiles(ctx, "demo", files)
+		Expect(err).To(MatchError("at most 1000 files may be given, got 1001"))
+		Expect(errors.Is(err, repo.ErrInvalidArgument)).To(BeTrue())
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("returns the query error", func() {
+		fakeDB.QueryReturns(nil, errors.New("boom"))
+
+		results, err := repoInst.GetTestsForFiles(ctx, "demo", []string{"pkg/auth/login.go"})
+		Expect(err).To(MatchError("boom"))
+		Expect(results).To(BeNil())
+	})
+})
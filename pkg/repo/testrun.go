@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+//go:generate counterfeiter -o fakes/fake_test_run_provider.go . TestRunProvider
+type TestRunProvider interface {
+	GetRecentTestRuns(ctx context.Context, projectID string, limit int) ([]*gql.TestRun, error)
+}
+
+type TestRunRepo struct {
+	db PgxQuerier
+}
+
+func NewTestRunRepo(db PgxQuerier) *TestRunRepo {
+	return &TestRunRepo{db: db}
+}
+
+// GetRecentTestRuns returns the latest test runs, newest first. An empty
+// projectID returns runs from every project.
+func (r *TestRunRepo) GetRecentTestRuns(ctx context.Context, projectID string, limit int) ([]*gql.TestRun, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+	query := `
+    SELECT
+        test_runs.id,
+        test_runs.git_branch,
+        test_runs.git_sha,
+        test_runs.build_trigger_actor,
+        test_runs.build_url,
+        test_runs.start_time,
+        test_runs.end_time
+    FROM test_runs
+    LEFT JOIN project_details ON test_runs.project_id = project_details.id
+    WHERE ($1 = '' OR ` + projectMatch + `)
+    ORDER BY test_runs.start_time DESC NULLS LAST, test_runs.id DESC
+    LIMIT $2;
+	`
+	rows, err := timedQuery(ctx, r.db, "recent_test_runs", query, projectID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*gql.TestRun
+
+	for rows.Next() {
+		var id int64
+		var startTime, endTime *time.Time
+		run := &gql.TestRun{}
+
+		if err := rows.Scan(&id, &run.GitBranch, &run.GitSha, &run.BuildTriggerActor, &run.BuildURL,
+			&startTime, &endTime); err != nil {
+			return nil, err
+		}
+
+		run.ID = strconv.FormatInt(id, 10)
+		run.StartTime = formatTime(startTime)
+		run.EndTime = formatTime(endTime)
+		results = append(results, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
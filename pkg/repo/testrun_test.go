@@ -0,0 +1,83 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("TestRunRepo", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.TestRunProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewTestRunRepo(fakeDB)
+	})
+
+	It("returns test runs with their metadata from fake rows", func() {
+		start := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+		mockRows := &fakeRows{
+			data: [][]any{
+				{int64(42), "main", "abc123", "tester", "https://ci.example.com/build/42", start, start.Add(time.Minute)},
+				{int64(41), nil, nil, nil, nil, nil, nil},
+			},
+		}
+
+		fakeDB.QueryReturns(mockRows, nil)
+
+		results, err := repoInst.GetRecentTestRuns(ctx, "policy-admin-ui", 2)
+		Expect(err).To(BeNil())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].ID).To(Equal("42"))
+		Expect(results[0].GitBranch).To(HaveValue(Equal("main")))
+		Expect(results[0].GitSha).To(HaveValue(Equal("abc123")))
+		Expect(results[0].BuildTriggerActor).To(HaveValue(Equal("tester")))
+		Expect(results[0].BuildURL).To(HaveValue(Equal("https://ci.example.com/build/42")))
+		Expect(results[0].StartTime).To(HaveValue(Equal("2025-04-01T10:00:00Z")))
+		Expect(results[0].EndTime).To(HaveValue(Equal("2025-04-01T10:01:00Z")))
+		Expect(results[1].ID).To(Equal("41"))
+		Expect(results[1].GitBranch).To(BeNil())
+		Expect(results[1].StartTime).To(BeNil())
+
+		_, sql, args := fakeDB.QueryArgsForCall(0)
+		Expect(sql).To(ContainSubstring("ORDER BY test_runs.start_time DESC"))
+		Expect(args).To(Equal([]any{"policy-admin-ui", 2}))
+	})
+
+	It("propagates query errors", func() {
+		fakeDB.QueryReturns(nil, errors.New("db down"))
+
+		results, err := repoInst.GetRecentTestRuns(ctx, "", 5)
+		Expect(err).To(MatchError("db down"))
+		Expect(results).To(BeNil())
+	})
+
+	It("propagates errors raised while reading rows", func() {
+		fakeDB.QueryReturns(&fakeRows{err: errors.New("connection reset")}, nil)
+
+		results, err := repoInst.GetRecentTestRuns(ctx, "", 5)
+		Expect(err).To(MatchError("connection reset"))
+		Expect(results).To(BeNil())
+	})
+
+	It("rejects limits outside the allowed range without querying", func() {
+		for _, limit := range []int{0, -5, repo.MaxLimit + 1} {
+			results, err := repoInst.GetRecentTestRuns(ctx, "policy-admin-ui", limit)
+			Expect(err).To(MatchError(ContainSubstring("limit must be between 1 and 1000")))
+			Expect(err).To(MatchError(repo.ErrInvalidArgument))
+			Expect(results).To(BeNil())
+		}
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+})
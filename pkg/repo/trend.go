@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/guidewire-oss/fern-mycelium/internal/gql"
+)
+
+// TrendBuckets lists the time buckets accepted by GetPassRateTrend.
+var TrendBuckets = []string{"day", "week"}
+
+//go:generate counterfeiter -o fakes/fake_trend_provider.go . TrendProvider
+type TrendProvider interface {
+	GetPassRateTrend(ctx context.Context, projectID, testName string, bucket string) ([]*gql.TrendPoint, error)
+}
+
+type TrendRepo struct {
+	db PgxQuerier
+}
+
+func NewTrendRepo(db PgxQuerier) *TrendRepo {
+	return &TrendRepo{db: db}
+}
+
+// GetPassRateTrend returns the pass rate of a single spec grouped into day or
+// week buckets, oldest bucket first.
+func (r *TrendRepo) GetPassRateTrend(ctx context.Context, projectID, testName string, bucket string) ([]*gql.TrendPoint, error) {
+	if !isTrendBucket(bucket) {
+		return nil, InvalidArgumentf("unsupported bucket %q: must be one of %v", bucket, TrendBuckets)
+	}
+
+	query := `
+    SELECT
+        date_trunc($3, spec_runs.start_time) AS period_start,
+        COUNT(*) AS run_count,
+        COUNT(*) FILTER (WHERE spec_runs.status = ANY($4)) AS pass_count
+    FROM spec_runs` + projectJoins + `
+    WHERE ` + projectMatch + `
+      AND spec_runs.spec_description = $2
+      AND spec_runs.start_time IS NOT NULL
+      AND NOT spec_runs.status = ANY($5)
+    GROUP BY period_start
+    ORDER BY period_start;
+	`
+	rows, err := timedQuery(ctx, r.db, "pass_rate_trend", query, projectID, testName, bucket, DefaultSuccessStatuses, DefaultIgnoredStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*gql.TrendPoint
+
+	for rows.Next() {
+		var periodStart time.Time
+		var runCount, passCount int
+
+		if err := rows.Scan(&periodStart, &runCount, &passCount); err != nil {
+			return nil, err
+		}
+
+		results = append(results, &gql.TrendPoint{
+			PeriodStart: periodStart.Format(time.RFC3339),
+			PassRate:    ratio(passCount, runCount),
+			RunCount:    runCount,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func isTrendBucket(bucket string) bool {
+	for _, b := range TrendBuckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo"
+	"github.com/guidewire-oss/fern-mycelium/pkg/repo/fakes"
+)
+
+var _ = Describe("TrendRepo", func() {
+	var (
+		ctx      context.Context
+		fakeDB   *fakes.FakePgxQuerier
+		repoInst repo.TrendProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeDB = &fakes.FakePgxQuerier{}
+		repoInst = repo.NewTrendRepo(fakeDB)
+	})
+
+	It("returns one trend point per bucket", func() {
+		mockRows := &fakeRows{
+			data: [][]any{
+				{time.Date(2025, 3, 24, 0, 0, 0, 0, time.UTC), 10, 9},
+				{time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), 8, 4},
+			},
+		}
+
+		fakeDB.QueryReturns(mockRows, nil)
+
+		points, err := repoInst.GetPassRateTrend(ctx, "policy-admin-ui", "LoginService handles expired tokens", "week")
+		Expect(err).To(BeNil())
+		Expect(points).To(HaveLen(2))
+		Expect(points[0].PeriodStart).To(Equal("2025-03-24T00:00:00Z"))
+		Expect(points[0].PassRate).To(BeNumerically("~", 0.9, 0.001))
+		Expect(points[1].PassRate).To(BeNumerically("~", 0.5, 0.001))
+		Expect(points[1].RunCount).To(Equal(8))
+
+		_, _, args := fakeDB.QueryArgsForCall(0)
+		Expect(args[:3]).To(Equal([]any{"policy-admin-ui", "LoginService handles expired tokens", "week"}))
+	})
+
+	It("rejects unsupported buckets without querying", func() {
+		points, err := repoInst.GetPassRateTrend(ctx, "policy-admin-ui", "LoginService handles expired tokens", "fortnight")
+		Expect(err).To(MatchError(ContainSubstring(`unsupported bucket "fortnight"`)))
+		Expect(points).To(BeNil())
+		Expect(fakeDB.QueryCallCount()).To(Equal(0))
+	})
+
+	It("propagates errors raised while reading rows", func() {
+		fakeDB.QueryReturns(&fakeRows{err: errors.New("connection reset")}, nil)
+
+		results, err := repoInst.GetPassRateTrend(ctx, "policy-admin-ui", "LoginService handles expired tokens", "week")
+		Expect(err).To(MatchError("connection reset"))
+		Expect(results).To(BeNil())
+	})
+})